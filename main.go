@@ -4,12 +4,23 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/api/rest/middleware"
+	"meeting-analyzer/server/commons/tenancy"
 	"meeting-analyzer/server/models/errorresponse"
+	"meeting-analyzer/server/services/authz"
+	"meeting-analyzer/server/services/credentials"
+	"meeting-analyzer/server/services/extractive"
+	"meeting-analyzer/server/services/jobs"
+	"meeting-analyzer/server/services/llm"
+	"meeting-analyzer/server/services/redaction"
 	"meeting-analyzer/server/services/service"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -34,13 +45,12 @@ func main() {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	ctx := context.Background()
-	//dbo, err := initializeDB(ctx, fetchPostgresConfig(ctx, constants.DatabaseName))
-	//
-	//if err != nil {
-	//	log.Fatal(ctx, nil, "", err, "failed to establish connection with database")
-	//}
+	dbo, err := initializeDB(ctx, fetchPostgresConfig(ctx, constants.DatabaseName))
+	if err != nil {
+		log.Fatal(ctx, nil, "", err, "failed to establish connection with database")
+	}
 
-	if err := runMain(ctx, c, nil); err != nil {
+	if err = runMain(ctx, c, dbo); err != nil {
 		log.Fatal(ctx, nil, "", err, "failed to start main process")
 	}
 }
@@ -50,16 +60,59 @@ func runMain(ctx context.Context, c chan os.Signal,
 	dbo interfaces.Database) error {
 	repo, err := db.NewRepository(dbo)
 	if err != nil {
-		log.Error(ctx, nil, "", err, "failed to init rules repo")
+		log.Error(ctx, nil, "", err, "failed to init meetings repo")
+		return err
+	}
+
+	apiKey, err := credentials.New(ctx, fetchCredentialsConfig(ctx))
+	if err != nil {
+		log.Error(ctx, nil, "", err, "failed to load the llm api key")
 		return err
 	}
 
-	svc, err := service.NewSvc(ctx, repo)
+	llmConfig, err := fetchLLMConfig(ctx, apiKey)
+	if err != nil {
+		log.Error(ctx, nil, "", err, "failed to load the llm config")
+		return err
+	}
+	openAIProvider, err := llm.NewOpenAIProvider(llmConfig)
+	if err != nil {
+		log.Error(ctx, nil, "", err, "failed to init llm provider")
+		return err
+	}
+	provider := llm.NewResilientProvider(openAIProvider, fetchResilienceConfig(ctx, llmConfig.Timeout))
+	if lookupBoolEnv(ctx, constants.EnvVarLLMExtractiveFallback, false) {
+		// the summaries are extracted offline while the model is unreachable
+		provider = llm.NewFallbackProvider(provider, extractive.NewProvider())
+	}
+
+	redactionConfig := fetchRedactionConfig(ctx)
+	if keyConfig, found := fetchRedactionKeyConfig(ctx); found {
+		key, err := credentials.New(ctx, keyConfig)
+		if err != nil {
+			log.Error(ctx, nil, "", err, "failed to load the redaction key")
+			return err
+		}
+		var previous credentials.Provider
+		if previousConfig, found := fetchPreviousRedactionKeysConfig(ctx); found {
+			if previous, err = credentials.New(ctx, previousConfig); err != nil {
+				log.Error(ctx, nil, "", err, "failed to load the previous redaction keys")
+				return err
+			}
+		}
+		if redactionConfig.Sealer, err = redaction.NewSealer(ctx, key, previous); err != nil {
+			log.Error(ctx, nil, "", err, "failed to init the redaction sealer")
+			return err
+		}
+	}
+
+	svc, err := service.NewSvc(ctx, repo, provider, fetchJobsConfig(ctx), redactionConfig)
 	if err != nil {
 		log.Error(ctx, nil, "", err, "failed to init service")
 		return err
 	}
-	server := CreateServer(ctx, svc)
+	// the permissions of the initiators on the meetings are checked in front of the service
+	server := CreateServer(ctx, authz.NewService(svc, repo), iamAuthentication)
 
 	go func() {
 		if err = server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
@@ -76,6 +129,9 @@ func runMain(ctx context.Context, c chan os.Signal,
 	if err = server.Shutdown(ctx); err != nil {
 		log.Error(ctx, nil, "", err, "Unable to gracefully shutdown")
 	}
+	if err = svc.Shutdown(ctx); err != nil {
+		log.Error(ctx, nil, "", err, "Unable to gracefully stop the summary jobs")
+	}
 	return err
 }
 
@@ -118,11 +174,212 @@ func fetchPostgresConfig(ctx context.Context, dbName string) *models.Config {
 	}
 }
 
+// fetchLLMConfig fetches the llm provider config values, the model has no default and is required
+func fetchLLMConfig(ctx context.Context, apiKey credentials.Provider) (llm.Config, error) {
+	baseURL, found := os.LookupEnv(constants.EnvVarLLMBaseURL)
+	if !found {
+		baseURL = constants.DefaultLLMBaseURL
+	}
+	model := strings.TrimSpace(os.Getenv(constants.EnvVarLLMModel))
+	if model == "" {
+		return llm.Config{}, fmt.Errorf("env var %s is required: it names the model of the llm provider",
+			constants.EnvVarLLMModel)
+	}
+	return llm.Config{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+		Model:   model,
+		Timeout: time.Duration(lookupPositiveIntEnv(ctx, constants.EnvVarLLMTimeout, constants.DefaultLLMTimeout)) * time.Second,
+		Limits: llm.Limits{
+			ChunkTokens:        lookupPositiveIntEnv(ctx, constants.EnvVarLLMChunkTokens, llm.DefaultChunkTokens),
+			ChunkOverlapTokens: lookupPositiveIntEnv(ctx, constants.EnvVarLLMChunkOverlapTokens, llm.DefaultChunkOverlapTokens),
+		},
+	}, nil
+}
+
+// fetchResilienceConfig fetches the retries and circuit breaker config values of the llm calls, each call is bounded
+// by the llm timeout
+func fetchResilienceConfig(ctx context.Context, attemptTimeout time.Duration) llm.ResilienceConfig {
+	return llm.ResilienceConfig{
+		MaxAttempts: lookupPositiveIntEnv(ctx, constants.EnvVarLLMMaxAttempts, llm.DefaultMaxAttempts),
+		InitialBackoff: time.Duration(lookupPositiveIntEnv(ctx, constants.EnvVarLLMInitialBackoff,
+			int(llm.DefaultInitialBackoff/time.Millisecond))) * time.Millisecond,
+		MaxBackoff: time.Duration(lookupPositiveIntEnv(ctx, constants.EnvVarLLMMaxBackoff,
+			int(llm.DefaultMaxBackoff/time.Second))) * time.Second,
+		AttemptTimeout:   attemptTimeout,
+		BreakerThreshold: lookupPositiveIntEnv(ctx, constants.EnvVarLLMBreakerThreshold, llm.DefaultBreakerThreshold),
+		BreakerCooldown: time.Duration(lookupPositiveIntEnv(ctx, constants.EnvVarLLMBreakerCooldown,
+			int(llm.DefaultBreakerCooldown/time.Second))) * time.Second,
+	}
+}
+
+// fetchCredentialsConfig fetches where the llm api key is loaded from, Vault when a secret path is set, else a
+// mounted secret file, else the env var
+func fetchCredentialsConfig(ctx context.Context) credentials.Config {
+	if secretPath, found := os.LookupEnv(constants.EnvVarVaultLLMSecretPath); found {
+		return credentials.Config{Vault: fetchVaultConfig(ctx, secretPath, os.Getenv(constants.EnvVarVaultLLMSecretKey))}
+	}
+	if file, found := os.LookupEnv(constants.EnvVarLLMAPIKeyFile); found {
+		return credentials.Config{File: file}
+	}
+	apiKey, found := os.LookupEnv(constants.EnvVarLLMAPIKey)
+	if !found {
+		log.Error(ctx, nil, "", errors.New("var not found"), constants.EnvVarLLMAPIKey)
+	}
+	return credentials.Config{APIKey: credentials.Static(apiKey)}
+}
+
+// fetchRedactionKeyConfig fetches where the key sealing the redacted values is loaded from, Vault when a secret path
+// is set, else a mounted secret file, else the env var. found is false when no key is configured, the redacted values
+// are then dropped.
+func fetchRedactionKeyConfig(ctx context.Context) (config credentials.Config, found bool) {
+	if secretPath, found := os.LookupEnv(constants.EnvVarVaultRedactionPath); found {
+		secretKey, found := os.LookupEnv(constants.EnvVarVaultRedactionKey)
+		if !found {
+			secretKey = constants.DefaultVaultRedactionKey
+		}
+		return credentials.Config{Vault: fetchVaultConfig(ctx, secretPath, secretKey)}, true
+	}
+	if file, found := os.LookupEnv(constants.EnvVarRedactionKeyFile); found {
+		return credentials.Config{File: file}, true
+	}
+	if key, found := os.LookupEnv(constants.EnvVarRedactionKey); found {
+		return credentials.Config{APIKey: credentials.Static(key)}, true
+	}
+	log.Error(ctx, nil, "", errors.New("var not found"), "%s, the redacted values are dropped",
+		constants.EnvVarRedactionKey)
+	return credentials.Config{}, false
+}
+
+// fetchPreviousRedactionKeysConfig fetches where the keys replaced by the redaction key are loaded from, separated by
+// commas or spaces: a field of the Vault secret of the key when named, else a mounted secret file, else the env var.
+// found is false when the key was never rotated, the values sealed with a removed key keep their placeholder.
+func fetchPreviousRedactionKeysConfig(ctx context.Context) (config credentials.Config, found bool) {
+	if secretPath, found := os.LookupEnv(constants.EnvVarVaultRedactionPath); found {
+		if secretKey, found := os.LookupEnv(constants.EnvVarVaultRedactionPrevious); found {
+			return credentials.Config{Vault: fetchVaultConfig(ctx, secretPath, secretKey)}, true
+		}
+	}
+	if file, found := os.LookupEnv(constants.EnvVarRedactionPreviousFile); found {
+		return credentials.Config{File: file}, true
+	}
+	if keys, found := os.LookupEnv(constants.EnvVarRedactionPreviousKeys); found {
+		return credentials.Config{APIKey: credentials.Static(keys)}, true
+	}
+	return credentials.Config{}, false
+}
+
+// fetchVaultConfig fetches the settings to read the secret at secretPath from Vault
+func fetchVaultConfig(ctx context.Context, secretPath, secretKey string) *credentials.VaultConfig {
+	return &credentials.VaultConfig{
+		Address:      os.Getenv(constants.EnvVarVaultAddr),
+		RoleID:       os.Getenv(constants.EnvVarVaultRoleID),
+		SecretID:     credentials.Static(os.Getenv(constants.EnvVarVaultSecretID)),
+		SecretIDFile: os.Getenv(constants.EnvVarVaultSecretIDFile),
+		AuthMount:    os.Getenv(constants.EnvVarVaultAuthMount),
+		KVMount:      os.Getenv(constants.EnvVarVaultKVMount),
+		SecretPath:   secretPath,
+		SecretKey:    secretKey,
+		RefreshInterval: time.Duration(lookupPositiveIntEnv(ctx, constants.EnvVarVaultRefresh,
+			constants.DefaultVaultRefresh)) * time.Second,
+	}
+}
+
+// fetchJobsConfig fetches the summary worker pool config values
+func fetchJobsConfig(ctx context.Context) jobs.Config {
+	// the host name, which is the pod name, identifies the instance across its restarts
+	instanceID, found := os.LookupEnv(constants.EnvVarSummaryInstanceID)
+	if !found {
+		hostname, err := os.Hostname()
+		if err != nil {
+			log.Error(ctx, nil, "", err, "failed to read the host name, the summary jobs are owned by a random instance id")
+		}
+		instanceID = hostname
+	}
+	return jobs.Config{
+		Workers:    lookupPositiveIntEnv(ctx, constants.EnvVarSummaryWorkers, constants.DefaultSummaryWorkers),
+		QueueSize:  lookupPositiveIntEnv(ctx, constants.EnvVarSummaryQueueSize, constants.DefaultSummaryQueueSize),
+		InstanceID: instanceID,
+		Lease: time.Duration(lookupPositiveIntEnv(ctx, constants.EnvVarSummaryJobLease,
+			constants.DefaultSummaryJobLease)) * time.Second,
+	}
+}
+
+// fetchRedactionConfig fetches the categories of the values redacted from the transcripts before they are summarized,
+// all of them when the env var is unset or invalid
+func fetchRedactionConfig(ctx context.Context) redaction.Config {
+	value, found := os.LookupEnv(constants.EnvVarRedactionCategories)
+	if !found {
+		return redaction.Config{Categories: redaction.Categories}
+	}
+	categories, err := redaction.ParseCategories(value)
+	if err != nil {
+		log.Error(ctx, nil, "", err, "invalid %s value %q, redacting all categories", constants.EnvVarRedactionCategories,
+			value)
+		return redaction.Config{Categories: redaction.Categories}
+	}
+	return redaction.Config{Categories: categories}
+}
+
+// lookupPositiveIntEnv returns the value of the env var, or defaultValue when it is unset or invalid
+func lookupPositiveIntEnv(ctx context.Context, name string, defaultValue int) int {
+	value, found := os.LookupEnv(name)
+	if !found {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Error(ctx, nil, "", err, "invalid %s value %q, using default", name, value)
+		return defaultValue
+	}
+	return parsed
+}
+
+// lookupBoolEnv returns the value of the env var, or defaultValue when it is unset or invalid
+func lookupBoolEnv(ctx context.Context, name string, defaultValue bool) bool {
+	value, found := os.LookupEnv(name)
+	if !found {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Error(ctx, nil, "", err, "invalid %s value %q, using default", name, value)
+		return defaultValue
+	}
+	return parsed
+}
+
+// authentication authenticates the requests and identifies their tenant
+type authentication struct {
+	// middleware authenticates the requests and stores the identity of their caller in their context
+	middleware mux.MiddlewareFunc
+	// identify reads the identity stored by middleware
+	identify middleware.Identify
+}
+
+// iamAuthentication identifies the tenants with the estate, initiator, groups and roles authenticated by the IAM library
+var iamAuthentication = authentication{
+	middleware: authcontextsvc.EnrichContextWithEstateInitiatorCtxMiddleware,
+	identify:   iamTenant,
+}
+
+// iamTenant returns the estate, initiator, groups and roles stored in ctx by the IAM middleware. There is no tenant
+// when the middleware stored no identity, or one without estate or initiator.
+func iamTenant(ctx context.Context) (tenancy.Tenant, bool) {
+	identity, err := authcontextsvc.GetEstateInitiatorCtx(ctx)
+	if err != nil || identity == nil || identity.EstateID == "" || identity.InitiatorID == "" {
+		return tenancy.Tenant{}, false
+	}
+	return tenancy.Tenant{EstateID: identity.EstateID, InitiatorID: identity.InitiatorID, Groups: identity.Groups,
+		Roles: identity.Roles}, true
+}
+
 // CreateServer adds the health livenesss and health dependencies
-func CreateServer(ctx context.Context, svc service.Service) *HTTPServer {
+func CreateServer(ctx context.Context, svc service.Service, auth authentication) *HTTPServer {
 	router := mux.NewRouter()
 	router.Use(log.AddCorrelationIDMiddleware)
-	router.Use(authcontextsvc.EnrichContextWithEstateInitiatorCtxMiddleware)
+	router.Use(auth.middleware)
+	router.Use(middleware.NewTenant(auth.identify))
 	RegisterOpenAPIHandler(ctx, router, svc)
 	return NewHTTPServer(":"+GetPort(), router)
 }
@@ -134,6 +391,12 @@ func RegisterOpenAPIHandler(ctx context.Context, router *mux.Router, svc service
 	}
 	// This lines removes server name validation
 	swagger.Servers = nil
+	validator, err := middleware.NewRequestValidator(swagger)
+	if err != nil {
+		log.Fatal(ctx, nil, "", err, "error creating the request validator")
+	}
+	router.Use(validator)
+	router.Use(middleware.Accept)
 
 	handler := generated.NewStrictHandlerWithOptions(controller.NewController(svc), nil, errorresponse.StrictHTTPServerOptions)
 	generated.HandlerFromMux(handler, router)
@@ -0,0 +1,603 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/constants"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/commons/utils"
+	"meeting-analyzer/server/repositories/repotest"
+	"meeting-analyzer/server/services/authz"
+	"meeting-analyzer/server/services/credentials"
+	"meeting-analyzer/server/services/extractive"
+	"meeting-analyzer/server/services/jobs"
+	"meeting-analyzer/server/services/llm"
+	"meeting-analyzer/server/services/llm/llmtest"
+	"meeting-analyzer/server/services/redaction"
+	"meeting-analyzer/server/services/service"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	summaryPath = "/api/meetings/summary"
+	testModel   = "test-model"
+)
+
+const summaryReply = `{"summary":"The team agreed to ship the release on Monday.",` +
+	`"key_points":["The release is ready","QA signed off"],"decisions":["Ship on Monday"],` +
+	`"action_items":[{"description":"Write the release notes","owner":"Bob","due_date":null,` +
+	`"due_phrase":"by Friday","quote":"I'll write the release notes by Friday"}]}`
+
+// testTenant sends the requests of the end-to-end tests, tenantContext reads its rows from the repository
+var (
+	testTenant    = tenancy.Tenant{EstateID: "estate-1", InitiatorID: "user-1"}
+	tenantContext = tenancy.NewContext(context.Background(), testTenant)
+)
+
+var transcription = []generated.MemberTranscription{
+	{MemberName: "Alice", Timestamp: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+		Content: "The release is ready and QA signed off. Let's ship it on Monday."},
+	{MemberName: "Bob", Timestamp: time.Date(2024, 1, 2, 10, 1, 0, 0, time.UTC),
+		Content: "Agreed. I'll write the release notes by Friday."},
+}
+
+// newTestServer serves the API like the main process, with an in-memory repository and the provider, to the
+// requests authenticated by testAuthentication
+func newTestServer(t *testing.T, provider llm.LLMProvider) (*httptest.Server, *repotest.Repository) {
+	return newAuthenticatedServer(t, provider, testAuthentication)
+}
+
+// newAuthenticatedServer serves the API like the main process, with an in-memory repository and the provider, to the
+// requests authenticated by auth
+func newAuthenticatedServer(t *testing.T, provider llm.LLMProvider, auth authentication) (*httptest.Server,
+	*repotest.Repository) {
+	ctx := context.Background()
+	repo := repotest.NewRepository()
+	sealer, err := redaction.NewSealer(ctx,
+		credentials.Static(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", redaction.KeySize)))), nil)
+	require.NoError(t, err)
+	svc, err := service.NewSvc(ctx, repo, provider, jobs.Config{Workers: 2, QueueSize: 10},
+		redaction.Config{Categories: redaction.Categories, Sealer: sealer})
+	require.NoError(t, err)
+	server := httptest.NewServer(CreateServer(ctx, authz.NewService(svc, repo), auth).Handler)
+	t.Cleanup(func() {
+		server.Close()
+		assert.NoError(t, svc.Shutdown(ctx))
+	})
+	return server, repo
+}
+
+// newLLMProvider returns an OpenAI provider calling the fake llm server
+func newLLMProvider(t *testing.T, fake *llmtest.Server) llm.LLMProvider {
+	provider, err := llm.NewOpenAIProvider(llm.Config{
+		BaseURL: fake.URL,
+		APIKey:  credentials.Static("test-key"),
+		Model:   testModel,
+		Timeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+// testTenantHeader carries the tenant of the test requests to testAuthentication, in place of the credentials
+// verified by the IAM library
+const testTenantHeader = "X-Test-Tenant"
+
+type testTenantKey struct{}
+
+// testAuthentication trusts the tenant encoded in testTenantHeader
+var testAuthentication = authentication{
+	middleware: func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var tenant tenancy.Tenant
+			if err := json.Unmarshal([]byte(r.Header.Get(testTenantHeader)), &tenant); err == nil {
+				r = r.WithContext(context.WithValue(r.Context(), testTenantKey{}, tenant))
+			}
+			next.ServeHTTP(w, r)
+		})
+	},
+	identify: func(ctx context.Context) (tenancy.Tenant, bool) {
+		tenant, ok := ctx.Value(testTenantKey{}).(tenancy.Tenant)
+		return tenant, ok
+	},
+}
+
+// send sends the request on behalf of the tenant, as authenticated by testAuthentication
+func send(t *testing.T, tenant tenancy.Tenant, req *http.Request) *http.Response {
+	if tenant.EstateID != "" {
+		encoded, err := json.Marshal(tenant)
+		require.NoError(t, err)
+		req.Header.Set(testTenantHeader, string(encoded))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+// requestSummary asks for the summary of the meeting on behalf of the tenant
+func requestSummary(t *testing.T, server *httptest.Server, tenant tenancy.Tenant, meetingID string,
+	engine *generated.SummaryEngineEnum) *http.Response {
+	body, err := json.Marshal(generated.GenerateMeetingSummaryRequest{
+		MeetingId:     meetingID,
+		MeetingTitle:  "Release sync",
+		SummaryEngine: engine,
+		Transcription: transcription,
+	})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, server.URL+summaryPath, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	return send(t, tenant, req)
+}
+
+func postSummary(t *testing.T, server *httptest.Server, meetingID string, engine *generated.SummaryEngineEnum) generated.GenerateMeetingSummaryResponse {
+	resp := requestSummary(t, server, testTenant, meetingID, engine)
+	return decodeResponse[generated.GenerateMeetingSummaryResponse](t, resp, http.StatusAccepted)
+}
+
+func get(t *testing.T, server *httptest.Server, tenant tenancy.Tenant, path string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	require.NoError(t, err)
+	return send(t, tenant, req)
+}
+
+// do sends the request with the JSON body, when not nil, on behalf of the tenant
+func do(t *testing.T, server *httptest.Server, tenant tenancy.Tenant, method string, path string, body any) *http.Response {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequest(method, server.URL+path, reader)
+	require.NoError(t, err)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return send(t, tenant, req)
+}
+
+// errorCode returns the code of the error response, once checked its status
+func errorCode(t *testing.T, resp *http.Response, expectedStatus int) string {
+	return *(*decodeResponse[generated.ErrorResponse](t, resp, expectedStatus).Messages)[0].Code
+}
+
+func getSummary(t *testing.T, server *httptest.Server, meetingID string) generated.MeetingSummary {
+	resp := get(t, server, testTenant, summaryPath+"/"+meetingID)
+	return decodeResponse[generated.MeetingSummary](t, resp, http.StatusOK)
+}
+
+// waitForSummary polls the summary of the meeting until its job is done or failed
+func waitForSummary(t *testing.T, server *httptest.Server, meetingID string) generated.MeetingSummary {
+	var summary generated.MeetingSummary
+	require.Eventually(t, func() bool {
+		summary = getSummary(t, server, meetingID)
+		return summary.Status == generated.DONE || summary.Status == generated.FAILED
+	}, 10*time.Second, 10*time.Millisecond)
+	return summary
+}
+
+func decodeResponse[T any](t *testing.T, resp *http.Response, expectedStatus int) T {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, expectedStatus, resp.StatusCode, string(body))
+	var value T
+	require.NoError(t, json.Unmarshal(body, &value))
+	return value
+}
+
+func TestEndToEnd_GenerateMeetingSummary(t *testing.T) {
+	fake := llmtest.NewServer(t)
+	fake.Handle(func(llmtest.Request) llmtest.Reply { return llmtest.Reply{Content: summaryReply} })
+	server, repo := newTestServer(t, newLLMProvider(t, fake))
+
+	accepted := postSummary(t, server, "meeting-1", nil)
+	summary := waitForSummary(t, server, "meeting-1")
+
+	assert.Equal(t, "meeting-1", accepted.MeetingId)
+	require.Equal(t, generated.DONE, summary.Status, utils.GetPtrValue(summary.Error, ""))
+	assert.Equal(t, "The team agreed to ship the release on Monday.", *summary.Abstract)
+	assert.Equal(t, []string{"The release is ready", "QA signed off"}, summary.KeyPoints)
+	assert.Equal(t, []string{"Ship on Monday"}, summary.Decisions)
+	assert.Equal(t, []string{"Alice", "Bob"}, summary.Participants)
+	assert.Equal(t, testModel, *summary.Model)
+	assert.Equal(t, 1, *summary.PromptVersion)
+
+	stored, err := repo.GetSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, "The team agreed to ship the release on Monday.", stored.Content)
+	assert.Equal(t, "default", stored.PromptTemplate)
+	items, err := repo.ListActionItems(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Bob", *items[0].Owner)
+	assert.Equal(t, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), *items[0].DueDate)
+	require.NotNil(t, items[0].Segment)
+	assert.Equal(t, "Bob", items[0].Segment.MemberName)
+
+	requests := fake.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, testModel, requests[0].Model)
+	assert.Equal(t, "Bearer test-key", requests[0].Authorization)
+	assert.Contains(t, requests[0].Message(llm.RoleUser), "Let's ship it on Monday.")
+	assert.NotEmpty(t, requests[0].ResponseFormat)
+}
+
+func TestEndToEnd_ImportMeetingTranscript(t *testing.T) {
+	fake := llmtest.NewServer(t)
+	fake.Handle(func(llmtest.Request) llmtest.Reply { return llmtest.Reply{Content: summaryReply} })
+	server, _ := newTestServer(t, newLLMProvider(t, fake))
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	require.NoError(t, form.WriteField("meeting_id", "meeting-2"))
+	require.NoError(t, form.WriteField("meeting_title", "Release sync"))
+	require.NoError(t, form.WriteField("summary_style", "executive_brief"))
+	file, err := form.CreateFormFile("file", "meeting.vtt")
+	require.NoError(t, err)
+	_, err = file.Write([]byte("WEBVTT\n\n00:00:01.000 --> 00:00:04.000\n<v Alice>Let's ship it on Monday.</v>\n\n" +
+		"00:00:05.000 --> 00:00:08.000\n<v Bob>I'll write the release notes by Friday.</v>\n"))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+	req, err := http.NewRequest(http.MethodPost, server.URL+summaryPath+"/import", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	decodeResponse[generated.GenerateMeetingSummaryResponse](t, send(t, testTenant, req), http.StatusAccepted)
+
+	summary := waitForSummary(t, server, "meeting-2")
+
+	require.Equal(t, generated.DONE, summary.Status, utils.GetPtrValue(summary.Error, ""))
+	assert.Equal(t, []string{"Alice", "Bob"}, summary.Participants)
+	assert.Equal(t, generated.SummaryStyleEnum("executive_brief"), *summary.SummaryStyle)
+	assert.Contains(t, fake.Requests()[0].Message(llm.RoleUser), "I'll write the release notes by Friday.")
+}
+
+func TestEndToEnd_RetriesTransientFailures(t *testing.T) {
+	fake := llmtest.NewServer(t)
+	fake.Enqueue(
+		llmtest.Reply{Status: http.StatusServiceUnavailable},
+		llmtest.Reply{Status: http.StatusBadGateway, RetryAfter: "0"},
+		llmtest.Reply{Content: summaryReply, Delay: 20 * time.Millisecond},
+	)
+	provider := llm.NewResilientProvider(newLLMProvider(t, fake), llm.ResilienceConfig{MaxAttempts: 3,
+		InitialBackoff: time.Millisecond})
+	server, _ := newTestServer(t, provider)
+
+	postSummary(t, server, "meeting-3", nil)
+	summary := waitForSummary(t, server, "meeting-3")
+
+	require.Equal(t, generated.DONE, summary.Status, utils.GetPtrValue(summary.Error, ""))
+	assert.Len(t, fake.Requests(), 3)
+}
+
+func TestEndToEnd_FailedSummary(t *testing.T) {
+	fake := llmtest.NewServer(t)
+	fake.Handle(func(llmtest.Request) llmtest.Reply {
+		return llmtest.Reply{Status: http.StatusBadRequest, Body: `{"error":{"message":"context too long"}}`}
+	})
+	server, repo := newTestServer(t, newLLMProvider(t, fake))
+
+	postSummary(t, server, "meeting-4", nil)
+	summary := waitForSummary(t, server, "meeting-4")
+
+	assert.Equal(t, generated.FAILED, summary.Status)
+	require.NotNil(t, summary.Error)
+	assert.Nil(t, summary.Abstract)
+	_, err := repo.GetSummary(tenantContext, "meeting-4")
+	assert.Error(t, err)
+}
+
+func TestEndToEnd_ExtractiveSummaries(t *testing.T) {
+	extractiveEngine := generated.Extractive
+	tests := []struct {
+		name     string
+		provider func(fake *llmtest.Server) llm.LLMProvider
+		engine   *generated.SummaryEngineEnum
+		calls    int
+	}{
+		{name: "Engine", engine: &extractiveEngine, calls: 0,
+			provider: func(fake *llmtest.Server) llm.LLMProvider { return newLLMProvider(t, fake) }},
+		{name: "Fallback", calls: 1, provider: func(fake *llmtest.Server) llm.LLMProvider {
+			return llm.NewFallbackProvider(newLLMProvider(t, fake), extractive.NewProvider())
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := llmtest.NewServer(t)
+			fake.Handle(func(llmtest.Request) llmtest.Reply { return llmtest.Reply{Status: http.StatusBadGateway} })
+			server, _ := newTestServer(t, tt.provider(fake))
+
+			postSummary(t, server, "meeting-5", tt.engine)
+			summary := waitForSummary(t, server, "meeting-5")
+
+			require.Equal(t, generated.DONE, summary.Status, utils.GetPtrValue(summary.Error, ""))
+			assert.Equal(t, extractive.Model, *summary.Model)
+			assert.NotEmpty(t, *summary.Abstract)
+			assert.Len(t, fake.Requests(), tt.calls)
+		})
+	}
+}
+
+func TestEndToEnd_StreamMeetingSummary(t *testing.T) {
+	fake := llmtest.NewServer(t)
+	release := make(chan struct{})
+	fake.Handle(func(llmtest.Request) llmtest.Reply {
+		// the answer waits for the client to subscribe to the events of the meeting
+		<-release
+		return llmtest.Reply{Content: summaryReply}
+	})
+	server, _ := newTestServer(t, newLLMProvider(t, fake))
+	postSummary(t, server, "meeting-6", nil)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+summaryPath+"/meeting-6", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	resp := send(t, testTenant, req)
+	defer resp.Body.Close()
+	close(release)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	names := make([]string, 0)
+	deltas := strings.Builder{}
+	var last generated.MeetingSummary
+	for _, event := range strings.Split(strings.TrimSpace(string(body)), "\n\n") {
+		name, data, found := strings.Cut(event, "\ndata: ")
+		require.True(t, found, event)
+		name = strings.TrimPrefix(name, "event: ")
+		names = append(names, name)
+		switch name {
+		case "delta":
+			var delta generated.SummaryDelta
+			require.NoError(t, json.Unmarshal([]byte(data), &delta))
+			deltas.WriteString(delta.Content)
+		case "summary":
+			require.NoError(t, json.Unmarshal([]byte(data), &last))
+		}
+	}
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "summary", names[0])
+	assert.Contains(t, names, "delta")
+	assert.Equal(t, summaryReply, deltas.String())
+	assert.Equal(t, generated.DONE, last.Status)
+	assert.Equal(t, "The team agreed to ship the release on Monday.", *last.Abstract)
+}
+
+func TestEndToEnd_TenantIsolation(t *testing.T) {
+	fake := llmtest.NewServer(t)
+	fake.Handle(func(llmtest.Request) llmtest.Reply { return llmtest.Reply{Content: summaryReply} })
+	server, repo := newTestServer(t, newLLMProvider(t, fake))
+	other := tenancy.Tenant{EstateID: "estate-2", InitiatorID: "user-2"}
+	postSummary(t, server, "meeting-7", nil)
+	waitForSummary(t, server, "meeting-7")
+
+	notFound := decodeResponse[generated.ErrorResponse](t, get(t, server, other, summaryPath+"/meeting-7"),
+		http.StatusNotFound)
+	actionItems := decodeResponse[generated.ErrorResponse](t,
+		get(t, server, other, "/api/meetings/meeting-7/action-items"), http.StatusNotFound)
+	listed := decodeResponse[[]generated.MeetingSummary](t, get(t, server, other, summaryPath), http.StatusOK)
+	unauthenticated := decodeResponse[generated.ErrorResponse](t,
+		get(t, server, tenancy.Tenant{}, summaryPath+"/meeting-7"), http.StatusUnauthorized)
+
+	assert.Equal(t, "MEETING_NOT_FOUND", *(*notFound.Messages)[0].Code)
+	assert.Equal(t, "MEETING_NOT_FOUND", *(*actionItems.Messages)[0].Code)
+	assert.Empty(t, listed)
+	assert.Equal(t, "UNAUTHENTICATED", *(*unauthenticated.Messages)[0].Code)
+	assert.Len(t, decodeResponse[[]generated.MeetingSummary](t, get(t, server, testTenant, summaryPath), http.StatusOK), 1)
+
+	// the estates have their own meeting ids, the other estate creates its own meeting-7
+	decodeResponse[generated.GenerateMeetingSummaryResponse](t, requestSummary(t, server, other, "meeting-7", nil),
+		http.StatusAccepted)
+	meeting, err := repo.GetMeeting(tenantContext, "meeting-7")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"estate-1", "user-1"}, []string{meeting.EstateID, meeting.CreatedBy})
+	otherMeeting, err := repo.GetMeeting(tenancy.NewContext(context.Background(), other), "meeting-7")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"estate-2", "user-2"}, []string{otherMeeting.EstateID, otherMeeting.CreatedBy})
+}
+
+func TestIAMAuthentication(t *testing.T) {
+	server, _ := newAuthenticatedServer(t, newLLMProvider(t, llmtest.NewServer(t)), iamAuthentication)
+
+	// the chain of the main process answers 401 without IAM identity, whatever the client headers claim
+	req, err := http.NewRequest(http.MethodGet, server.URL+summaryPath, nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Estate-Id", "estate-1")
+	req.Header.Set("X-Initiator-Id", "user-1")
+	req.Header.Set("X-Initiator-Roles", tenancy.RoleAdmin)
+	encoded, err := json.Marshal(testTenant)
+	require.NoError(t, err)
+	req.Header.Set(testTenantHeader, string(encoded))
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "UNAUTHENTICATED", errorCode(t, resp, http.StatusUnauthorized))
+}
+
+func TestIAMTenant_NoIdentity(t *testing.T) {
+	tenant, ok := iamTenant(context.Background())
+
+	assert.False(t, ok)
+	assert.Equal(t, tenancy.Tenant{}, tenant)
+}
+
+func TestEndToEnd_AccessControl(t *testing.T) {
+	fake := llmtest.NewServer(t)
+	fake.Handle(func(llmtest.Request) llmtest.Reply { return llmtest.Reply{Content: summaryReply} })
+	server, _ := newTestServer(t, newLLMProvider(t, fake))
+	stranger := tenancy.Tenant{EstateID: "estate-1", InitiatorID: "user-3"}
+	member := tenancy.Tenant{EstateID: "estate-1", InitiatorID: "user-4", Groups: []string{"team"}}
+	// bob is the member name of a speaker of the transcription
+	speaker := tenancy.Tenant{EstateID: "estate-1", InitiatorID: "bob"}
+	admin := tenancy.Tenant{EstateID: "estate-1", InitiatorID: "user-5", Roles: []string{tenancy.RoleAdmin}}
+	meetingPath := summaryPath + "/meeting-8"
+	sharesPath := "/api/meetings/meeting-8/shares"
+	postSummary(t, server, "meeting-8", nil)
+	waitForSummary(t, server, "meeting-8")
+
+	// only the owner and the admins see the meeting until it is shared, naming a speaker grants nothing
+	assert.Equal(t, "FORBIDDEN", errorCode(t, get(t, server, stranger, meetingPath), http.StatusForbidden))
+	assert.Equal(t, "FORBIDDEN", errorCode(t, get(t, server, member, meetingPath), http.StatusForbidden))
+	assert.Empty(t, decodeResponse[[]generated.MeetingSummary](t, get(t, server, member, summaryPath), http.StatusOK))
+	assert.Equal(t, "FORBIDDEN", errorCode(t, get(t, server, speaker, meetingPath), http.StatusForbidden))
+	assert.Empty(t, decodeResponse[[]generated.MeetingSummary](t, get(t, server, speaker, summaryPath), http.StatusOK))
+	decodeResponse[generated.MeetingSummary](t, get(t, server, admin, meetingPath), http.StatusOK)
+	// the roles only come from the authentication
+	req, err := http.NewRequest(http.MethodGet, server.URL+meetingPath, nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Initiator-Roles", tenancy.RoleAdmin)
+	assert.Equal(t, "FORBIDDEN", errorCode(t, send(t, stranger, req), http.StatusForbidden))
+	assert.Equal(t, "FORBIDDEN", errorCode(t, requestSummary(t, server, speaker, "meeting-8", nil),
+		http.StatusForbidden))
+	assert.Equal(t, "FORBIDDEN", errorCode(t, do(t, server, speaker, http.MethodPost, sharesPath,
+		generated.ShareMeetingRequest{PrincipalType: generated.Group, PrincipalId: "team"}), http.StatusForbidden))
+
+	share := decodeResponse[generated.MeetingShare](t, do(t, server, testTenant, http.MethodPost, sharesPath,
+		generated.ShareMeetingRequest{PrincipalType: generated.Group, PrincipalId: "team"}), http.StatusCreated)
+	assert.Equal(t, "user-1", share.CreatedBy)
+	shares := decodeResponse[[]generated.MeetingShare](t, get(t, server, testTenant, sharesPath), http.StatusOK)
+	assert.Equal(t, []generated.MeetingShare{share}, shares)
+
+	// the group reads the meeting but only the owner and the admins update its action items
+	decodeResponse[generated.MeetingSummary](t, get(t, server, member, meetingPath), http.StatusOK)
+	assert.Len(t, decodeResponse[[]generated.MeetingSummary](t, get(t, server, member, summaryPath), http.StatusOK), 1)
+	items := decodeResponse[[]generated.MeetingActionItem](t,
+		get(t, server, member, "/api/meetings/meeting-8/action-items"), http.StatusOK)
+	require.Len(t, items, 1)
+	itemPath := "/api/meetings/meeting-8/action-items/" + items[0].Id
+	assert.Equal(t, "FORBIDDEN", errorCode(t, do(t, server, member, http.MethodPatch, itemPath,
+		generated.UpdateActionItemRequest{Done: true}), http.StatusForbidden))
+	item := decodeResponse[generated.MeetingActionItem](t, do(t, server, testTenant, http.MethodPatch, itemPath,
+		generated.UpdateActionItemRequest{Done: true}), http.StatusOK)
+	assert.True(t, item.Done)
+	assert.Equal(t, "FORBIDDEN", errorCode(t, do(t, server, member, http.MethodDelete, meetingPath, nil),
+		http.StatusForbidden))
+
+	resp := do(t, server, testTenant, http.MethodDelete, sharesPath+"/group/team", nil)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "SHARE_NOT_FOUND", errorCode(t, do(t, server, testTenant, http.MethodDelete,
+		sharesPath+"/group/team", nil), http.StatusNotFound))
+	assert.Equal(t, "FORBIDDEN", errorCode(t, get(t, server, member, meetingPath), http.StatusForbidden))
+
+	resp = do(t, server, admin, http.MethodDelete, meetingPath, nil)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "MEETING_NOT_FOUND", errorCode(t, get(t, server, testTenant, meetingPath), http.StatusNotFound))
+}
+
+func TestEndToEnd_ParticipantAccess(t *testing.T) {
+	fake := llmtest.NewServer(t)
+	fake.Handle(func(llmtest.Request) llmtest.Reply { return llmtest.Reply{Content: summaryReply} })
+	server, _ := newTestServer(t, newLLMProvider(t, fake))
+	participant := tenancy.Tenant{EstateID: "estate-1", InitiatorID: "user-6"}
+	meetingPath := summaryPath + "/meeting-10"
+	withMembers := slices.Clone(transcription)
+	withMembers[1].MemberId = utils.ToPointer(participant.InitiatorID)
+	decodeResponse[generated.GenerateMeetingSummaryResponse](t, do(t, server, testTenant, http.MethodPost, summaryPath,
+		generated.GenerateMeetingSummaryRequest{MeetingId: "meeting-10", MeetingTitle: "Release sync",
+			Transcription: withMembers}), http.StatusAccepted)
+	waitForSummary(t, server, "meeting-10")
+
+	// the participant reads the meeting through the share stored with it, but neither regenerates nor deletes it
+	decodeResponse[generated.MeetingSummary](t, get(t, server, participant, meetingPath), http.StatusOK)
+	assert.Len(t, decodeResponse[[]generated.MeetingSummary](t, get(t, server, participant, summaryPath), http.StatusOK), 1)
+	shares := decodeResponse[[]generated.MeetingShare](t, get(t, server, testTenant, "/api/meetings/meeting-10/shares"),
+		http.StatusOK)
+	require.Len(t, shares, 1)
+	assert.Equal(t, []string{"user", "user-6", "user-1"}, []string{string(shares[0].PrincipalType),
+		shares[0].PrincipalId, shares[0].CreatedBy})
+	assert.Equal(t, "FORBIDDEN", errorCode(t, requestSummary(t, server, participant, "meeting-10", nil),
+		http.StatusForbidden))
+	assert.Equal(t, "FORBIDDEN", errorCode(t, do(t, server, participant, http.MethodDelete, meetingPath, nil),
+		http.StatusForbidden))
+}
+
+func TestEndToEnd_Redaction(t *testing.T) {
+	fake := llmtest.NewServer(t)
+	fake.Handle(func(llmtest.Request) llmtest.Reply {
+		return llmtest.Reply{Content: `{"summary":"Bob will send the release notes to [EMAIL_1].",` +
+			`"key_points":["The staging server is [IP_ADDRESS_1]"],"decisions":[],` +
+			`"action_items":[{"description":"Send the release notes to [EMAIL_1]","owner":"Bob","due_date":null,` +
+			`"quote":"I'll send them to [EMAIL_1]"}]}`}
+	})
+	server, repo := newTestServer(t, newLLMProvider(t, fake))
+	member := tenancy.Tenant{EstateID: "estate-1", InitiatorID: "user-4", Groups: []string{"team"}}
+	meetingPath := summaryPath + "/meeting-9"
+	resp := do(t, server, testTenant, http.MethodPost, summaryPath, generated.GenerateMeetingSummaryRequest{
+		MeetingId:    "meeting-9",
+		MeetingTitle: "Release sync",
+		Transcription: []generated.MemberTranscription{
+			{MemberName: "Alice", Timestamp: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC),
+				Content: "Staging runs on 10.20.30.40, the notes go to carol@example.com."},
+			{MemberName: "Bob", Timestamp: time.Date(2024, 1, 2, 10, 1, 0, 0, time.UTC),
+				Content: "I'll send them to carol@example.com."},
+		},
+	})
+	decodeResponse[generated.GenerateMeetingSummaryResponse](t, resp, http.StatusAccepted)
+	owned := waitForSummary(t, server, "meeting-9")
+	decodeResponse[generated.MeetingShare](t, do(t, server, testTenant, http.MethodPost, "/api/meetings/meeting-9/shares",
+		generated.ShareMeetingRequest{PrincipalType: generated.Group, PrincipalId: "team"}), http.StatusCreated)
+
+	// the model never receives the values, the owner reads them and the group the placeholders
+	requests := fake.Requests()
+	require.Len(t, requests, 1)
+	assert.NotContains(t, requests[0].Message("user"), "carol@example.com")
+	assert.NotContains(t, requests[0].Message("user"), "10.20.30.40")
+	assert.Contains(t, requests[0].Message("user"), "the notes go to [EMAIL_1]")
+	// nor are they stored in clear
+	key := repotest.Key{EstateID: "estate-1", MeetingID: "meeting-9"}
+	assert.Equal(t, "I'll send them to [EMAIL_1].", repo.Segments[key][1].Content)
+	for _, value := range repo.Redactions[key] {
+		assert.NotContains(t, string(value.Sealed), "carol@example.com")
+	}
+	require.Equal(t, generated.DONE, owned.Status, utils.GetPtrValue(owned.Error, ""))
+	assert.Equal(t, "Bob will send the release notes to carol@example.com.", *owned.Abstract)
+	assert.Equal(t, []string{"The staging server is 10.20.30.40"}, owned.KeyPoints)
+	assert.Equal(t, &[]generated.Redaction{{Placeholder: "[EMAIL_1]", Category: generated.Email},
+		{Placeholder: "[IP_ADDRESS_1]", Category: generated.IpAddress}}, owned.Redactions)
+	shared := decodeResponse[generated.MeetingSummary](t, get(t, server, member, meetingPath), http.StatusOK)
+	assert.Equal(t, "Bob will send the release notes to [EMAIL_1].", *shared.Abstract)
+	listed := decodeResponse[[]generated.MeetingSummary](t, get(t, server, member, summaryPath), http.StatusOK)
+	require.Len(t, listed, 1)
+	assert.Equal(t, []string{"The staging server is [IP_ADDRESS_1]"}, listed[0].KeyPoints)
+
+	items := decodeResponse[[]generated.MeetingActionItem](t,
+		get(t, server, member, "/api/meetings/meeting-9/action-items"), http.StatusOK)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Send the release notes to [EMAIL_1]", items[0].Description)
+	require.NotNil(t, items[0].SourceSegment)
+	assert.Equal(t, "I'll send them to [EMAIL_1].", items[0].SourceSegment.Content)
+	items = decodeResponse[[]generated.MeetingActionItem](t,
+		get(t, server, testTenant, "/api/meetings/meeting-9/action-items"), http.StatusOK)
+	assert.Equal(t, "Send the release notes to carol@example.com", items[0].Description)
+	assert.Equal(t, "I'll send them to carol@example.com.", items[0].SourceSegment.Content)
+}
+
+func TestFetchLLMConfig_RequiresModel(t *testing.T) {
+	t.Setenv(constants.EnvVarLLMModel, " ")
+
+	_, err := fetchLLMConfig(context.Background(), credentials.Static("test-key"))
+
+	assert.ErrorContains(t, err, constants.EnvVarLLMModel)
+
+	t.Setenv(constants.EnvVarLLMModel, testModel)
+	config, err := fetchLLMConfig(context.Background(), credentials.Static("test-key"))
+
+	require.NoError(t, err)
+	assert.Equal(t, testModel, config.Model)
+}
@@ -3,8 +3,10 @@ package controller
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/api/rest/middleware"
+	"meeting-analyzer/server/commons/utils"
 	"meeting-analyzer/server/models"
 	"meeting-analyzer/server/services/service"
 )
@@ -20,16 +22,125 @@ func NewController(svc service.Service) generated.StrictServerInterface {
 }
 
 func (c *controller) GetMeetingSummaries(ctx context.Context, request generated.GetMeetingSummariesRequestObject) (generated.GetMeetingSummariesResponseObject, error) {
-	panic("implement me")
+	summaries, total, err := c.svc.ListMeetingSummaries(ctx, request.Params)
+	if err != nil {
+		return nil, err
+	}
+	offset := utils.GetPtrValue(request.Params.Offset, 0)
+	if offset == 0 && len(summaries) == total {
+		return generated.GetMeetingSummaries200JSONResponse(summaries), nil
+	}
+	return generated.GetMeetingSummaries206JSONResponse{
+		Body:    summaries,
+		Headers: generated.GetMeetingSummaries206ResponseHeaders{ContentRange: contentRange(offset, len(summaries), total)},
+	}, nil
 }
 
 func (c *controller) GenerateMeetingSummary(ctx context.Context, request generated.GenerateMeetingSummaryRequestObject) (generated.GenerateMeetingSummaryResponseObject, error) {
-	var meetingDetails models.MeetingDetails
-	bytes, _ := json.Marshal(request.Body)
-	json.Unmarshal(bytes, &meetingDetails)
-	res, _ := c.svc.GenerateMeetingSummary(ctx, &meetingDetails)
+	options := generateOptions(request.Params.IdempotencyKey, request.Params.Force,
+		string(utils.GetPtrValue(request.Body.SummaryStyle, "")), string(utils.GetPtrValue(request.Body.SummaryEngine, "")))
+	res, err := c.svc.GenerateMeetingSummary(ctx, models.MeetingDetailsFromRequest(request.Body), options)
+	if err != nil {
+		return nil, err
+	}
 	return generated.GenerateMeetingSummary202JSONResponse(*res), nil
 }
+
+func (c *controller) ImportMeetingTranscript(ctx context.Context, request generated.ImportMeetingTranscriptRequestObject) (generated.ImportMeetingTranscriptResponseObject, error) {
+	upload, err := readTranscriptUpload(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.svc.ImportMeetingTranscript(ctx, upload,
+		generateOptions(request.Params.IdempotencyKey, request.Params.Force, upload.SummaryStyle, upload.SummaryEngine))
+	if err != nil {
+		return nil, err
+	}
+	return generated.ImportMeetingTranscript202JSONResponse(*res), nil
+}
+
 func (c *controller) GetMeetingSummaryById(ctx context.Context, request generated.GetMeetingSummaryByIdRequestObject) (generated.GetMeetingSummaryByIdResponseObject, error) {
-	panic("implement me")
+	if middleware.Accepts(ctx, eventStreamMediaType) {
+		events, err := c.svc.StreamMeetingSummary(ctx, request.MeetingID)
+		if err != nil {
+			return nil, err
+		}
+		return summaryEventStream{events: events, keepAlive: keepAliveInterval}, nil
+	}
+	res, err := c.svc.GetMeetingSummary(ctx, request.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+	return generated.GetMeetingSummaryById200JSONResponse(*res), nil
+}
+
+func (c *controller) DeleteMeeting(ctx context.Context, request generated.DeleteMeetingRequestObject) (generated.DeleteMeetingResponseObject, error) {
+	if err := c.svc.DeleteMeeting(ctx, request.MeetingID); err != nil {
+		return nil, err
+	}
+	return generated.DeleteMeeting204Response{}, nil
+}
+
+func (c *controller) GetMeetingActionItems(ctx context.Context, request generated.GetMeetingActionItemsRequestObject) (generated.GetMeetingActionItemsResponseObject, error) {
+	items, err := c.svc.ListActionItems(ctx, request.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+	return generated.GetMeetingActionItems200JSONResponse(items), nil
+}
+
+func (c *controller) UpdateMeetingActionItem(ctx context.Context, request generated.UpdateMeetingActionItemRequestObject) (generated.UpdateMeetingActionItemResponseObject, error) {
+	item, err := c.svc.UpdateActionItem(ctx, request.MeetingID, request.ActionItemID, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return generated.UpdateMeetingActionItem200JSONResponse(*item), nil
+}
+
+func (c *controller) GetMeetingAnalytics(ctx context.Context, request generated.GetMeetingAnalyticsRequestObject) (generated.GetMeetingAnalyticsResponseObject, error) {
+	res, err := c.svc.GetMeetingAnalytics(ctx, request.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+	return generated.GetMeetingAnalytics200JSONResponse(*res), nil
+}
+
+func (c *controller) ListMeetingShares(ctx context.Context, request generated.ListMeetingSharesRequestObject) (generated.ListMeetingSharesResponseObject, error) {
+	shares, err := c.svc.ListMeetingShares(ctx, request.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+	return generated.ListMeetingShares200JSONResponse(shares), nil
+}
+
+func (c *controller) ShareMeeting(ctx context.Context, request generated.ShareMeetingRequestObject) (generated.ShareMeetingResponseObject, error) {
+	share, err := c.svc.ShareMeeting(ctx, request.MeetingID, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return generated.ShareMeeting201JSONResponse(*share), nil
+}
+
+func (c *controller) UnshareMeeting(ctx context.Context, request generated.UnshareMeetingRequestObject) (generated.UnshareMeetingResponseObject, error) {
+	if err := c.svc.UnshareMeeting(ctx, request.MeetingID, request.PrincipalType, request.PrincipalID); err != nil {
+		return nil, err
+	}
+	return generated.UnshareMeeting204Response{}, nil
+}
+
+// contentRange formats the range of a page of a collection, e.g. 0-99/250, or */250 for an empty page
+func contentRange(offset int, count int, total int) string {
+	if count == 0 {
+		return fmt.Sprintf("*/%d", total)
+	}
+	return fmt.Sprintf("%d-%d/%d", offset, offset+count-1, total)
+}
+
+func generateOptions(idempotencyKey *string, force *bool, summaryStyle string, summaryEngine string) models.GenerateOptions {
+	return models.GenerateOptions{
+		IdempotencyKey: utils.GetPtrValue(idempotencyKey, ""),
+		Force:          utils.GetPtrValue(force, false),
+		SummaryStyle:   summaryStyle,
+		SummaryEngine:  summaryEngine,
+	}
 }
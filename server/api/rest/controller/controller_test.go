@@ -1 +1,130 @@
 package controller
+
+import (
+	"bytes"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/models/errorresponse"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		offset   int
+		count    int
+		total    int
+		expected string
+	}{
+		{name: "FirstPage", offset: 0, count: 100, total: 250, expected: "0-99/250"},
+		{name: "LastPage", offset: 200, count: 50, total: 250, expected: "200-249/250"},
+		{name: "BeyondLastPage", offset: 300, count: 0, total: 250, expected: "*/250"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, contentRange(tt.offset, tt.count, tt.total))
+		})
+	}
+}
+
+func newMultipartReader(t *testing.T, fields map[string]string) *multipart.Reader {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		if name == "file" {
+			part, err := writer.CreateFormFile(name, "meeting.vtt")
+			require.NoError(t, err)
+			_, err = part.Write([]byte(value))
+			require.NoError(t, err)
+			continue
+		}
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	require.NoError(t, writer.Close())
+	return multipart.NewReader(body, writer.Boundary())
+}
+
+func TestReadTranscriptUpload(t *testing.T) {
+	upload, err := readTranscriptUpload(newMultipartReader(t, map[string]string{
+		"meeting_id":     "m1",
+		"meeting_title":  " Weekly ",
+		"started_at":     "2024-01-01T10:00:00Z",
+		"summary_style":  "retrospective",
+		"summary_engine": "extractive",
+		"file":           "WEBVTT\n",
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, &models.TranscriptUpload{
+		MeetingID:     "m1",
+		MeetingTitle:  "Weekly",
+		StartedAt:     time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		SummaryStyle:  "retrospective",
+		SummaryEngine: "extractive",
+		Content:       []byte("WEBVTT\n"),
+	}, upload)
+}
+
+func TestReadTranscriptUpload_Invalid(t *testing.T) {
+	valid := map[string]string{"meeting_id": "m1", "meeting_title": "Weekly", "file": "WEBVTT\n"}
+	tests := []struct {
+		name    string
+		without string
+		extra   map[string]string
+	}{
+		{name: "MissingMeetingID", without: "meeting_id"},
+		{name: "MissingTitle", without: "meeting_title"},
+		{name: "MissingFile", without: "file"},
+		{name: "InvalidStartedAt", extra: map[string]string{"started_at": "yesterday"}},
+		{name: "FieldTooLarge", extra: map[string]string{"meeting_title": strings.Repeat("x", maxFieldSize+1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := map[string]string{}
+			for name, value := range valid {
+				if name != tt.without {
+					fields[name] = value
+				}
+			}
+			for name, value := range tt.extra {
+				fields[name] = value
+			}
+
+			_, err := readTranscriptUpload(newMultipartReader(t, fields))
+
+			assert.ErrorIs(t, err, errorresponse.ErrInvalidTranscriptUpload)
+		})
+	}
+}
+
+func TestReadTranscriptUpload_MeetingIDTooLong(t *testing.T) {
+	_, err := readTranscriptUpload(newMultipartReader(t, map[string]string{"meeting_id": strings.Repeat("é", 257),
+		"meeting_title": "Weekly", "file": "WEBVTT\n"}))
+
+	var validationErr *errorresponse.ServiceErrorResponse
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, generated.N400, *validationErr.HttpStatusCode)
+	assert.Equal(t, "meeting_id: maximum string length is 256", *(*validationErr.Messages)[0].Message)
+	assert.Equal(t, errorresponse.ErrInvalidRequest.Code, *(*validationErr.Messages)[0].Code)
+
+	// the length is counted in characters, like the column
+	upload, err := readTranscriptUpload(newMultipartReader(t, map[string]string{"meeting_id": strings.Repeat("é", 256),
+		"meeting_title": "Weekly", "file": "WEBVTT\n"}))
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("é", 256), upload.MeetingID)
+}
+
+func TestReadTranscriptUpload_FileTooLarge(t *testing.T) {
+	_, err := readTranscriptUpload(newMultipartReader(t, map[string]string{"meeting_id": "m1",
+		"meeting_title": "Weekly", "file": "WEBVTT\n" + strings.Repeat("x", maxTranscriptSize)}))
+
+	assert.ErrorIs(t, err, errorresponse.ErrTranscriptTooLarge)
+}
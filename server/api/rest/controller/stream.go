@@ -0,0 +1,68 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"meeting-analyzer/server/models"
+	"net/http"
+	"time"
+)
+
+const (
+	eventStreamMediaType = "text/event-stream"
+	// keepAliveInterval is the idle time after which a comment is sent, so that proxies keep the stream open
+	keepAliveInterval = 15 * time.Second
+)
+
+// summaryEventStream writes the summary events as Server-Sent Events, flushing each of them, until the service
+// closes the channel. The generated text/event-stream response copies a reader without flushing.
+type summaryEventStream struct {
+	events    <-chan models.SummaryEvent
+	keepAlive time.Duration
+}
+
+func (response summaryEventStream) VisitGetMeetingSummaryByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", eventStreamMediaType)
+	w.Header().Set("Cache-Control", "no-cache")
+	// disables the buffering of nginx
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	controller := http.NewResponseController(w)
+
+	keepAlive := time.NewTicker(response.keepAlive)
+	defer keepAlive.Stop()
+	for {
+		var err error
+		select {
+		case event, ok := <-response.events:
+			if !ok {
+				return nil
+			}
+			err = writeEvent(w, event)
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err == nil {
+			// a writer which cannot flush still streams, in larger pieces
+			if err = controller.Flush(); errors.Is(err, http.ErrNotSupported) {
+				err = nil
+			}
+		}
+		if err != nil {
+			// the client is gone, the service stops streaming when the context of the request is canceled, and the
+			// status is already sent so there is no error response to write
+			return nil
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event models.SummaryEvent) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Name, data)
+	return err
+}
@@ -0,0 +1,50 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+package controller
+
+import (
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryEventStream(t *testing.T) {
+	events := make(chan models.SummaryEvent, 3)
+	events <- models.SummaryEvent{Name: models.SummaryEventProgress,
+		Data: generated.SummaryProgress{SummarizedChunks: 1, TotalChunks: 2}}
+	events <- models.SummaryEvent{Name: models.SummaryEventDelta, Data: generated.SummaryDelta{Attempt: 1, Content: "{\"sum"}}
+	events <- models.SummaryEvent{Name: models.SummaryEventSummary,
+		Data: &generated.MeetingSummary{MeetingId: "m1", Status: generated.DONE}}
+	close(events)
+	recorder := httptest.NewRecorder()
+
+	err := summaryEventStream{events: events, keepAlive: time.Hour}.VisitGetMeetingSummaryByIdResponse(recorder)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+	assert.True(t, recorder.Flushed)
+	assert.Equal(t, "event: progress\ndata: {\"summarized_chunks\":1,\"total_chunks\":2}\n\n"+
+		"event: delta\ndata: {\"attempt\":1,\"content\":\"{\\\"sum\"}\n\n"+
+		"event: summary\ndata: {\"action_items\":null,\"decisions\":null,\"key_points\":null,\"meeting_id\":\"m1\","+
+		"\"participants\":null,\"status\":\"DONE\",\"title\":\"\"}\n\n", recorder.Body.String())
+}
+
+func TestSummaryEventStream_KeepAlive(t *testing.T) {
+	events := make(chan models.SummaryEvent)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(events)
+	}()
+	recorder := httptest.NewRecorder()
+
+	err := summaryEventStream{events: events, keepAlive: 10 * time.Millisecond}.VisitGetMeetingSummaryByIdResponse(recorder)
+
+	require.NoError(t, err)
+	assert.Contains(t, recorder.Body.String(), ": keep-alive\n\n")
+}
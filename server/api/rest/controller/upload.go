@@ -0,0 +1,98 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/models/errorresponse"
+	"mime/multipart"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxTranscriptSize bounds the uploaded files, a day long transcript is a few megabytes
+	maxTranscriptSize = 10 << 20
+	// maxFieldSize bounds the text fields of the form
+	maxFieldSize = 4 << 10
+	// maxMeetingIDLength is the maxLength of meeting_id in the spec, the size of its column
+	maxMeetingIDLength = 256
+)
+
+// readTranscriptUpload reads the fields of the multipart import form
+func readTranscriptUpload(reader *multipart.Reader) (*models.TranscriptUpload, error) {
+	if reader == nil {
+		return nil, fmt.Errorf("%w: multipart form expected", errorresponse.ErrInvalidTranscriptUpload)
+	}
+	upload := &models.TranscriptUpload{}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errorresponse.ErrInvalidTranscriptUpload, err)
+		}
+		err = readUploadPart(part, upload)
+		_ = part.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case upload.MeetingID == "":
+		return nil, fmt.Errorf("%w: meeting_id is required", errorresponse.ErrInvalidTranscriptUpload)
+	case upload.MeetingTitle == "":
+		return nil, fmt.Errorf("%w: meeting_title is required", errorresponse.ErrInvalidTranscriptUpload)
+	case len(upload.Content) == 0:
+		return nil, fmt.Errorf("%w: file is required", errorresponse.ErrInvalidTranscriptUpload)
+	}
+	return upload, nil
+}
+
+func readUploadPart(part *multipart.Part, upload *models.TranscriptUpload) error {
+	limit := int64(maxFieldSize)
+	if part.FormName() == "file" {
+		limit = maxTranscriptSize
+	}
+	value, err := io.ReadAll(io.LimitReader(part, limit+1))
+	if err != nil {
+		return fmt.Errorf("%w: %w", errorresponse.ErrInvalidTranscriptUpload, err)
+	}
+	if int64(len(value)) > limit && part.FormName() == "file" {
+		return errorresponse.WithArgs(errorresponse.ErrTranscriptTooLarge, strconv.Itoa(maxTranscriptSize))
+	}
+	if int64(len(value)) > limit {
+		return fmt.Errorf("%w: %s exceeds %d bytes", errorresponse.ErrInvalidTranscriptUpload, part.FormName(), limit)
+	}
+
+	switch part.FormName() {
+	case "meeting_id":
+		upload.MeetingID = strings.TrimSpace(string(value))
+		// the multipart bodies are not validated against the spec, see middleware.NewRequestValidator
+		if utf8.RuneCountInString(upload.MeetingID) > maxMeetingIDLength {
+			return errorresponse.CreateValidationErrorResponse([]string{
+				fmt.Sprintf("meeting_id: maximum string length is %d", maxMeetingIDLength)})
+		}
+	case "meeting_title":
+		upload.MeetingTitle = strings.TrimSpace(string(value))
+	case "started_at":
+		startedAt, err := time.Parse(time.RFC3339, strings.TrimSpace(string(value)))
+		if err != nil {
+			return fmt.Errorf("%w: started_at must be an RFC 3339 time", errorresponse.ErrInvalidTranscriptUpload)
+		}
+		upload.StartedAt = startedAt
+	case "summary_style":
+		upload.SummaryStyle = strings.TrimSpace(string(value))
+	case "summary_engine":
+		upload.SummaryEngine = strings.TrimSpace(string(value))
+	case "file":
+		upload.Content = value
+	}
+	return nil
+}
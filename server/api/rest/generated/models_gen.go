@@ -5,6 +5,8 @@ package generated
 
 import (
 	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 // Defines values for HTTPStatusEnum.
@@ -26,6 +28,30 @@ const (
 	N503 HTTPStatusEnum = 503
 )
 
+// Defines values for JobStatusEnum.
+const (
+	DONE       JobStatusEnum = "DONE"
+	FAILED     JobStatusEnum = "FAILED"
+	INPROGRESS JobStatusEnum = "IN_PROGRESS"
+	PENDING    JobStatusEnum = "PENDING"
+)
+
+// Defines values for PrincipalTypeEnum.
+const (
+	Group PrincipalTypeEnum = "group"
+	User  PrincipalTypeEnum = "user"
+)
+
+// Defines values for RedactionCategoryEnum.
+const (
+	CreditCard RedactionCategoryEnum = "credit_card"
+	Email      RedactionCategoryEnum = "email"
+	IpAddress  RedactionCategoryEnum = "ip_address"
+	NationalId RedactionCategoryEnum = "national_id"
+	Phone      RedactionCategoryEnum = "phone"
+	Secret     RedactionCategoryEnum = "secret"
+)
+
 // Defines values for SeverityEnum.
 const (
 	CRITICAL SeverityEnum = "CRITICAL"
@@ -34,6 +60,28 @@ const (
 	WARNING  SeverityEnum = "WARNING"
 )
 
+// Defines values for SummaryEngineEnum.
+const (
+	Extractive SummaryEngineEnum = "extractive"
+	Llm        SummaryEngineEnum = "llm"
+)
+
+// Defines values for SummaryStyleEnum.
+const (
+	CustomerCall       SummaryStyleEnum = "customer_call"
+	Default            SummaryStyleEnum = "default"
+	EngineeringStandup SummaryStyleEnum = "engineering_standup"
+	ExecutiveBrief     SummaryStyleEnum = "executive_brief"
+	Retrospective      SummaryStyleEnum = "retrospective"
+)
+
+// ActionItem defines model for ActionItem.
+type ActionItem struct {
+	Description string              `json:"description"`
+	DueDate     *openapi_types.Date `json:"due_date,omitempty"`
+	Owner       *string             `json:"owner,omitempty"`
+}
+
 // ErrorMessage A message describing the failure, a contributing factor to the failure, or possibly the aftermath of the failure.
 type ErrorMessage struct {
 	// Arguments Ordered list of substitution args for the error message. Must match up with
@@ -44,12 +92,14 @@ type ErrorMessage struct {
 	// Code Identifier for this kind of message. This is a string that can be used to look up
 	// additional information on the support website. (Note - specific format can be determined
 	// by platform - hex value codes are common in midrange storage.)
+	// The meeting analyzer codes, e.g. MEETING_NOT_FOUND or LLM_UNAVAILABLE, never change once released.
 	Code *string `json:"code,omitempty"`
 
 	// Message Message string in English.
 	Message *string `json:"message,omitempty"`
 
-	// MessageL10n Localized message string.
+	// MessageL10n Localized message string, in the language negotiated from the Accept-Language header of the request
+	// among de, en, es, fr and ja, English otherwise. The language is sent in the Content-Language header.
 	MessageL10n *string `json:"message_l10n,omitempty"`
 
 	// Severity The severity of the condition.
@@ -91,14 +141,37 @@ type ErrorResponse struct {
 
 // GenerateMeetingSummaryRequest defines model for GenerateMeetingSummaryRequest.
 type GenerateMeetingSummaryRequest struct {
-	MeetingId     string                `json:"meeting_id"`
-	MeetingTitle  string                `json:"meeting_title"`
+	MeetingId    string `json:"meeting_id"`
+	MeetingTitle string `json:"meeting_title"`
+
+	// SummaryEngine The engine generating the summary. Defaults to llm.
+	// * llm - The summarization model, or the extractive engine when the model is unreachable and the fallback is enabled.
+	// * extractive - The offline extractive engine, selecting the most central sentences of the transcription
+	//   without a model. Only the English phrases announcing the decisions and action items are recognized.
+	SummaryEngine *SummaryEngineEnum `json:"summary_engine,omitempty"`
+
+	// SummaryStyle The style of the summary, selecting the prompt templates sent to the model. Defaults to default.
+	// * default - A general summary of the meeting.
+	// * executive_brief - The outcome and business impact for readers who did not attend.
+	// * engineering_standup - The progress, next steps and blockers of each participant.
+	// * customer_call - The goal, requirements and sentiment of the customer and the follow-ups promised to them.
+	// * retrospective - What went well, what to improve and the changes the team agreed to try.
+	SummaryStyle  *SummaryStyleEnum     `json:"summary_style,omitempty"`
 	Transcription []MemberTranscription `json:"transcription"`
 }
 
 // GenerateMeetingSummaryResponse defines model for GenerateMeetingSummaryResponse.
 type GenerateMeetingSummaryResponse struct {
-	MeetingId *string `json:"meeting_id,omitempty"`
+	// JobId Identifier of the background job generating the summary
+	JobId     string `json:"job_id"`
+	MeetingId string `json:"meeting_id"`
+
+	// Status The status of a summary generation job.
+	// * PENDING - The job is queued and waits for a free worker.
+	// * IN_PROGRESS - The summary is being generated.
+	// * DONE - The summary was generated and stored.
+	// * FAILED - The summary generation failed.
+	Status JobStatusEnum `json:"status"`
 }
 
 // HTTPStatusEnum Possible HTTP status values of completed or failed jobs.
@@ -119,13 +192,205 @@ type GenerateMeetingSummaryResponse struct {
 // * 503 - Service Unavailable - The service is temporarily unavailable. Try again later.
 type HTTPStatusEnum int
 
+// ImportMeetingTranscriptRequest defines model for ImportMeetingTranscriptRequest.
+type ImportMeetingTranscriptRequest struct {
+	// File The .vtt or .srt transcript, the format is detected from its content. The speaker names are limited to
+	// 256 characters.
+	File         openapi_types.File `json:"file"`
+	MeetingId    string             `json:"meeting_id"`
+	MeetingTitle string             `json:"meeting_title"`
+
+	// StartedAt Start time of the recording, the cue offsets are added to it. Defaults to the upload time.
+	StartedAt *time.Time `json:"started_at,omitempty"`
+
+	// SummaryEngine The engine generating the summary. Defaults to llm.
+	// * llm - The summarization model, or the extractive engine when the model is unreachable and the fallback is enabled.
+	// * extractive - The offline extractive engine, selecting the most central sentences of the transcription
+	//   without a model. Only the English phrases announcing the decisions and action items are recognized.
+	SummaryEngine *SummaryEngineEnum `json:"summary_engine,omitempty"`
+
+	// SummaryStyle The style of the summary, selecting the prompt templates sent to the model. Defaults to default.
+	// * default - A general summary of the meeting.
+	// * executive_brief - The outcome and business impact for readers who did not attend.
+	// * engineering_standup - The progress, next steps and blockers of each participant.
+	// * customer_call - The goal, requirements and sentiment of the customer and the follow-ups promised to them.
+	// * retrospective - What went well, what to improve and the changes the team agreed to try.
+	SummaryStyle *SummaryStyleEnum `json:"summary_style,omitempty"`
+}
+
+// Interruption A change of speaker before the previous one finished, or less than a second after
+type Interruption struct {
+	InterruptedMemberName string  `json:"interrupted_member_name"`
+	MemberName            string  `json:"member_name"`
+	OverlapSeconds        float64 `json:"overlap_seconds"`
+
+	// Seq Position of the interrupting segment in the transcription
+	Seq       int       `json:"seq"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// JobStatusEnum The status of a summary generation job.
+// * PENDING - The job is queued and waits for a free worker.
+// * IN_PROGRESS - The summary is being generated.
+// * DONE - The summary was generated and stored.
+// * FAILED - The summary generation failed.
+type JobStatusEnum string
+
+// MeetingActionItem defines model for MeetingActionItem.
+type MeetingActionItem struct {
+	CreatedAt   time.Time           `json:"created_at"`
+	Description string              `json:"description"`
+	Done        bool                `json:"done"`
+	DoneAt      *time.Time          `json:"done_at,omitempty"`
+	DueDate     *openapi_types.Date `json:"due_date,omitempty"`
+
+	// DuePhrase The deadline as said in the meeting, e.g. by Friday
+	DuePhrase *string `json:"due_phrase,omitempty"`
+
+	// Id Id of the item, derived from its description and source segment. The same item of a regenerated summary
+	// keeps its id and its done status.
+	Id        string `json:"id"`
+	MeetingId string `json:"meeting_id"`
+
+	// Owner The member_name of the speaker owning the task, missing when no speaker matches
+	Owner *string `json:"owner,omitempty"`
+
+	// SourceSegment The transcript segment where the task was agreed
+	SourceSegment *SourceSegment `json:"source_segment,omitempty"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// MeetingAnalytics Participation metrics of a meeting, the durations are in seconds
+type MeetingAnalytics struct {
+	DurationSeconds  float64        `json:"duration_seconds"`
+	Interruptions    []Interruption `json:"interruptions"`
+	LongestMonologue *Monologue     `json:"longest_monologue,omitempty"`
+	SilenceGaps      []SilenceGap   `json:"silence_gaps"`
+
+	// SilenceSeconds Total time of the silence gaps
+	SilenceSeconds float64 `json:"silence_seconds"`
+
+	// Speakers The speakers in order of first appearance
+	Speakers        []SpeakerAnalytics `json:"speakers"`
+	TalkTimeSeconds float64            `json:"talk_time_seconds"`
+
+	// Timed False when some segments have no timestamp, the talk times are then estimated from the words only and the interruptions and silences are not computed
+	Timed bool `json:"timed"`
+}
+
+// MeetingShare defines model for MeetingShare.
+type MeetingShare struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// CreatedBy The initiator which shared the meeting
+	CreatedBy   string `json:"created_by"`
+	MeetingId   string `json:"meeting_id"`
+	PrincipalId string `json:"principal_id"`
+
+	// PrincipalType The kind of principal a meeting is shared with.
+	// * user - A user, matched with the authenticated initiator.
+	// * group - A group, matched with the authenticated groups of the initiator.
+	PrincipalType PrincipalTypeEnum `json:"principal_type"`
+}
+
+// MeetingSummary defines model for MeetingSummary.
+type MeetingSummary struct {
+	// Abstract Short prose summary of the meeting, missing until the summary is generated
+	Abstract    *string      `json:"abstract,omitempty"`
+	ActionItems []ActionItem `json:"action_items"`
+
+	// Analytics Participation metrics of a meeting, the durations are in seconds
+	Analytics *MeetingAnalytics `json:"analytics,omitempty"`
+	CreatedAt *time.Time        `json:"created_at,omitempty"`
+	Decisions []string          `json:"decisions"`
+
+	// Error Reason of the failure when status is FAILED. The failures of the llm provider are given by their catalog
+	// code and HTTP status, e.g. LLM_UNAVAILABLE: llm provider returned status 503.
+	Error     *string  `json:"error,omitempty"`
+	KeyPoints []string `json:"key_points"`
+	MeetingId string   `json:"meeting_id"`
+
+	// Model The llm model which generated the summary, extractive-textrank for the extractive summaries
+	Model *string `json:"model,omitempty"`
+
+	// Participants Distinct speakers of the transcription in order of first appearance
+	Participants []string `json:"participants"`
+
+	// PromptVersion Version of the prompt templates of the summary style which generated the summary
+	PromptVersion *int `json:"prompt_version,omitempty"`
+
+	// Redactions Placeholders of the values redacted from the title and transcript of the meeting
+	Redactions *[]Redaction `json:"redactions,omitempty"`
+
+	// Status The status of a summary generation job.
+	// * PENDING - The job is queued and waits for a free worker.
+	// * IN_PROGRESS - The summary is being generated.
+	// * DONE - The summary was generated and stored.
+	// * FAILED - The summary generation failed.
+	Status JobStatusEnum `json:"status"`
+
+	// SummaryStyle The style of the summary, selecting the prompt templates sent to the model. Defaults to default.
+	// * default - A general summary of the meeting.
+	// * executive_brief - The outcome and business impact for readers who did not attend.
+	// * engineering_standup - The progress, next steps and blockers of each participant.
+	// * customer_call - The goal, requirements and sentiment of the customer and the follow-ups promised to them.
+	// * retrospective - What went well, what to improve and the changes the team agreed to try.
+	SummaryStyle *SummaryStyleEnum `json:"summary_style,omitempty"`
+	Title        string            `json:"title"`
+	UpdatedAt    *time.Time        `json:"updated_at,omitempty"`
+}
+
 // MemberTranscription defines model for MemberTranscription.
 type MemberTranscription struct {
-	Content    string    `json:"content"`
+	Content string `json:"content"`
+
+	// MemberId Initiator id of the speaker, as authenticated by the IAM middleware. The meeting is shared with the user
+	// when it is stored, so that the participant reads it.
+	MemberId   *string   `json:"member_id,omitempty"`
 	MemberName string    `json:"member_name"`
 	Timestamp  time.Time `json:"timestamp"`
 }
 
+// Monologue defines model for Monologue.
+type Monologue struct {
+	DurationSeconds float64 `json:"duration_seconds"`
+	MemberName      string  `json:"member_name"`
+
+	// Seq Position of the first segment of the monologue in the transcription
+	Seq       int        `json:"seq"`
+	Start     *time.Time `json:"start,omitempty"`
+	WordCount int        `json:"word_count"`
+}
+
+// PrincipalTypeEnum The kind of principal a meeting is shared with.
+// * user - A user, matched with the authenticated initiator.
+// * group - A group, matched with the authenticated groups of the initiator.
+type PrincipalTypeEnum string
+
+// Redaction defines model for Redaction.
+type Redaction struct {
+	// Category The kind of a redacted value.
+	// * email - An email address.
+	// * phone - A phone number.
+	// * credit_card - A payment card number passing the Luhn check.
+	// * national_id - A US social security number or a UK national insurance number.
+	// * ip_address - An IPv4 or IPv6 address.
+	// * secret - An API key, access token or another random token.
+	Category RedactionCategoryEnum `json:"category"`
+
+	// Placeholder The placeholder of the value in the stored title and transcript, e.g. [EMAIL_1]
+	Placeholder string `json:"placeholder"`
+}
+
+// RedactionCategoryEnum The kind of a redacted value.
+// * email - An email address.
+// * phone - A phone number.
+// * credit_card - A payment card number passing the Luhn check.
+// * national_id - A US social security number or a UK national insurance number.
+// * ip_address - An IPv4 or IPv6 address.
+// * secret - An API key, access token or another random token.
+type RedactionCategoryEnum string
+
 // SeverityEnum The severity of the condition.
 // * INFO - Information that may be of use in understanding the failure. It is not a problem to fix.
 // * WARNING - A condition that isn't a failure, but may be unexpected or a contributing factor. It may be necessary to fix the condition to successfully retry the request.
@@ -133,5 +398,172 @@ type MemberTranscription struct {
 // * CRITICAL - A failure with significant impact to the system. Normally failed commands roll back and are just ERROR, but may be used for exceptional cases.
 type SeverityEnum string
 
+// ShareMeetingRequest defines model for ShareMeetingRequest.
+type ShareMeetingRequest struct {
+	PrincipalId string `json:"principal_id"`
+
+	// PrincipalType The kind of principal a meeting is shared with.
+	// * user - A user, matched with the authenticated initiator.
+	// * group - A group, matched with the authenticated groups of the initiator.
+	PrincipalType PrincipalTypeEnum `json:"principal_type"`
+}
+
+// SilenceGap A pause of at least 3 seconds
+type SilenceGap struct {
+	// AfterSeq Position of the segment preceding the silence in the transcription
+	AfterSeq        int       `json:"after_seq"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Start           time.Time `json:"start"`
+}
+
+// SourceSegment The transcript segment where the task was agreed
+type SourceSegment struct {
+	Content    string `json:"content"`
+	MemberName string `json:"member_name"`
+
+	// Seq Position of the segment in the transcription, starting at 0
+	Seq       int        `json:"seq"`
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// SpeakerAnalytics defines model for SpeakerAnalytics.
+type SpeakerAnalytics struct {
+	// Interrupted Number of times the speaker was interrupted
+	Interrupted int `json:"interrupted"`
+
+	// Interruptions Number of times the speaker interrupted somebody
+	Interruptions int    `json:"interruptions"`
+	MemberName    string `json:"member_name"`
+
+	// TalkShare Share of the talk time of the meeting, between 0 and 1
+	TalkShare       float64 `json:"talk_share"`
+	TalkTimeSeconds float64 `json:"talk_time_seconds"`
+
+	// TurnCount Number of runs of consecutive segments of the speaker
+	TurnCount int `json:"turn_count"`
+	WordCount int `json:"word_count"`
+}
+
+// SummaryDelta Data of the delta events, holding the next part of the output of the model generating the summary. An attempt
+// answering an invalid summary is retried, the deltas of the next attempt replace the previous ones.
+type SummaryDelta struct {
+	Attempt int    `json:"attempt"`
+	Content string `json:"content"`
+}
+
+// SummaryEngineEnum The engine generating the summary. Defaults to llm.
+//   - llm - The summarization model, or the extractive engine when the model is unreachable and the fallback is enabled.
+//   - extractive - The offline extractive engine, selecting the most central sentences of the transcription
+//     without a model. Only the English phrases announcing the decisions and action items are recognized.
+type SummaryEngineEnum string
+
+// SummaryProgress Data of the progress events, sent each time a chunk of a long meeting is summarized
+type SummaryProgress struct {
+	SummarizedChunks int `json:"summarized_chunks"`
+	TotalChunks      int `json:"total_chunks"`
+}
+
+// SummaryStyleEnum The style of the summary, selecting the prompt templates sent to the model. Defaults to default.
+// * default - A general summary of the meeting.
+// * executive_brief - The outcome and business impact for readers who did not attend.
+// * engineering_standup - The progress, next steps and blockers of each participant.
+// * customer_call - The goal, requirements and sentiment of the customer and the follow-ups promised to them.
+// * retrospective - What went well, what to improve and the changes the team agreed to try.
+type SummaryStyleEnum string
+
+// UpdateActionItemRequest defines model for UpdateActionItemRequest.
+type UpdateActionItemRequest struct {
+	Done bool `json:"done"`
+}
+
+// CreatedAtFilter defines model for CreatedAtFilter.
+type CreatedAtFilter = []string
+
+// Force defines model for Force.
+type Force = bool
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// MeetingIDFilter defines model for MeetingIDFilter.
+type MeetingIDFilter = []string
+
+// Offset defines model for Offset.
+type Offset = int
+
+// Order defines model for Order.
+type Order = string
+
+// ParticipantFilter defines model for ParticipantFilter.
+type ParticipantFilter = []string
+
+// TitleFilter defines model for TitleFilter.
+type TitleFilter = []string
+
+// UpdatedAtFilter defines model for UpdatedAtFilter.
+type UpdatedAtFilter = []string
+
+// GetMeetingSummariesParams defines parameters for GetMeetingSummaries.
+type GetMeetingSummariesParams struct {
+	// Offset Number of meetings to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit Maximum number of meetings to return
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Order Comma separated list of sort fields, each optionally suffixed by .asc or .desc, e.g. title.asc,created_at.desc.
+	// Sortable fields are meeting_id, title, created_at and updated_at. Defaults to created_at.desc.
+	Order *Order `form:"order,omitempty" json:"order,omitempty"`
+
+	// MeetingId Filter on the meeting id, supported operators are eq, neq, like and ilike
+	MeetingId *MeetingIDFilter `form:"meeting_id,omitempty" json:"meeting_id,omitempty"`
+
+	// Title Filter on the meeting title, supported operators are eq, neq, like and ilike. The titles are stored redacted, the filters match their redacted text, e.g. [EMAIL_1] rather than the redacted email address.
+	Title *TitleFilter `form:"title,omitempty" json:"title,omitempty"`
+
+	// Participant Filter on the speakers of the transcription, supported operators are eq, like and ilike. The speakers are stored redacted, the filters match their redacted names, e.g. [EMAIL_1] rather than the redacted email address.
+	Participant *ParticipantFilter `form:"participant,omitempty" json:"participant,omitempty"`
+
+	// CreatedAt Filter on the creation time (RFC 3339), supported operators are eq, neq, gt, gte, lt and lte
+	CreatedAt *CreatedAtFilter `form:"created_at,omitempty" json:"created_at,omitempty"`
+
+	// UpdatedAt Filter on the last update time (RFC 3339), supported operators are eq, neq, gt, gte, lt and lte
+	UpdatedAt *UpdatedAtFilter `form:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+// GenerateMeetingSummaryParams defines parameters for GenerateMeetingSummary.
+type GenerateMeetingSummaryParams struct {
+	// Force Generate the summary again when the meeting already has one
+	Force *Force `form:"force,omitempty" json:"force,omitempty"`
+
+	// IdempotencyKey Unique key of the request chosen by the client. A request sent again with the same key within 24 hours gets
+	// the response of the first request instead of generating another summary. Reusing the key for another
+	// request is rejected with a 409.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ImportMeetingTranscriptParams defines parameters for ImportMeetingTranscript.
+type ImportMeetingTranscriptParams struct {
+	// Force Generate the summary again when the meeting already has one
+	Force *Force `form:"force,omitempty" json:"force,omitempty"`
+
+	// IdempotencyKey Unique key of the request chosen by the client. A request sent again with the same key within 24 hours gets
+	// the response of the first request instead of generating another summary. Reusing the key for another
+	// request is rejected with a 409.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // GenerateMeetingSummaryJSONRequestBody defines body for GenerateMeetingSummary for application/json ContentType.
 type GenerateMeetingSummaryJSONRequestBody = GenerateMeetingSummaryRequest
+
+// ImportMeetingTranscriptMultipartRequestBody defines body for ImportMeetingTranscript for multipart/form-data ContentType.
+type ImportMeetingTranscriptMultipartRequestBody = ImportMeetingTranscriptRequest
+
+// UpdateMeetingActionItemJSONRequestBody defines body for UpdateMeetingActionItem for application/json ContentType.
+type UpdateMeetingActionItemJSONRequestBody = UpdateActionItemRequest
+
+// ShareMeetingJSONRequestBody defines body for ShareMeeting for application/json ContentType.
+type ShareMeetingJSONRequestBody = ShareMeetingRequest
@@ -10,6 +10,8 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path"
@@ -25,13 +27,37 @@ import (
 type ServerInterface interface {
 	// Get meeting summaries
 	// (GET /api/meetings/summary)
-	GetMeetingSummaries(w http.ResponseWriter, r *http.Request)
+	GetMeetingSummaries(w http.ResponseWriter, r *http.Request, params GetMeetingSummariesParams)
 	// Generate meeting summary
 	// (POST /api/meetings/summary)
-	GenerateMeetingSummary(w http.ResponseWriter, r *http.Request)
+	GenerateMeetingSummary(w http.ResponseWriter, r *http.Request, params GenerateMeetingSummaryParams)
+	// Import a meeting transcript file
+	// (POST /api/meetings/summary/import)
+	ImportMeetingTranscript(w http.ResponseWriter, r *http.Request, params ImportMeetingTranscriptParams)
+	// Delete a meeting
+	// (DELETE /api/meetings/summary/{MeetingID})
+	DeleteMeeting(w http.ResponseWriter, r *http.Request, meetingID string)
 	// Get meeting summary by ID
 	// (GET /api/meetings/summary/{MeetingID})
 	GetMeetingSummaryById(w http.ResponseWriter, r *http.Request, meetingID string)
+	// Get the action items of a meeting
+	// (GET /api/meetings/{MeetingID}/action-items)
+	GetMeetingActionItems(w http.ResponseWriter, r *http.Request, meetingID string)
+	// Update the status of an action item
+	// (PATCH /api/meetings/{MeetingID}/action-items/{ActionItemID})
+	UpdateMeetingActionItem(w http.ResponseWriter, r *http.Request, meetingID string, actionItemID string)
+	// Get the speaker analytics of a meeting
+	// (GET /api/meetings/{MeetingID}/analytics)
+	GetMeetingAnalytics(w http.ResponseWriter, r *http.Request, meetingID string)
+	// Get the shares of a meeting
+	// (GET /api/meetings/{MeetingID}/shares)
+	ListMeetingShares(w http.ResponseWriter, r *http.Request, meetingID string)
+	// Share a meeting
+	// (POST /api/meetings/{MeetingID}/shares)
+	ShareMeeting(w http.ResponseWriter, r *http.Request, meetingID string)
+	// Stop sharing a meeting
+	// (DELETE /api/meetings/{MeetingID}/shares/{PrincipalType}/{PrincipalID})
+	UnshareMeeting(w http.ResponseWriter, r *http.Request, meetingID string, principalType PrincipalTypeEnum, principalID string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -46,8 +72,77 @@ type MiddlewareFunc func(http.Handler) http.Handler
 // GetMeetingSummaries operation middleware
 func (siw *ServerInterfaceWrapper) GetMeetingSummaries(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMeetingSummariesParams
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "meeting_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "meeting_id", r.URL.Query(), &params.MeetingId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "meeting_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "title" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "title", r.URL.Query(), &params.Title)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "title", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "participant" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "participant", r.URL.Query(), &params.Participant)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "participant", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_at", r.URL.Query(), &params.CreatedAt)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_at", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "updated_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "updated_at", r.URL.Query(), &params.UpdatedAt)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "updated_at", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetMeetingSummaries(w, r)
+		siw.Handler.GetMeetingSummaries(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -60,8 +155,115 @@ func (siw *ServerInterfaceWrapper) GetMeetingSummaries(w http.ResponseWriter, r
 // GenerateMeetingSummary operation middleware
 func (siw *ServerInterfaceWrapper) GenerateMeetingSummary(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GenerateMeetingSummaryParams
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GenerateMeetingSummary(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ImportMeetingTranscript operation middleware
+func (siw *ServerInterfaceWrapper) ImportMeetingTranscript(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportMeetingTranscriptParams
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportMeetingTranscript(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteMeeting operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMeeting(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "MeetingID" -------------
+	var meetingID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "MeetingID", mux.Vars(r)["MeetingID"], &meetingID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "MeetingID", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GenerateMeetingSummary(w, r)
+		siw.Handler.DeleteMeeting(w, r, meetingID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -96,6 +298,183 @@ func (siw *ServerInterfaceWrapper) GetMeetingSummaryById(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
+// GetMeetingActionItems operation middleware
+func (siw *ServerInterfaceWrapper) GetMeetingActionItems(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "MeetingID" -------------
+	var meetingID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "MeetingID", mux.Vars(r)["MeetingID"], &meetingID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "MeetingID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMeetingActionItems(w, r, meetingID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateMeetingActionItem operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMeetingActionItem(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "MeetingID" -------------
+	var meetingID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "MeetingID", mux.Vars(r)["MeetingID"], &meetingID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "MeetingID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "ActionItemID" -------------
+	var actionItemID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "ActionItemID", mux.Vars(r)["ActionItemID"], &actionItemID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ActionItemID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateMeetingActionItem(w, r, meetingID, actionItemID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMeetingAnalytics operation middleware
+func (siw *ServerInterfaceWrapper) GetMeetingAnalytics(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "MeetingID" -------------
+	var meetingID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "MeetingID", mux.Vars(r)["MeetingID"], &meetingID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "MeetingID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMeetingAnalytics(w, r, meetingID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListMeetingShares operation middleware
+func (siw *ServerInterfaceWrapper) ListMeetingShares(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "MeetingID" -------------
+	var meetingID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "MeetingID", mux.Vars(r)["MeetingID"], &meetingID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "MeetingID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListMeetingShares(w, r, meetingID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ShareMeeting operation middleware
+func (siw *ServerInterfaceWrapper) ShareMeeting(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "MeetingID" -------------
+	var meetingID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "MeetingID", mux.Vars(r)["MeetingID"], &meetingID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "MeetingID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ShareMeeting(w, r, meetingID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnshareMeeting operation middleware
+func (siw *ServerInterfaceWrapper) UnshareMeeting(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "MeetingID" -------------
+	var meetingID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "MeetingID", mux.Vars(r)["MeetingID"], &meetingID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "MeetingID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "PrincipalType" -------------
+	var principalType PrincipalTypeEnum
+
+	err = runtime.BindStyledParameterWithOptions("simple", "PrincipalType", mux.Vars(r)["PrincipalType"], &principalType, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "PrincipalType", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "PrincipalID" -------------
+	var principalID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "PrincipalID", mux.Vars(r)["PrincipalID"], &principalID, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "PrincipalID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnshareMeeting(w, r, meetingID, principalType, principalID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -191,124 +570,642 @@ func HandlerFromMuxWithBaseURL(si ServerInterface, r *mux.Router, baseURL string
 	})
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.Handler {
-	r := options.BaseRouter
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = mux.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.HandleFunc(options.BaseURL+"/api/meetings/summary", wrapper.GetMeetingSummaries).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/meetings/summary", wrapper.GenerateMeetingSummary).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/api/meetings/summary/import", wrapper.ImportMeetingTranscript).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/api/meetings/summary/{MeetingID}", wrapper.DeleteMeeting).Methods("DELETE")
+
+	r.HandleFunc(options.BaseURL+"/api/meetings/summary/{MeetingID}", wrapper.GetMeetingSummaryById).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/meetings/{MeetingID}/action-items", wrapper.GetMeetingActionItems).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/meetings/{MeetingID}/action-items/{ActionItemID}", wrapper.UpdateMeetingActionItem).Methods("PATCH")
+
+	r.HandleFunc(options.BaseURL+"/api/meetings/{MeetingID}/analytics", wrapper.GetMeetingAnalytics).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/meetings/{MeetingID}/shares", wrapper.ListMeetingShares).Methods("GET")
+
+	r.HandleFunc(options.BaseURL+"/api/meetings/{MeetingID}/shares", wrapper.ShareMeeting).Methods("POST")
+
+	r.HandleFunc(options.BaseURL+"/api/meetings/{MeetingID}/shares/{PrincipalType}/{PrincipalID}", wrapper.UnshareMeeting).Methods("DELETE")
+
+	return r
+}
+
+type GetMeetingSummariesRequestObject struct {
+	Params GetMeetingSummariesParams
+}
+
+type GetMeetingSummariesResponseObject interface {
+	VisitGetMeetingSummariesResponse(w http.ResponseWriter) error
+}
+
+type GetMeetingSummaries200JSONResponse []MeetingSummary
+
+func (response GetMeetingSummaries200JSONResponse) VisitGetMeetingSummariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingSummaries206ResponseHeaders struct {
+	ContentRange string
+}
+
+type GetMeetingSummaries206JSONResponse struct {
+	Body    []MeetingSummary
+	Headers GetMeetingSummaries206ResponseHeaders
+}
+
+func (response GetMeetingSummaries206JSONResponse) VisitGetMeetingSummariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Range", fmt.Sprint(response.Headers.ContentRange))
+	w.WriteHeader(206)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetMeetingSummaries400JSONResponse ErrorResponse
+
+func (response GetMeetingSummaries400JSONResponse) VisitGetMeetingSummariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingSummaries404JSONResponse ErrorResponse
+
+func (response GetMeetingSummaries404JSONResponse) VisitGetMeetingSummariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingSummaries500JSONResponse ErrorResponse
+
+func (response GetMeetingSummaries500JSONResponse) VisitGetMeetingSummariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateMeetingSummaryRequestObject struct {
+	Params GenerateMeetingSummaryParams
+	Body   *GenerateMeetingSummaryJSONRequestBody
+}
+
+type GenerateMeetingSummaryResponseObject interface {
+	VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error
+}
+
+type GenerateMeetingSummary202JSONResponse GenerateMeetingSummaryResponse
+
+func (response GenerateMeetingSummary202JSONResponse) VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateMeetingSummary400JSONResponse ErrorResponse
+
+func (response GenerateMeetingSummary400JSONResponse) VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateMeetingSummary403JSONResponse ErrorResponse
+
+func (response GenerateMeetingSummary403JSONResponse) VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateMeetingSummary404JSONResponse ErrorResponse
+
+func (response GenerateMeetingSummary404JSONResponse) VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateMeetingSummary409JSONResponse ErrorResponse
+
+func (response GenerateMeetingSummary409JSONResponse) VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateMeetingSummary500JSONResponse ErrorResponse
+
+func (response GenerateMeetingSummary500JSONResponse) VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateMeetingSummary503JSONResponse ErrorResponse
+
+func (response GenerateMeetingSummary503JSONResponse) VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportMeetingTranscriptRequestObject struct {
+	Params ImportMeetingTranscriptParams
+	Body   *multipart.Reader
+}
+
+type ImportMeetingTranscriptResponseObject interface {
+	VisitImportMeetingTranscriptResponse(w http.ResponseWriter) error
+}
+
+type ImportMeetingTranscript202JSONResponse GenerateMeetingSummaryResponse
+
+func (response ImportMeetingTranscript202JSONResponse) VisitImportMeetingTranscriptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportMeetingTranscript400JSONResponse ErrorResponse
+
+func (response ImportMeetingTranscript400JSONResponse) VisitImportMeetingTranscriptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportMeetingTranscript403JSONResponse ErrorResponse
+
+func (response ImportMeetingTranscript403JSONResponse) VisitImportMeetingTranscriptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportMeetingTranscript409JSONResponse ErrorResponse
+
+func (response ImportMeetingTranscript409JSONResponse) VisitImportMeetingTranscriptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportMeetingTranscript422JSONResponse ErrorResponse
+
+func (response ImportMeetingTranscript422JSONResponse) VisitImportMeetingTranscriptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportMeetingTranscript500JSONResponse ErrorResponse
+
+func (response ImportMeetingTranscript500JSONResponse) VisitImportMeetingTranscriptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportMeetingTranscript503JSONResponse ErrorResponse
+
+func (response ImportMeetingTranscript503JSONResponse) VisitImportMeetingTranscriptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteMeetingRequestObject struct {
+	MeetingID string `json:"MeetingID"`
+}
+
+type DeleteMeetingResponseObject interface {
+	VisitDeleteMeetingResponse(w http.ResponseWriter) error
+}
+
+type DeleteMeeting204Response struct {
+}
+
+func (response DeleteMeeting204Response) VisitDeleteMeetingResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteMeeting403JSONResponse ErrorResponse
+
+func (response DeleteMeeting403JSONResponse) VisitDeleteMeetingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteMeeting404JSONResponse ErrorResponse
+
+func (response DeleteMeeting404JSONResponse) VisitDeleteMeetingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteMeeting500JSONResponse ErrorResponse
+
+func (response DeleteMeeting500JSONResponse) VisitDeleteMeetingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingSummaryByIdRequestObject struct {
+	MeetingID string `json:"MeetingID"`
+}
+
+type GetMeetingSummaryByIdResponseObject interface {
+	VisitGetMeetingSummaryByIdResponse(w http.ResponseWriter) error
+}
+
+type GetMeetingSummaryById200JSONResponse MeetingSummary
+
+func (response GetMeetingSummaryById200JSONResponse) VisitGetMeetingSummaryByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingSummaryById200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetMeetingSummaryById200TexteventStreamResponse) VisitGetMeetingSummaryByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetMeetingSummaryById403JSONResponse ErrorResponse
+
+func (response GetMeetingSummaryById403JSONResponse) VisitGetMeetingSummaryByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingSummaryById404JSONResponse ErrorResponse
+
+func (response GetMeetingSummaryById404JSONResponse) VisitGetMeetingSummaryByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingSummaryById500JSONResponse ErrorResponse
+
+func (response GetMeetingSummaryById500JSONResponse) VisitGetMeetingSummaryByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingActionItemsRequestObject struct {
+	MeetingID string `json:"MeetingID"`
+}
+
+type GetMeetingActionItemsResponseObject interface {
+	VisitGetMeetingActionItemsResponse(w http.ResponseWriter) error
+}
+
+type GetMeetingActionItems200JSONResponse []MeetingActionItem
+
+func (response GetMeetingActionItems200JSONResponse) VisitGetMeetingActionItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingActionItems403JSONResponse ErrorResponse
+
+func (response GetMeetingActionItems403JSONResponse) VisitGetMeetingActionItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingActionItems404JSONResponse ErrorResponse
+
+func (response GetMeetingActionItems404JSONResponse) VisitGetMeetingActionItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingActionItems500JSONResponse ErrorResponse
+
+func (response GetMeetingActionItems500JSONResponse) VisitGetMeetingActionItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMeetingActionItemRequestObject struct {
+	MeetingID    string `json:"MeetingID"`
+	ActionItemID string `json:"ActionItemID"`
+	Body         *UpdateMeetingActionItemJSONRequestBody
+}
+
+type UpdateMeetingActionItemResponseObject interface {
+	VisitUpdateMeetingActionItemResponse(w http.ResponseWriter) error
+}
+
+type UpdateMeetingActionItem200JSONResponse MeetingActionItem
+
+func (response UpdateMeetingActionItem200JSONResponse) VisitUpdateMeetingActionItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMeetingActionItem400JSONResponse ErrorResponse
+
+func (response UpdateMeetingActionItem400JSONResponse) VisitUpdateMeetingActionItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMeetingActionItem403JSONResponse ErrorResponse
+
+func (response UpdateMeetingActionItem403JSONResponse) VisitUpdateMeetingActionItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMeetingActionItem404JSONResponse ErrorResponse
+
+func (response UpdateMeetingActionItem404JSONResponse) VisitUpdateMeetingActionItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateMeetingActionItem500JSONResponse ErrorResponse
+
+func (response UpdateMeetingActionItem500JSONResponse) VisitUpdateMeetingActionItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingAnalyticsRequestObject struct {
+	MeetingID string `json:"MeetingID"`
+}
+
+type GetMeetingAnalyticsResponseObject interface {
+	VisitGetMeetingAnalyticsResponse(w http.ResponseWriter) error
+}
+
+type GetMeetingAnalytics200JSONResponse MeetingAnalytics
+
+func (response GetMeetingAnalytics200JSONResponse) VisitGetMeetingAnalyticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingAnalytics403JSONResponse ErrorResponse
+
+func (response GetMeetingAnalytics403JSONResponse) VisitGetMeetingAnalyticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeetingAnalytics404JSONResponse ErrorResponse
 
-	if r == nil {
-		r = mux.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+func (response GetMeetingAnalytics404JSONResponse) VisitGetMeetingAnalyticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
 
-	r.HandleFunc(options.BaseURL+"/api/meetings/summary", wrapper.GetMeetingSummaries).Methods("GET")
+	return json.NewEncoder(w).Encode(response)
+}
 
-	r.HandleFunc(options.BaseURL+"/api/meetings/summary", wrapper.GenerateMeetingSummary).Methods("POST")
+type GetMeetingAnalytics500JSONResponse ErrorResponse
 
-	r.HandleFunc(options.BaseURL+"/api/meetings/summary/{MeetingID}", wrapper.GetMeetingSummaryById).Methods("GET")
+func (response GetMeetingAnalytics500JSONResponse) VisitGetMeetingAnalyticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	return r
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetMeetingSummariesRequestObject struct {
+type ListMeetingSharesRequestObject struct {
+	MeetingID string `json:"MeetingID"`
 }
 
-type GetMeetingSummariesResponseObject interface {
-	VisitGetMeetingSummariesResponse(w http.ResponseWriter) error
+type ListMeetingSharesResponseObject interface {
+	VisitListMeetingSharesResponse(w http.ResponseWriter) error
 }
 
-type GetMeetingSummaries200JSONResponse map[string]interface{}
+type ListMeetingShares200JSONResponse []MeetingShare
 
-func (response GetMeetingSummaries200JSONResponse) VisitGetMeetingSummariesResponse(w http.ResponseWriter) error {
+func (response ListMeetingShares200JSONResponse) VisitListMeetingSharesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetMeetingSummaries404JSONResponse ErrorResponse
+type ListMeetingShares403JSONResponse ErrorResponse
 
-func (response GetMeetingSummaries404JSONResponse) VisitGetMeetingSummariesResponse(w http.ResponseWriter) error {
+func (response ListMeetingShares403JSONResponse) VisitListMeetingSharesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListMeetingShares404JSONResponse ErrorResponse
+
+func (response ListMeetingShares404JSONResponse) VisitListMeetingSharesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetMeetingSummaries500JSONResponse ErrorResponse
+type ListMeetingShares500JSONResponse ErrorResponse
 
-func (response GetMeetingSummaries500JSONResponse) VisitGetMeetingSummariesResponse(w http.ResponseWriter) error {
+func (response ListMeetingShares500JSONResponse) VisitListMeetingSharesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateMeetingSummaryRequestObject struct {
-	Body *GenerateMeetingSummaryJSONRequestBody
+type ShareMeetingRequestObject struct {
+	MeetingID string `json:"MeetingID"`
+	Body      *ShareMeetingJSONRequestBody
 }
 
-type GenerateMeetingSummaryResponseObject interface {
-	VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error
+type ShareMeetingResponseObject interface {
+	VisitShareMeetingResponse(w http.ResponseWriter) error
 }
 
-type GenerateMeetingSummary202JSONResponse GenerateMeetingSummaryResponse
+type ShareMeeting201JSONResponse MeetingShare
 
-func (response GenerateMeetingSummary202JSONResponse) VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error {
+func (response ShareMeeting201JSONResponse) VisitShareMeetingResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateMeetingSummary404JSONResponse ErrorResponse
+type ShareMeeting400JSONResponse ErrorResponse
 
-func (response GenerateMeetingSummary404JSONResponse) VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error {
+func (response ShareMeeting400JSONResponse) VisitShareMeetingResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateMeetingSummary409JSONResponse ErrorResponse
+type ShareMeeting403JSONResponse ErrorResponse
 
-func (response GenerateMeetingSummary409JSONResponse) VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error {
+func (response ShareMeeting403JSONResponse) VisitShareMeetingResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateMeetingSummary500JSONResponse ErrorResponse
+type ShareMeeting404JSONResponse ErrorResponse
 
-func (response GenerateMeetingSummary500JSONResponse) VisitGenerateMeetingSummaryResponse(w http.ResponseWriter) error {
+func (response ShareMeeting404JSONResponse) VisitShareMeetingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ShareMeeting500JSONResponse ErrorResponse
+
+func (response ShareMeeting500JSONResponse) VisitShareMeetingResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetMeetingSummaryByIdRequestObject struct {
-	MeetingID string `json:"MeetingID"`
+type UnshareMeetingRequestObject struct {
+	MeetingID     string            `json:"MeetingID"`
+	PrincipalType PrincipalTypeEnum `json:"PrincipalType"`
+	PrincipalID   string            `json:"PrincipalID"`
 }
 
-type GetMeetingSummaryByIdResponseObject interface {
-	VisitGetMeetingSummaryByIdResponse(w http.ResponseWriter) error
+type UnshareMeetingResponseObject interface {
+	VisitUnshareMeetingResponse(w http.ResponseWriter) error
 }
 
-type GetMeetingSummaryById200JSONResponse map[string]interface{}
+type UnshareMeeting204Response struct {
+}
 
-func (response GetMeetingSummaryById200JSONResponse) VisitGetMeetingSummaryByIdResponse(w http.ResponseWriter) error {
+func (response UnshareMeeting204Response) VisitUnshareMeetingResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UnshareMeeting403JSONResponse ErrorResponse
+
+func (response UnshareMeeting403JSONResponse) VisitUnshareMeetingResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnshareMeeting404JSONResponse ErrorResponse
+
+func (response UnshareMeeting404JSONResponse) VisitUnshareMeetingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnshareMeeting500JSONResponse ErrorResponse
+
+func (response UnshareMeeting500JSONResponse) VisitUnshareMeetingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
@@ -321,9 +1218,33 @@ type StrictServerInterface interface {
 	// Generate meeting summary
 	// (POST /api/meetings/summary)
 	GenerateMeetingSummary(ctx context.Context, request GenerateMeetingSummaryRequestObject) (GenerateMeetingSummaryResponseObject, error)
+	// Import a meeting transcript file
+	// (POST /api/meetings/summary/import)
+	ImportMeetingTranscript(ctx context.Context, request ImportMeetingTranscriptRequestObject) (ImportMeetingTranscriptResponseObject, error)
+	// Delete a meeting
+	// (DELETE /api/meetings/summary/{MeetingID})
+	DeleteMeeting(ctx context.Context, request DeleteMeetingRequestObject) (DeleteMeetingResponseObject, error)
 	// Get meeting summary by ID
 	// (GET /api/meetings/summary/{MeetingID})
 	GetMeetingSummaryById(ctx context.Context, request GetMeetingSummaryByIdRequestObject) (GetMeetingSummaryByIdResponseObject, error)
+	// Get the action items of a meeting
+	// (GET /api/meetings/{MeetingID}/action-items)
+	GetMeetingActionItems(ctx context.Context, request GetMeetingActionItemsRequestObject) (GetMeetingActionItemsResponseObject, error)
+	// Update the status of an action item
+	// (PATCH /api/meetings/{MeetingID}/action-items/{ActionItemID})
+	UpdateMeetingActionItem(ctx context.Context, request UpdateMeetingActionItemRequestObject) (UpdateMeetingActionItemResponseObject, error)
+	// Get the speaker analytics of a meeting
+	// (GET /api/meetings/{MeetingID}/analytics)
+	GetMeetingAnalytics(ctx context.Context, request GetMeetingAnalyticsRequestObject) (GetMeetingAnalyticsResponseObject, error)
+	// Get the shares of a meeting
+	// (GET /api/meetings/{MeetingID}/shares)
+	ListMeetingShares(ctx context.Context, request ListMeetingSharesRequestObject) (ListMeetingSharesResponseObject, error)
+	// Share a meeting
+	// (POST /api/meetings/{MeetingID}/shares)
+	ShareMeeting(ctx context.Context, request ShareMeetingRequestObject) (ShareMeetingResponseObject, error)
+	// Stop sharing a meeting
+	// (DELETE /api/meetings/{MeetingID}/shares/{PrincipalType}/{PrincipalID})
+	UnshareMeeting(ctx context.Context, request UnshareMeetingRequestObject) (UnshareMeetingResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -356,9 +1277,11 @@ type strictHandler struct {
 }
 
 // GetMeetingSummaries operation middleware
-func (sh *strictHandler) GetMeetingSummaries(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) GetMeetingSummaries(w http.ResponseWriter, r *http.Request, params GetMeetingSummariesParams) {
 	var request GetMeetingSummariesRequestObject
 
+	request.Params = params
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetMeetingSummaries(ctx, request.(GetMeetingSummariesRequestObject))
 	}
@@ -380,9 +1303,11 @@ func (sh *strictHandler) GetMeetingSummaries(w http.ResponseWriter, r *http.Requ
 }
 
 // GenerateMeetingSummary operation middleware
-func (sh *strictHandler) GenerateMeetingSummary(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) GenerateMeetingSummary(w http.ResponseWriter, r *http.Request, params GenerateMeetingSummaryParams) {
 	var request GenerateMeetingSummaryRequestObject
 
+	request.Params = params
+
 	var body GenerateMeetingSummaryJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
@@ -410,6 +1335,65 @@ func (sh *strictHandler) GenerateMeetingSummary(w http.ResponseWriter, r *http.R
 	}
 }
 
+// ImportMeetingTranscript operation middleware
+func (sh *strictHandler) ImportMeetingTranscript(w http.ResponseWriter, r *http.Request, params ImportMeetingTranscriptParams) {
+	var request ImportMeetingTranscriptRequestObject
+
+	request.Params = params
+
+	if reader, err := r.MultipartReader(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+		return
+	} else {
+		request.Body = reader
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ImportMeetingTranscript(ctx, request.(ImportMeetingTranscriptRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ImportMeetingTranscript")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ImportMeetingTranscriptResponseObject); ok {
+		if err := validResponse.VisitImportMeetingTranscriptResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteMeeting operation middleware
+func (sh *strictHandler) DeleteMeeting(w http.ResponseWriter, r *http.Request, meetingID string) {
+	var request DeleteMeetingRequestObject
+
+	request.MeetingID = meetingID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteMeeting(ctx, request.(DeleteMeetingRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteMeeting")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteMeetingResponseObject); ok {
+		if err := validResponse.VisitDeleteMeetingResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetMeetingSummaryById operation middleware
 func (sh *strictHandler) GetMeetingSummaryById(w http.ResponseWriter, r *http.Request, meetingID string) {
 	var request GetMeetingSummaryByIdRequestObject
@@ -436,52 +1420,313 @@ func (sh *strictHandler) GetMeetingSummaryById(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// GetMeetingActionItems operation middleware
+func (sh *strictHandler) GetMeetingActionItems(w http.ResponseWriter, r *http.Request, meetingID string) {
+	var request GetMeetingActionItemsRequestObject
+
+	request.MeetingID = meetingID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMeetingActionItems(ctx, request.(GetMeetingActionItemsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMeetingActionItems")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMeetingActionItemsResponseObject); ok {
+		if err := validResponse.VisitGetMeetingActionItemsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateMeetingActionItem operation middleware
+func (sh *strictHandler) UpdateMeetingActionItem(w http.ResponseWriter, r *http.Request, meetingID string, actionItemID string) {
+	var request UpdateMeetingActionItemRequestObject
+
+	request.MeetingID = meetingID
+	request.ActionItemID = actionItemID
+
+	var body UpdateMeetingActionItemJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateMeetingActionItem(ctx, request.(UpdateMeetingActionItemRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateMeetingActionItem")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateMeetingActionItemResponseObject); ok {
+		if err := validResponse.VisitUpdateMeetingActionItemResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetMeetingAnalytics operation middleware
+func (sh *strictHandler) GetMeetingAnalytics(w http.ResponseWriter, r *http.Request, meetingID string) {
+	var request GetMeetingAnalyticsRequestObject
+
+	request.MeetingID = meetingID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMeetingAnalytics(ctx, request.(GetMeetingAnalyticsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMeetingAnalytics")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMeetingAnalyticsResponseObject); ok {
+		if err := validResponse.VisitGetMeetingAnalyticsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListMeetingShares operation middleware
+func (sh *strictHandler) ListMeetingShares(w http.ResponseWriter, r *http.Request, meetingID string) {
+	var request ListMeetingSharesRequestObject
+
+	request.MeetingID = meetingID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListMeetingShares(ctx, request.(ListMeetingSharesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListMeetingShares")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListMeetingSharesResponseObject); ok {
+		if err := validResponse.VisitListMeetingSharesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ShareMeeting operation middleware
+func (sh *strictHandler) ShareMeeting(w http.ResponseWriter, r *http.Request, meetingID string) {
+	var request ShareMeetingRequestObject
+
+	request.MeetingID = meetingID
+
+	var body ShareMeetingJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ShareMeeting(ctx, request.(ShareMeetingRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ShareMeeting")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ShareMeetingResponseObject); ok {
+		if err := validResponse.VisitShareMeetingResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UnshareMeeting operation middleware
+func (sh *strictHandler) UnshareMeeting(w http.ResponseWriter, r *http.Request, meetingID string, principalType PrincipalTypeEnum, principalID string) {
+	var request UnshareMeetingRequestObject
+
+	request.MeetingID = meetingID
+	request.PrincipalType = principalType
+	request.PrincipalID = principalID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UnshareMeeting(ctx, request.(UnshareMeetingRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnshareMeeting")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UnshareMeetingResponseObject); ok {
+		if err := validResponse.VisitUnshareMeetingResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xYbW/bOBL+KwPdAbd7kB3VcdIm37Lddi+3TVokXuyHTVFQ1MhiQpEqX2yrQf77YUhJ",
-	"tmOnzaLYu/uWmOS8zzPP6D7hum60QuVscnqfWF5hzcKfb4zR5gKtZXOk/wu03IjGCa2S0+QM6ngE8fdc",
-	"qDm4CqFkQnqDKTDgWjkjcu/orGTcaQNOb9/SBhptrchlGw5Y6dDUzFWgy82b4yRNGqMbNE5gsI+Zua97",
-	"u7eNe28KNFiAFNaRHOtz64TzdAzMzC2UZEqFgORk78oYLrx1UDPHK/ANLIWr4EbRvfvsIYX7Fw8poOPj",
-	"8RgYd55J2YJQQVAfDYMlGlQcC8jbrROuC4QbxXK9wBRECUy14xuVpIlwWAcvXNtgcppYZ4SaJw9p/wMz",
-	"hrVJmqxGom4kkteMfBkp7ej8Cj97QQ4vK1Rg0SzQUGS5FKgcyBeZAmHB+qbRxmGRgnYVmqWwCDoEjclx",
-	"kL8QVuRCCtcmp8n55ezN1eXZO7KErN8N9HmByolSoOkiKizcCVVQ0IegzuhXYYFBdAxcxRxwpiBH8BYL",
-	"Kgqp9R3F/EaxohDRJBCq1FQMlDYd49z5AEvMrXA4hh8utUMYgW2Qi1JwiE96+QVSPQmFBdyovIVGMkc3",
-	"YAQVrmDBpI+pscAM/VXXWlFSa1EYpuYI1mlDfvwYcrWTovqpDulap3daKHij5lLYavwVMZ8oV7uy3mnO",
-	"pPiCxVBN8eWuqL+6SB7SxOICTaiQ++TvBsvkNPnbwRpGDjoMObju7r1Rvg7FLGq0jtXNrn+zCoGOgTlY",
-	"VoJXG82pOffGYEG+xtwmp0nBHI7oxW4s122j81vkbl9ITBeL5PSPtTtdka9Tumnyx4c0IuIV2kYruxcS",
-	"rWOqYKYA012CXBdtrHHqDyYlKK1Gk9UKrt5cz4Z7dhfeKueaT9Yx5+2nvvm+Fu1/zWYfrsP1Pt6dG3af",
-	"pT0y9neeQHFAxbVXLqBpwDNhgYKH1o3hzIFEZh3cKK0QlkJKajldQogU9JGFHDnzFuFclRqYKuB3ZhSp",
-	"4lrFZrdQaFCa2pYuRtDtFBE8kD2PwPJrwdiaXY9xdLdCHtLkF1RomMMLRBpX176umWmvogWkbjs7dbz2",
-	"SRS7uE31Zp1upJhX4SldSqZlW31uXnlbmpwFG3oZTjiJzxVj9Gqhqqrm5qhqoiuGqY3k3j8vQhdY52hm",
-	"W2/3DZxdC8pyhZid3JpMfzlJHsiEzXbaiMxjFx/b+pEaLPj+jfDv6+hdwxSu9G32pVous+lJCM1TUtcd",
-	"/L1ZZfnJ0SGiP/yyPOmC8S2XOuXP88lJka9wusIpexkr91Gf7/T2h8imEOgiRACJY85SZ1IpSHRYEPRT",
-	"V2EBtzq34xv1T5hkGYzg/a8wgmvPOVpbetk/EVqlkRKxbXzrnr6AEbw2yEj2E+/JAAY8XBq6+4cP769n",
-	"1ONcS4k83DNotTccf+xkT2AEZ5xjE4X/W+dQMQs50iRzjMbVGK62QDcQjludD7JAKMJnjhs3u/AQJIVH",
-	"RAHYggnJKIKl0XUQESamcEPw+mhNYQSXGl5r5WiEfjVq2rsnAncMI/jAjBNMboj6wOa4MUicDoR6iNBn",
-	"j6YTcHJCkR/yGlJ00eM6nZh1sNfpz70LMRwmQDlwgTGcKS6kZKYF4gIBn3U5CKlZCxVbYFc/Y3iLy/7Q",
-	"gq20lwUNghBEp8Gg80aF4ZECs2HAiOiniHqHx06Hh07XgsOI/kVBLAQsRTZWrdE0Zxi/I07YrwcUiWko",
-	"33O1YFIU0IEHjGC2MUuIiiqujUHuxjCL2wiugjHdIoIpBAkbRWqJmpZGKxdqhUZUGIU0mMfwMzompO1X",
-	"gd0cT0Nz/KaYd5U2gcdtW8WZouFHrntXEafm1Efd40MYwVttclEUqHb9oZdMSr3sWEa0LGYyCoh16uCt",
-	"9qpXLYqO/A5WR4G/Xb3rhYYodCJijalSCv44pDzku7N/XWCFH6q2exYqk3QFQqdcaD/sFz3HzBzd0K5R",
-	"74Q6/31DQEpV/zYC1rb+DsUKH3g2rpCHRa8TQIbPtIYLptq+JmyQICw01HbcSzbQYIVYhDK0Ui+h0EsV",
-	"Mu7YHRICILMt4YczbVxVgUGBksU8H3UF6NDQ+hJZUKeqRqZi6TdGF57H7mOQ+3nQwL11uqZKj/1DyzPj",
-	"rufjnXwqhWs0C8GR6mmAqhgR250ICw7rRhtmhGzBry+OYUaWz5lQIJlDE0kVhlHyxyTL0kn2Ip1kk3SS",
-	"TdNJdpxOTk7SaZal0+xFOs0O02k2TafZSTqdTNLp5CQ9yrL0KDv8OMwz6uw5mjDQvBK0nydvz17PiF7s",
-	"Yx07U5hHDHzuCD7+XLZ3hV+YWyN9R6xIyyfF6mfTqqP2ENHKV+KYqyLSqs1t5Rl7x365y2Px8tav7iZH",
-	"ZZC7Q5bWlm6qTIcgbHCkfcF7Hoto7vTkuLqTS2aPXwXvtnazvevYQN679hyoeqjE88u370Oprxf0sNXT",
-	"aIgrAGGkUOBVgSbsRY9WizGcr9GLeiKXWAeqL1ZBxe9nV5fnl7/Q5F8rj1qEVf+gV8NXJBpmnWqvcNUg",
-	"7xjO3o9QQXV3XSENbJp0UfW2rwEIhpkuWxplpt3Ey2Dqm6ur91dkqOq+Cw3706bhRvt5tbHe7uIn2SqU",
-	"j9j3+up8dv767F0IQC8vQKgVcyVKwRkN0LohlOg+q9nWOqzHcElZIXs7bOS6rpkq7MbsJMwhunPrrYsO",
-	"bIex31lxRbQrfo/hzAbus0aMhAohSZMuWUmaBFFJmvTmJx93N/SHNBGq1KE5u+LWDSrWiCRNFmhsLMMX",
-	"44xAoz86TQ7H9FOaNMxVASoOWCMOOtZuD2yk2HQwR7db1gTbAhfYJ6kwoqSW0f18OS8Cb3dblJ1QKXYt",
-	"WveTLtptjIot3a3xdDTJskcoxppG0kAXWh3c2gh5cQ/bgKd+FSV5j75m/kpRmGbTPyX2m+vxsInsUTmQ",
-	"BdJ89Ccd+i7Nw/C8jh+owoMAWUN2KUPQJR3sRo72Qd/C8umhQ2/8VMTp0Gi7pzb6be2R4HZPeexb675W",
-	"Id8Rrq+vxQ8Pe4pv8pcrfzp5/Yr2v63XaXby39Pcc+L/00Z5sqj39YptsXkx/3Ksj6bNbdS5F2AP7ruS",
-	"OP/54Umw3W3SFvIWzn/+JuC2P7XnRfKdqLpLaMzG0m+c7T5lKAjfuoixVMI6bQTvJwP4huieTaHU3XqV",
-	"tyBUIRai8I9vgXUGWY0FsR6DTAaaOL7Z5Wh7knnxKD/fwrt1KPeS2VfLl67KMoEvscM8ZliNDo1NTv+4",
-	"TwTppCGapElkycmQ0WSToTrjMd2dVcMg/xgMDQUYJXsjk9Pw7fr04EBqzmSlrTt9lb3KkoePD/8JAAD/",
-	"/xFhfqhvHAAA",
+	"H4sIAAAAAAAC/+x97W4bObLoqxB9L3BnBm1ZcZzsxMD+8CTOHO86TmA7O8BZBQbVXZIYd5Mdkm1bE/jd",
+	"L6pIdrM/ZMvZTHbOngC7g0gii8Visb5Z/pxkqqyUBGlNcvA5qbjmJVjQ9OmlBm4hP7SvRWFB41c5mEyL",
+	"ygolk4PEfc+UZHYFLMPhAj+IEtgPZ69fsqdPn774MWWmriqlLeRMVaC5VdowroHBp5RJ/M/S4v8hZYVl",
+	"XOassJCkicA1PtWg10maSF5CcpBkDqdLbpM0MdkKSo54CQsl4WzXFQ4zVgu5TO7S8AXXmq+Tu7s0ea10",
+	"BsO9/AoSUQPaiqnLkus140suJLtZgdthCWCFXDJeaOD5mq24YUpuQnVBC8VY5rDgdWGTgwUvDDS4zZUq",
+	"gEvC7jiHslIWZLb+O6yHaL6X4lMN7ArWTC0IKQ2fajCWZStlQLL52h1GIUDaCTtsfjcgbdiQsCu3T146",
+	"WPiNkGxvn61UrQ1bgjUz6cCbSkkDYbmF0MY2QIU0FniOPy4dAYk+UtkV6EDGCTuD2uAPduWWWygdBs1k",
+	"A8swDR8hQz4hDDnbn76YzGSg7wp4DrolcESrHSRWTOqS356AXNpVcrD37HmalEKGz0/SPpfcpcmJKIUd",
+	"kvsNvxVlXTJZl3Pk9EXgAcOsYhpsreWG4y8I4ujxP5lO06R0oOnTlBD0Hxv0hLSwBE34vXHLHr/a7i4G",
+	"ThX5FrevEFdA107gvzZsx0O8FPmXXry3i4WBERqfjtLWXIlqAyrKARolbUzJ6Sgl3+p8jH4vVVlyZgBF",
+	"INKqEMYiTkZpyxYCitykDHi2Yoqm8KJYM1MvFuIWcrx1E24ypjSbINyUwWQ5YVbYAvCHtJVb9PtkJs+V",
+	"tnxegAdOR9ISOXVzU9ZOpCOqqzzAYa/cpolcQ/ibiKfdHWppN7wM77i2IhMVl1uKflMBvwJtgpCwmstm",
+	"9P0c2GW+CbuIweEgY5WGnGnIOYqG1EshXN6wktuMRJnQzQiGOzX+BP559Obw+OTyyQemOckku+LSy00/",
+	"HEouCsbzXIMxkw1kq1qSfCn/X+CBPu76eh545A12RKS5X0pCC7f2K1OQ8PlS2r2v8seYIgU31l+VP9Qa",
+	"aW/jl23sLsyi0YcZbubYQomfKo3oWQFmsNsRwHkNl4gL/rhQuuQ2OUjoi3Q4WN1IR8Ph1UdlLDTkycE/",
+	"O2t+SP0BHsRoNrDVHBU3wj7SWuk3YAxfjlhZh6x0PzH3/TxYBQsuilpDyjjLlLRazGu6AQueWaVRwnVG",
+	"Kc0qZYyYF87c4QsLuOlVY6a4kciMXUJyvazLYO92kSPNEIv+em6ssDX+zLheGrJbEDrgJsNWJuxNbay/",
+	"R3XlbBdnPH2e3qXs85O7lIHNJpMJ45mtSXOIcNEdNTQsQIPMnCqJf8lUDmwm+VxdQ8rEgnG59tJ9Oy5L",
+	"k9sdUVYF4K7JQN+RChklOfNn7QxcA/oab5D2tiMrnkwlE6a9Lykji+1GGGiU4ITgXwsj5qIQdo1m2enF",
+	"0dnp4QligtgPCX2cg7RiIUB7igrDroTMnQ3giXqB3wrDOHMbQ7ljWcYlmwOrDQopxQqlrpDmM8nzXDiU",
+	"mJDuBuCxBQXl9sBuYG6EhQn74VRZYDvMVJCJhciYmxLg54D8JCTkbCbna1YV3OIItsNWcMuueVG7o3Gi",
+	"I1NlqSQeailyzeXSyVzcx48zeRG7DpIX699Bu8lexL45Oro4Pv318vTtxeXrt+9PX+EpnJy8uXx/eviP",
+	"w+OTw19OjlA24flkKwKvZAZMQwHcQO74YcAG5aZb6K9nIKyQ7EguC2FWk3vAXCI/DGGdqIwX4nfIG451",
+	"M9PA4gWXyxq/l7BUVpBxtdCqpB8Pswwqu3MSxjgTv+fazCQvlVyyHFIGMmVIt4Um0fyRpwH3ljmd/mvW",
+	"RRYGaQM+L5W0IAdrjtHwj746KP7xUOnefE7+r4ZFcpD8n93WKd/16mH33I87knWJ86wowVheVsMTccq/",
+	"BMYtu1mJbBWJLJVltdaQ40F31MQOzkjGPKOuhB8hSawymu34q98yYYzyh6AnzrxzOaYojOUy5zpvPdC5",
+	"ytfu5pP/WBRMKrmzd3vLzo7OL5pxZij0V9ZWl8ZyW5vLIJLuo/Z/XVy8O6fhgd5+G2YM06AvwpgNuo2B",
+	"zFQtLegg5YUJLD5hh5bhZbZsJpUEdiOKAgWRWjCiFAuUZXPIeG2AHcuFojvwG9cSl8qUdCLQsFwxqSxz",
+	"A4MfTwuh0ER8eirkPmJ0NPqYcTawAUIsxTut5y4McOYwGNo2kWv5SM8dudFYVRViuSLACCLZX6xXn6qf",
+	"a7PQc04YhhW8CfN5KzBa3V7L1arM9LNVRWB8POMS5FLIB5nIb/uIBgc+CiCMXRfbQjjHsc3Fj12rjrF5",
+	"H6Q3gD72RWfuHdH22E1/MmI1DEmyWNwCTF981FP1+wtnv8a3vxMj6NK8j3hkT97PLWMCaIiYhFv1cfr7",
+	"6uZmuv+CzmoT1FbgdJnwo5p7Btxoq3itNOfZ1VKrGtWPmsdxryh0OK5IYz7fhgX5/MWzpwD1099vXrht",
+	"OSH20HH/Tc1j8XXfMfl9N5C3OBhPwu1OxhZifgv7t7DP/+LERU+4Dij+zhn2wHAgc2g5i4viC7jdAsh9",
+	"0yTKgM7BTGbyJ7Y3nbId9vbvbIed11kGxizqIkyhWISPLHaUip/6hO0wH/PeNB8R4D7Y0ojUH969Pb+g",
+	"IIwqCiDvCOGrWmfwo4e9x3a8sUPA/6bmFD2eA0jcoraQY5g01nRk+yKHBVgUbeUyg2ikJw/qAZrENTB+",
+	"zUXhQktoZyEIMlOEbYgXqLXPdtipCjbR/VRTtd1AuOdsh1HMiBcRqHd8CZH2torxmELkSjsAL14g5Ztz",
+	"pSN6E5Qp/qJbYrfHP68t0bBRu4vGAJuwQ5mJosAYPhpgpBTVogFScgzeX4Pnnwl7DTfhR8PMStVFjtqX",
+	"iNgEekljp4wbuujC7VO4dZvJVtFEq0qRsR38CMIHw7MMHNdqhcqdZ1dMycZTRUrsE/sey2teiJx5Ech2",
+	"yJ6NwuRCZkpryOyEXTjHGG4JGe8TQ8oIQsSkBr2khVbShxENOPsDrSGMJFouChNM5OEZ79PleC95bVdK",
+	"k7nfxSrjUipLW6/tCqQVGd4jP/kp22GvlZ6LPAc53A/O5EWhbrxp5zBzJ+kAOD617DWJXQdA5N4Pa7B2",
+	"AN+fnQSgRAUPwvGYXBQi65M0o/P2+LcMltcN1/ppTeKErGhp6fo1qRHL9RJsc13dunt4899SoAm5/rUT",
+	"WN31vRTLa3LH4BYyijl4AIj4hVLsDZfrwBOGIAjDXFyyLnjje0iA3EXQC3XDcnUj6cQtvwImLANuKCFj",
+	"9dpFTRhnORTcnfMzz4AWNHrSzvT0S5XApWP9Squ8ztzt42xeL2mFrDZWlaDD/cmUtDyzwQny8JEVzkFf",
+	"iwyQnxpR5Shi/C/CMAtlpTTXolizuh04YRdNYq7gNjhuQKrkn3vTabo3fZLuTffSvel+ujd9nu69eJHu",
+	"T6fp/vRJuj99mu5P99P96Yt0f28v3d97kT6bTtNn06cfBtkCVGi1FNZgfPHw5QXq8GNEynqN2NpTG23b",
+	"hShg3EWbXFtL+QKjbRQw98FZF40QhiIRWeMzC2uIriTjolC5i3mT9Ke8E0VHZnLv2XMMGGieWdDGUapx",
+	"++ZCbmWoPCqV9qCt7WwY7aOmA8qc42/OfW2CAJnSOUUU3M0D5hJAbrs8z2mzTPQyIji4rgrFcwK3rcf7",
+	"Z7DyH2NWE4NFRtsDDDrirdFt13XjUPTd2xBxWjTMNoeF0uBFAVwLVVMenC2EFGZF0Q7NCjDG5Qo4M4DO",
+	"qZM3A+dchPUhvyzJT7l04fXPY8x1/+/qGnTBq0u3oOlGw1U9L6ITdzldOi74NGqGimDyBX3vyCSXzMCy",
+	"jGJKXd9mmHbsxWu2jLx0Ayufku72042Ei1cbkiTmlfjkRzij60aMSjFvf5JZ7Lm+8YiURNuT5P67o9NX",
+	"x6e/eimPFqkwaAHWkJPuuOHCOkOKs4UGYDdKX5Fs/4kdn16+O3v769nR+bmfHxYSaEDjcfgVvb3x6u3p",
+	"UW/kDTftIFrR5cRo/OvD45OjV70Z0Sa8kRjrmcRvKEmTCL0kTXDtJE0cyOTDiHjxV/O+ZE9U5LIlt6QP",
+	"J4iUjK9MU3PifnncUo/JNeHgaqW52aAGc+B5ISQwbpjhIm8zI0QmHyGfr9lrLXI+qq7G3fbm3looU5aD",
+	"FtexFo2GO35wLpa/2F658tLNd/ytoWUhzyYzeQVQGYIoHGMRcCXD1dgYm98cC4gzdEOCRTc9bDHIZXUj",
+	"QxjCcnOVslIYqrsh90+qZiDlqsCMaj+iw6Wnw4O6i0af+8F3aZwR/TJB11Fy9CEmgefjtFsHFi0aSbfh",
+	"RRsRcWEQpmWsyEbiu00xBH5mJVgtMi/wGh5Fiue1ExfOLBGSBYHb13dh4COVlIiEtdk68NcR8YPgbZoU",
+	"Si7B2MtSSVWoZf2gtfKmGYjMIgqQGVwuebU9Sudu0q+8GkMogIyI07sDyvKiYyH6KYywSLdS+O4emA06",
+	"zf+Kh0jlMriQq3vjVQVcc0mFfdvt1gFrGWxkz5YXV5e4o0eyBE4ZkX2veWHAZ6ZU2cg040IeUrHGPEi9",
+	"rCiu3HfEuhYngrGi7ObqbpTODVOyWDuHsmMTEd/LPJyFg0Q5CFVWtYU8GS13jG++2006vB9jBBoySnSq",
+	"/cvSY9QRGdGczmYRcb7iGr6Org5z5utxBhRSWMGt0j5/Z3DpPFaLX6BSKi0kirHi4QHup/v5+l0YfbGu",
+	"tnBbesB76HRI0hHuw7Ny53DPOfkA/OCk+NxY9IRHnM6V0pZVWpnW/vOypRHxQZXW0oqiUyQsItNy7Fw4",
+	"KaDLRlpsJTYirTUiMHisru7P+/T4+y79QusyE2ageB4ob0oTSjoP6X0G3LSOVUiPOoHl/AlhvFXuDDE/",
+	"pKkvLIoSj+taoHDmGthSXDe1z0KzjFteqOVMUvUMyqUol+BNyl59xUEXqIv4Qh4QejZ9usGSu4L1ZaWE",
+	"tI+kzQPXtVQ5FOPiARGln714aK3SiC1TjAkju4tr2LH0b3nVFjA1v/nxYtwajMoeR5TlK2GskJm9v/5z",
+	"azX6IMkqrcrKXl6DNqOxin+4HwIWbjgFEgtuW+4JF5eiM/fRcNSTd1WP4S70bMWCZ7BSRR7RwmeummLJ",
+	"Rp+SXHOqtCFXT+xsa2ScBZxGLaovSRl+jUz1xgDgv+wkdFRLiIR1eLVzL2P51ZPHY3nPniIZVTXDdPrQ",
+	"MnBxWvzn42sYnn9arK/y+lp/1EXtaxjI7xt1dht7QeQ9n5DSVZ2UTCgwPD58w0qR5wXccO1rpppnAybY",
+	"HE3GozagZzLkEYXxAZSUGeUq8+jGtUfANPDcYFSWxOajg8idON+/XhPybP0UwBQ/i+eZzImej43IjcO9",
+	"eS7+8rG+vdp7tiC4A0bdFJYL3NHhuyFXbZdkr67U3vPVVXHDzfOf3XuR2J/7Kv7nQ6HXrYKoTvyH6Kn/",
+	"svE9tw+nUgphe/MFXZdLKr6KUI8fhWw+Mxd4HfFJIpjxGTZ0HxEbQ5t5VL+HUtjGRG5DDb2bSeFLvJlY",
+	"XUD/SH1sJ7q53dvf+BY0F+tYKppM/3pwNo0ybUi8hRXFRhGPJE1o7GgItFVXQ6HJLSyVXm+t8V76CUHr",
+	"VK0KHqduNKCjogPz+ccSY9q5/yLiQT0VI5O2e4v4pSXFCL+Mb/JenuGtoUG7olN27zN22KHsPdXAH6uV",
+	"kph+PfT/cjeefso05MJeZlznbgBf072lL9w4VnHTPO07qVeSZSvIrmi65K7Q9VK46e/PmVGZ4AUzkNVU",
+	"zOiBUNz//d+bGUxIU5N9GKMjqkuPuNvL8bvrfZx6/O76eWdLBjIN1g06fHeMTw5TxqmwhVl1BZK17w+Z",
+	"5jJXpfu+y8dEKzQsVk3MMZCDnoA0u0MzrcGNZAauP8r6nTre0ZNsCj09czZlnT4Z8votZejbEnfSvljR",
+	"4spFa0OsXMscNNXQ9spQJ+y4LbpAG3leQElloeKWlvjt8OzU5WoO28XdKsLI/4ezmncY87pZupZwW7lU",
+	"NR3nyDMOWtoPl4DHgWa4W7q7V/zSNKVIxZppKliIyjwI1aOzs7dn7pzdy4qAWQdxrerlKiqFHpZ9IK5C",
+	"+tvy8uz44vjl4QkRoHFOUR4asZT4WIBLy0RZ8cyGRLNZGwvlhJ3iqSC+vqQDHwZwmZuo5AdFCtfAPtbG",
+	"ug10yRjqm+E2A18pzjJuwHSZExkhSRN/WEmaEKgkTQL64+yHusPbtxtrFvqRokfabl87jnR/6CiSpWOb",
+	"G5GqUfx5JNtdUc00StJQkP10YySf8tmXWxk+weSpNGTQXMkQud7a7vlC2+1R5lKP/O0mA5wRNOJTaMk7",
+	"RvxOwmj87ULrDQeq3axAQ5PXomwuX2qAfHAkkdP16OKBRx3k2JGlrpQTT5dbNv1mhQAjvkSXzmMH0c9L",
+	"DMRAVF5w36Nt2lAnDYnHE08eI8Mgm7U9+Ag05TewSHF0jYfOm7IKJoT1+yFhrqPCPp8gGUSF52BvACSb",
+	"klh/sl326YvTPbWWrReziWC6lr5OWhoqJLyOMkDd4MAo1f4FX2ksTRMRuQO7s51hwibmn5it+1w7xtmD",
+	"iqxRMePKuzaU73dLyYqiJMsAA69xlYj43SeFMRhLRU+92Kpfo20gggOZMKyWGni2osrHkExb8KIgC0EY",
+	"BpLPXc3JTzE8t7haLKhiYrBQygxQebXfTKmMZRlIq8nmltal5saCtDPJoiJvwnPC3kr/vDY8sXOFHIZx",
+	"KVUts7BOE11zxo2r76b4Glk6GjK1lFg03DVhigJT8u0uxi2WfmRxQxkShnK78d0+NQbhYKRIMOD8juNT",
+	"9z0l6Az8v52nTBxTbMgU+SPzN+9yrgUswrnVNlOlO/B5bYQEY4Id6WrIOQWOb1aK5cJZptxakJ4P6IwB",
+	"CXNJhn1decCVVksNxqRMwq1lxkLlnwQUKgtxeeS3OEBHMEPN7mWGz+kctKXiRcr8LXdiA2EhtUQctglz",
+	"WwZWWMO9gwECpLXwb3TtCtz10WC1MhUEXv4NXYob0u9QFCm7wc9WIUm0um4vhitAdErAAi+97ifYet3l",
+	"KX9QxFedM6AxAwKi8oxJkKRJB8tRlnTNCNoM3UZDelPNVf+dvZJuIS/kNsEfyLo7UqQLNX4nmsp/vIPE",
+	"60qyOax4sQhHCK5+HQnd5py3iBanUY8hw3I1o+L/bKUUvSuA0vlL7mWtmVDgwS1F5QMGwJVMYXF60/QF",
+	"sVjxwQ9M5CaNb1gQYDPvwDvIbcXBAt8IpA33NGRoxFuDjBNOccshfOfw/vTw/cV/HZ2iF3Vx9GoykzP5",
+	"m+uP1NLMYdTEFKIIXQ4VSCqU6MbIUtqYD6AhclpRf44N1J7JltzNXkiPmr7EYWM1Az7LGw9EqlJEniZQ",
+	"iVmAPJPNfBPF/vJSSMKTvNNcMbgGvUZSLFsCx3mX5svagHaf/I5tJ70wk3F+AQVfg1cXnDf7I+xcqpfe",
+	"/fiESM+q2dCFZia7KHQyHK6xz8Lzbj/JQYgJ3Sm9W2pCUCrPBJ6orqVVhO4Yjz1lr9+e/XL86tXRKZHI",
+	"P9c1AF0y+Qf0TTsKn6gljsTVKEBl0k7gzqQ+bOeidAi+jarl7aDjdyFkBu6cfKCspZ7vHyPzmWwpGTZE",
+	"kU3i1yrOeZo6W+HZtE1j2lrxddyLJmiUJoASG1Qz6S0q/EVpsRSIvU+kRkBAZnpdWchHONoxr3G81ZEe",
+	"DXsHyDPpQKfbcC4bMK7tBpVNynhhVOMgEhnTDlunM9lUcIwUnzZHEFtSk051cg6F5cieGniJ2KwEWpKj",
+	"NSmMFzd8bWYS8Rtg6+BGtM21qqrQ2cBGb3JQNkvFmvy3C6u6gosOE/hLK2eyqaJACVkUXh47peCqKbzK",
+	"UxVIXokkTZrkfvJkMqVSWP/TQfJ0gl9hoteuSL/u8krshnPdNW3hz3Ksz9gJ3qMowj9gCKGjO8Ze+/5I",
+	"uBu4reim5MjboWvQhIjmcgIzSTv5q+u/9JNZy+ynuHfXX5d2sjfd29+ZPtmZPrmYTg/of/9NjWxazvgr",
+	"fJocFsK98KzAd9ObSderCaOJcyG9JRQ996cNHJ6+cjRV4bHZcU6PeG0nn+0KPeJGj/8cD9C1Q3Z957a7",
+	"9MGRro3eFgNdF7YtBvYb320xJW60tcXwYbezLSb1e2NuMaXfw+ruQ5o0PSuQXfem014Ii1dVgZaBUHL3",
+	"o3H5spE2U1tUgIVihtEeVL02SPh6OtxWygciF7ZmGsl/d68R3N70+Z8B6+H7Y84qvmxDNoONCNPuI/XN",
+	"JV3fUwdi5wzdjpHqNS5bsAFC14y1VKNMsQ0cOFjbJxKnOy9e7O49m97bjg+3uv9IzniwnUfziH+Ekr/w",
+	"5ulxQmvvf7u1mze+uPKzb7nr5s3ruWvmQxPirh9OljaWQFw2N1aScW2y/acWal3vC1fAUykz+uAxPKUL",
+	"kLvFc8hOIOmplG8HEEWshDWtype97hTY/DXApJe6M2mEXBatFdF7PMW4ZZzinM6454OGt8FKgxxvj/Pl",
+	"2q/a5rIzSc1v465Htvvke2On1772GmtB8WgF1uuqu4Wwdl2CnYgmpH/BKPPX4sb7W570qoisruFuoCr2",
+	"/nBkNt+V0Mgi+beLpqffbu24f0HHJSXfWCqLbhx1Lug28fz3ytD96Ytvt3Jor/BnEd6IxTfkkJG2BgMF",
+	"4q5aT4tsardk1lA9Wf7+XD3brz66FUddnl1Bj75x3riSece1AcbZbzD/x8UF+wF7D/zIlGbn9fxMVOyH",
+	"idH2xzjzCre+R+p8zS6AlwZH/7dSZercJyZsaITisZnJr6e4zuMGwORkh6rpmZzV0+nT7DqMoY/ArhVS",
+	"3nK0rZR2wzmbhYTRAZslrNKwELdOt8XZsayOasN52WYacfUS9JISFyfiqtscPgQW00hROo0a6URqVDdQ",
+	"niEUSVryEepww+P+P4k+LOvCCvRmdzGZuZNzy7e/XQ/0LfiuE//zdOK/STPt7+19u4Xfy0orqnTDVO+R",
+	"tMKuPbEiabsQBYkBqxQruEb3Urc1el5qt+Iah3/XsRt0rBMkkUzu0TnZrEY/N8GmO6dDC7AjAYBX9H3X",
+	"Z1NLoGQUyW9hzbAFvs9JxzHdFNWijwBTZmcykPhurTfNc6CexNsfqQRp2rl9N5H/Z4YZPH/xmDyjEW2M",
+	"R8QWSdyUoMeSttOrBRmUaiBsv1kLw0wn43ImnUI7oD8FsAvXGBBz6YbQpLnTKS406sbf1cLvbuccpGVH",
+	"ONccRNkJgtbgNZOj3dTi5gqyKXFwc02wPbNVLa98NwZsZdDsnrJEjcGVuoTJTPrZDUkqHzVUta3q6EEK",
+	"ZZ9cDKSH9Aok9eG2vo9NKE7w3T5cloboMJOUB3ZN1uJjcpAIZ2pVo3RoQePa5TwYw1//sj7Ok38xevyY",
+	"8CvGX/ts0IU2lNLd48eiFgMMbULHK387f3vKXCHDQZcyhlGiirMuFulMDpiA+d/ehR8om4pHPRjyyjHA",
+	"SH3l4I6+6Tln38Xof0i0do3e7PGrTU8Ef775i11NpwL+Aj5i23Wq6C+AYPKx/QMgjcGQ9L2T+0L6HwYW",
+	"SGR57DoLYafJk9yfzOxU2/lauviV8aZ+Bt7rbh5md4of395I733PZHgRFhL19z71pvtX0z2H4L0bVVzH",
+	"GDUdn8baPc0kZUZmSdPxaZakTEPByVX3SHS6vzxUI36/RG3LrEzyDfNx9/V3GEvJfRdC/3OF0OCaxnZa",
+	"8qeQM7ufW4b03s8fhVQ6Cite/nF7RPLZbDX2t/v0VZ/2jLvmbBQkVJWr86K8FVZcjNeCM9fiLfSScwCG",
+	"nppL7481HfsjMkibakS3ipN9dUMxlmabpdf3oNh3ybm95Hzv/3xbt8mojG9zcne/gIvfNo1aUS9dc7Ju",
+	"GWu/z190RG37yF54JwT0fXOig/bJUOpCO+lMuv5pVB5Sa2lYBbrt0kETXS++th9CuqHJWvR00HucHXQM",
+	"/gmZYu2sJE8/bXv2kgmleyApmT9s+Ca0b/nGLXvybOo/INalkLWFB+yq6InOHy5+mrW+207/gbZTk44L",
+	"x/wnMaBczPZhF+0RFcVpp1kW/oAF/oNrhqDjdnjf1HWhFb97Lf8rbl7z3uTbXLeNRXIr3iuS83lyvFqu",
+	"3QRdre67ppRe0uHBUwi5eWVy7q5VB15cu9bUAQToDrYr9TT+jacwNI8oNKYJ42YEf5APMNbvYCv7/8lX",
+	"DxQ7kTCSd3Vl0t/t/+8y6LEyyN35SO5soYt3P3faidxFnx/I6Z5bVQV9OxQ0dtUTBiPuv3vR9j1T+7+K",
+	"R2Ou+SYqcjx61uH6e+E9uhXP/es92p5G8hE9HV1qXSQH9OdZD3Z3C/xTwitl7MHP05+nyd2Hu/8/AK+8",
+	"nh6ghgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,42 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package middleware
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type acceptKey struct{}
+
+// Accept stores the Accept header of the request in its context, so that the strict handlers, which only receive the
+// context, can negotiate the media type of their response
+func Accept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if accept := r.Header.Values("Accept"); len(accept) > 0 {
+			r = r.WithContext(context.WithValue(r.Context(), acceptKey{}, strings.Join(accept, ",")))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Accepts tells whether the Accept header stored in ctx explicitly lists the media type, wildcards excluded
+func Accepts(ctx context.Context, mediaType string) bool {
+	accept, _ := ctx.Value(acceptKey{}).(string)
+	for _, value := range strings.Split(accept, ",") {
+		accepted, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err != nil || accepted != mediaType {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if weight, err := strconv.ParseFloat(q, 64); err != nil || weight == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
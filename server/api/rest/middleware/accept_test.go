@@ -0,0 +1,42 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccepts(t *testing.T) {
+	tests := []struct {
+		name     string
+		accept   []string
+		expected bool
+	}{
+		{name: "Exact", accept: []string{"text/event-stream"}, expected: true},
+		{name: "List", accept: []string{"application/json, text/event-stream;q=0.9"}, expected: true},
+		{name: "SeveralHeaders", accept: []string{"application/json", "text/event-stream"}, expected: true},
+		{name: "Refused", accept: []string{"text/event-stream;q=0, application/json"}, expected: false},
+		{name: "Wildcard", accept: []string{"*/*"}, expected: false},
+		{name: "Missing", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/meetings/summary/m1", nil)
+			for _, value := range tt.accept {
+				req.Header.Add("Accept", value)
+			}
+			var accepts bool
+			handler := Accept(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				accepts = Accepts(r.Context(), "text/event-stream")
+			}))
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.expected, accepts)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package middleware
+
+import (
+	"context"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models/errorresponse"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Identify returns the estate, initiator, groups and roles authenticated for the request of ctx, ok is false when the
+// request was not authenticated
+type Identify func(ctx context.Context) (tenant tenancy.Tenant, ok bool)
+
+// NewTenant returns a middleware storing the tenant of the request in its context, identified by the authentication
+// which ran before it. The client headers never select the estate, the initiator or its groups and roles. The service
+// only sees the meetings of this estate, the requests without an authenticated estate are answered with a 401
+// ErrorResponse.
+func NewTenant(identify Identify) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenant, ok := identify(r.Context())
+			if !ok || tenant.EstateID == "" {
+				errorresponse.ResponseErrorHandlerFunc(w, r, errorresponse.ErrUnauthenticated)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(tenancy.NewContext(r.Context(), tenant)))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenant(t *testing.T) {
+	tests := []struct {
+		name          string
+		authenticated *tenancy.Tenant
+		expected      *tenancy.Tenant
+	}{
+		{name: "Tenant",
+			authenticated: &tenancy.Tenant{EstateID: "e1", InitiatorID: "u1", Groups: []string{"g1"}, Roles: []string{"admin"}},
+			expected:      &tenancy.Tenant{EstateID: "e1", InitiatorID: "u1", Groups: []string{"g1"}, Roles: []string{"admin"}}},
+		{name: "NotAdmin", authenticated: &tenancy.Tenant{EstateID: "e1", InitiatorID: "u1"},
+			expected: &tenancy.Tenant{EstateID: "e1", InitiatorID: "u1"}},
+		{name: "NoInitiator", authenticated: &tenancy.Tenant{EstateID: "e1"}, expected: &tenancy.Tenant{EstateID: "e1"}},
+		{name: "NoEstate", authenticated: &tenancy.Tenant{InitiatorID: "u1"}},
+		{name: "NotAuthenticated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/meetings/summary/m1", nil)
+			// the identity headers of the clients are ignored
+			req.Header.Set("X-Estate-Id", "e2")
+			req.Header.Set("X-Initiator-Id", "u2")
+			req.Header.Set("X-Initiator-Groups", "g2")
+			req.Header.Set("X-Initiator-Roles", "admin")
+			recorder := httptest.NewRecorder()
+			var served *tenancy.Tenant
+			identify := func(context.Context) (tenancy.Tenant, bool) {
+				if tt.authenticated == nil {
+					return tenancy.Tenant{}, false
+				}
+				return *tt.authenticated, true
+			}
+			handler := NewTenant(identify)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				tenant, ok := tenancy.FromContext(r.Context())
+				require.True(t, ok)
+				served = &tenant
+			}))
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expected, served)
+			if tt.expected == nil {
+				var body generated.ErrorResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+				assert.Equal(t, "UNAUTHENTICATED", *(*body.Messages)[0].Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package middleware holds the http middlewares of the rest api
+package middleware
+
+import (
+	"fmt"
+	"meeting-analyzer/server/models/errorresponse"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gorilla/mux"
+)
+
+// NewRequestValidator returns a middleware validating the parameters and bodies of the requests against the spec
+// before they reach the handlers. An invalid request is answered with a 400 ErrorResponse holding a message per
+// invalid field. The requests of paths missing from the spec are left to the router.
+func NewRequestValidator(swagger *openapi3.T) (mux.MiddlewareFunc, error) {
+	router, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		return nil, err
+	}
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		// the handlers apply the defaults of the parameters themselves
+		SkipSettingDefaults: true,
+	}
+	// multipart uploads are streamed and validated by the controller instead of being read in memory here
+	multipartOptions := *options
+	multipartOptions.ExcludeRequestBody = true
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if isMultipart(r) {
+				input.Options = &multipartOptions
+			}
+			if err = openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				errorresponse.ResponseErrorHandlerFunc(w, r,
+					errorresponse.CreateValidationErrorResponse(validationMessages("", err)))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+func isMultipart(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && strings.HasPrefix(mediaType, "multipart/")
+}
+
+// validationMessages flattens the validation errors into a message per invalid field, prefixed with the path of
+// the field in the body, like transcription.0.timestamp, or the name of the parameter. The messages of kin-openapi
+// never include the invalid values.
+func validationMessages(field string, err error) []string {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		messages := make([]string, 0, len(e))
+		for _, child := range e {
+			messages = append(messages, validationMessages(field, child)...)
+		}
+		return messages
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			field = e.Parameter.Name
+		}
+		if e.Err == nil {
+			return []string{fieldMessage(field, e.Reason)}
+		}
+		return validationMessages(field, e.Err)
+	case *openapi3.SchemaError:
+		reason := e.Reason
+		if e.SchemaField == "format" && e.Schema != nil {
+			// the reason of a format holds its regular expression, which does not help the clients
+			reason = fmt.Sprintf("string doesn't match the format %q", e.Schema.Format)
+		}
+		return []string{fieldMessage(joinField(field, e.JSONPointer()), reason)}
+	default:
+		return []string{fieldMessage(field, err.Error())}
+	}
+}
+
+func joinField(field string, pointer []string) string {
+	if field == "" {
+		return strings.Join(pointer, ".")
+	}
+	return strings.Join(append([]string{field}, pointer...), ".")
+}
+
+func fieldMessage(field string, reason string) string {
+	if field == "" {
+		return reason
+	}
+	return field + ": " + reason
+}
@@ -0,0 +1,155 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"meeting-analyzer/server/api/rest/generated"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRouter returns a router of the spec validated by the middleware, whose handlers answer 204
+func newTestRouter(t *testing.T) *mux.Router {
+	swagger, err := generated.GetSwagger()
+	require.NoError(t, err)
+	swagger.Servers = nil
+	validator, err := NewRequestValidator(swagger)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.Use(validator)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	router.Handle("/api/meetings/summary", handler).Methods(http.MethodGet, http.MethodPost)
+	router.Handle("/api/meetings/summary/import", handler).Methods(http.MethodPost)
+	router.Handle("/api/unspecified", handler)
+	return router
+}
+
+func serve(router *mux.Router, method string, target string, contentType string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func errorMessages(t *testing.T, recorder *httptest.ResponseRecorder) []string {
+	var body generated.ErrorResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, generated.N400, *body.HttpStatusCode)
+	messages := make([]string, 0)
+	for _, message := range *body.Messages {
+		messages = append(messages, *message.Message)
+	}
+	return messages
+}
+
+func TestRequestValidator_ValidRequest(t *testing.T) {
+	body := `{"meeting_id":"m1","meeting_title":"Weekly","transcription":[
+		{"member_name":"Alice","timestamp":"2024-01-01T10:00:00Z","content":"Hello"}]}`
+
+	recorder := serve(newTestRouter(t), http.MethodPost, "/api/meetings/summary", "application/json", []byte(body))
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+func TestRequestValidator_InvalidBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected []string
+	}{
+		{
+			name: "MissingMeetingID",
+			body: `{"meeting_title":"Weekly","transcription":[
+				{"member_name":"Alice","timestamp":"2024-01-01T10:00:00Z","content":"Hello"}]}`,
+			expected: []string{`meeting_id: property "meeting_id" is missing`},
+		},
+		{
+			name: "InvalidTimestamp",
+			body: `{"meeting_id":"m1","meeting_title":"Weekly","transcription":[
+				{"member_name":"Alice","timestamp":"01/01/2024 10:00","content":"Hello"}]}`,
+			expected: []string{`transcription.0.timestamp: string doesn't match the format "date-time"`},
+		},
+		{
+			name:     "EmptyTranscription",
+			body:     `{"meeting_id":"","meeting_title":"Weekly","transcription":[]}`,
+			expected: []string{"meeting_id: minimum string length is 1", "transcription: minimum number of items is 1"},
+		},
+		{
+			name: "EmptyContent",
+			body: `{"meeting_id":"m1","meeting_title":"Weekly","transcription":[
+				{"member_name":"Alice","timestamp":"2024-01-01T10:00:00Z"},
+				{"member_name":"Bob","timestamp":"2024-01-01T10:00:01Z","content":""}]}`,
+			expected: []string{`transcription.0.content: property "content" is missing`,
+				"transcription.1.content: minimum string length is 1"},
+		},
+		{
+			name: "TooLong",
+			body: `{"meeting_id":"` + strings.Repeat("m", 257) + `","meeting_title":"Weekly","transcription":[
+				{"member_name":"` + strings.Repeat("a", 257) + `","timestamp":"2024-01-01T10:00:00Z","content":"Hello"}]}`,
+			expected: []string{"meeting_id: maximum string length is 256",
+				"transcription.0.member_name: maximum string length is 256"},
+		},
+		{
+			name:     "MissingBody",
+			body:     "",
+			expected: []string{"value is required but missing"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(newTestRouter(t), http.MethodPost, "/api/meetings/summary", "application/json",
+				[]byte(tt.body))
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			assert.ElementsMatch(t, tt.expected, errorMessages(t, recorder))
+		})
+	}
+}
+
+func TestRequestValidator_MalformedJSON(t *testing.T) {
+	recorder := serve(newTestRouter(t), http.MethodPost, "/api/meetings/summary", "application/json",
+		[]byte(`{"meeting_id":`))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Len(t, errorMessages(t, recorder), 1)
+}
+
+func TestRequestValidator_InvalidParameter(t *testing.T) {
+	recorder := serve(newTestRouter(t), http.MethodGet, "/api/meetings/summary?limit=many", "", nil)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	messages := errorMessages(t, recorder)
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "limit: ")
+}
+
+func TestRequestValidator_SkipsMultipartBody(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("meeting_id", "m1"))
+	require.NoError(t, writer.Close())
+
+	recorder := serve(newTestRouter(t), http.MethodPost, "/api/meetings/summary/import", writer.FormDataContentType(),
+		body.Bytes())
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+func TestRequestValidator_UnspecifiedRoute(t *testing.T) {
+	recorder := serve(newTestRouter(t), http.MethodGet, "/api/unspecified", "", nil)
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+}
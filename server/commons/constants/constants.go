@@ -14,4 +14,45 @@ const (
 	EnvVarDBUser                 = "POSTGRES_USER"
 	EnvVarDBHost                 = "POSTGRES_HOST"
 	EnvVarDBPort                 = "POSTGRES_PORT"
+	EnvVarLLMBaseURL             = "LLM_BASE_URL"
+	EnvVarLLMAPIKey              = "LLM_API_KEY"
+	EnvVarLLMAPIKeyFile          = "LLM_API_KEY_FILE"
+	EnvVarLLMModel               = "LLM_MODEL"
+	EnvVarLLMTimeout             = "LLM_TIMEOUT_SECONDS"
+	DefaultLLMBaseURL            = "https://chat.dell.com/api"
+	DefaultLLMTimeout            = 120
+	EnvVarLLMChunkTokens         = "LLM_CHUNK_TOKENS"
+	EnvVarLLMChunkOverlapTokens  = "LLM_CHUNK_OVERLAP_TOKENS"
+	EnvVarLLMMaxAttempts         = "LLM_MAX_ATTEMPTS"
+	EnvVarLLMInitialBackoff      = "LLM_INITIAL_BACKOFF_MS"
+	EnvVarLLMMaxBackoff          = "LLM_MAX_BACKOFF_SECONDS"
+	EnvVarLLMBreakerThreshold    = "LLM_BREAKER_THRESHOLD"
+	EnvVarLLMBreakerCooldown     = "LLM_BREAKER_COOLDOWN_SECONDS"
+	EnvVarLLMExtractiveFallback  = "LLM_EXTRACTIVE_FALLBACK"
+	EnvVarSummaryWorkers         = "SUMMARY_WORKERS"
+	EnvVarSummaryQueueSize       = "SUMMARY_QUEUE_SIZE"
+	DefaultSummaryWorkers        = 4
+	DefaultSummaryQueueSize      = 100
+	EnvVarSummaryInstanceID      = "SUMMARY_INSTANCE_ID"
+	EnvVarSummaryJobLease        = "SUMMARY_JOB_LEASE_SECONDS"
+	DefaultSummaryJobLease       = 120
+	EnvVarRedactionCategories    = "REDACTION_CATEGORIES"
+	EnvVarRedactionKey           = "REDACTION_KEY"
+	EnvVarRedactionKeyFile       = "REDACTION_KEY_FILE"
+	EnvVarRedactionPreviousKeys  = "REDACTION_PREVIOUS_KEYS"
+	EnvVarRedactionPreviousFile  = "REDACTION_PREVIOUS_KEYS_FILE"
+	EnvVarVaultAddr              = "VAULT_ADDR"
+	EnvVarVaultRoleID            = "VAULT_ROLE_ID"
+	EnvVarVaultSecretID          = "VAULT_SECRET_ID"
+	EnvVarVaultSecretIDFile      = "VAULT_SECRET_ID_FILE"
+	EnvVarVaultAuthMount         = "VAULT_APPROLE_MOUNT"
+	EnvVarVaultKVMount           = "VAULT_KV_MOUNT"
+	EnvVarVaultLLMSecretPath     = "VAULT_LLM_SECRET_PATH"
+	EnvVarVaultLLMSecretKey      = "VAULT_LLM_SECRET_KEY"
+	EnvVarVaultRedactionPath     = "VAULT_REDACTION_SECRET_PATH"
+	EnvVarVaultRedactionKey      = "VAULT_REDACTION_SECRET_KEY"
+	DefaultVaultRedactionKey     = "key"
+	EnvVarVaultRedactionPrevious = "VAULT_REDACTION_PREVIOUS_SECRET_KEY"
+	EnvVarVaultRefresh           = "VAULT_REFRESH_SECONDS"
+	DefaultVaultRefresh          = 300
 )
@@ -0,0 +1,42 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package tenancy carries the tenant of a request through the contexts of its processing
+package tenancy
+
+import (
+	"context"
+	"slices"
+)
+
+// RoleAdmin is the role of the initiators administering their estate, who may read and manage all its meetings
+const RoleAdmin = "admin"
+
+// Tenant is the caller of a request, the estate owning the meetings and the initiator acting for it
+type Tenant struct {
+	// EstateID isolates the meetings, summaries and jobs of the estates from each other
+	EstateID string
+	// InitiatorID is the user or service which sent the request, the creator of the rows it stores
+	InitiatorID string
+	// Groups are the groups of the initiator, the meetings shared with one of them are shared with the initiator
+	Groups []string
+	// Roles are the roles of the initiator in the estate
+	Roles []string
+}
+
+// IsAdmin tells whether the initiator has the RoleAdmin role in the estate
+func (t Tenant) IsAdmin() bool {
+	return slices.Contains(t.Roles, RoleAdmin)
+}
+
+type tenantKey struct{}
+
+// NewContext returns a copy of ctx carrying the tenant
+func NewContext(ctx context.Context, tenant Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// FromContext returns the tenant carried by ctx, ok is false when ctx carries no tenant with an estate
+func FromContext(ctx context.Context) (Tenant, bool) {
+	tenant, _ := ctx.Value(tenantKey{}).(Tenant)
+	return tenant, tenant.EstateID != ""
+}
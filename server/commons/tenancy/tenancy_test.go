@@ -0,0 +1,39 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected Tenant
+		ok       bool
+	}{
+		{name: "Tenant", ctx: NewContext(context.Background(), Tenant{EstateID: "e1", InitiatorID: "u1"}),
+			expected: Tenant{EstateID: "e1", InitiatorID: "u1"}, ok: true},
+		{name: "NoTenant", ctx: context.Background()},
+		{name: "NoEstate", ctx: NewContext(context.Background(), Tenant{InitiatorID: "u1"}),
+			expected: Tenant{InitiatorID: "u1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant, ok := FromContext(tt.ctx)
+
+			assert.Equal(t, tt.expected, tenant)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestTenant_IsAdmin(t *testing.T) {
+	assert.True(t, Tenant{Roles: []string{"auditor", RoleAdmin}}.IsAdmin())
+	assert.False(t, Tenant{Roles: []string{"auditor"}}.IsAdmin())
+	assert.False(t, Tenant{}.IsAdmin())
+}
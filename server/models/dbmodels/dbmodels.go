@@ -1 +1,174 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
 package dbmodels
+
+import (
+	"meeting-analyzer/server/api/rest/generated"
+	"time"
+)
+
+// Meeting is a row of the meetings table, EstateID is the estate owning the meeting and CreatedBy the initiator which
+// created it
+type Meeting struct {
+	MeetingID string
+	EstateID  string
+	CreatedBy string
+	Title     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TranscriptSegment is a row of the transcript_segments table, Seq keeps the original ordering
+type TranscriptSegment struct {
+	MeetingID  string
+	Seq        int
+	MemberName string
+	Timestamp  *time.Time
+	Content    string
+}
+
+// Summary is a row of the summaries table, Content is the prose summary of the meeting. Analytics is stored as JSON
+// and is nil for the summaries generated before the analytics were computed.
+type Summary struct {
+	MeetingID   string
+	EstateID    string
+	CreatedBy   string
+	Content     string
+	KeyPoints   []string
+	Decisions   []string
+	ActionItems []ActionItem
+	Analytics   *generated.MeetingAnalytics
+	// Redactions are the placeholders of the values redacted from the transcript, the content, key points, decisions
+	// and action items hold them. Their values are sealed in the meeting_redactions table.
+	Redactions []Redaction
+	Model      string
+	// PromptTemplate and PromptVersion identify the prompt templates which generated the summary
+	PromptTemplate string
+	PromptVersion  int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Redaction is stored as JSON in the summaries table, without the redacted value
+type Redaction struct {
+	Placeholder string                          `json:"placeholder"`
+	Category    generated.RedactionCategoryEnum `json:"category"`
+}
+
+// RedactedValue is a row of the meeting_redactions table, a value replaced by its placeholder in the stored title and
+// transcript of the meeting. Sealed is the value encrypted with the redaction key of KeyID, nil when the value was
+// dropped. KeyID is empty for the values sealed before the keys had ids.
+type RedactedValue struct {
+	Placeholder string
+	Category    generated.RedactionCategoryEnum
+	Sealed      []byte
+	KeyID       string
+}
+
+// ActionItem is stored as JSON in the summaries table, DueDate is formatted as YYYY-MM-DD
+type ActionItem struct {
+	Description string  `json:"description"`
+	Owner       *string `json:"owner,omitempty"`
+	DueDate     *string `json:"due_date,omitempty"`
+}
+
+// MeetingActionItem is a row of the action_items table. Owner is the member_name of a speaker and SegmentSeq the
+// transcript segment where the task was agreed, both are nil when they could not be matched. Segment is joined on
+// reads and DueDate only holds a date.
+type MeetingActionItem struct {
+	ItemID      string
+	MeetingID   string
+	Seq         int
+	Description string
+	Owner       *string
+	DueDate     *time.Time
+	DuePhrase   *string
+	SegmentSeq  *int
+	Segment     *TranscriptSegment
+	Done        bool
+	DoneAt      *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// SummaryJob is a row of the summary_jobs table. Owner is the instance of the service running the job, it renews the
+// lease of the job by updating HeartbeatAt until the job is finished.
+type SummaryJob struct {
+	JobID       string
+	MeetingID   string
+	EstateID    string
+	CreatedBy   string
+	Status      generated.JobStatusEnum
+	Error       *string
+	Owner       string
+	HeartbeatAt time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IdempotencyKey is a row of the idempotency_keys table, the response of the summary request sent with the key.
+// RequestHash identifies the body of the request, a key reused with another body is rejected. The keys of each estate
+// are distinct.
+type IdempotencyKey struct {
+	EstateID    string
+	Key         string
+	RequestHash string
+	MeetingID   string
+	JobID       string
+	Status      generated.JobStatusEnum
+	CreatedAt   time.Time
+}
+
+// MeetingShare is a row of the meeting_shares table, a user or group of the estate the meeting is shared with by
+// CreatedBy
+type MeetingShare struct {
+	MeetingID     string
+	PrincipalType generated.PrincipalTypeEnum
+	PrincipalID   string
+	CreatedBy     string
+	CreatedAt     time.Time
+}
+
+// MeetingListItem is a meeting joined with its participants, summary and latest job, both may be nil
+type MeetingListItem struct {
+	Meeting
+	Participants []string
+	Summary      *Summary
+	Job          *SummaryJob
+}
+
+// FilterOperator is a comparison supported by meeting filters
+type FilterOperator string
+
+// filter operators
+const (
+	OperatorEq    FilterOperator = "eq"
+	OperatorNeq   FilterOperator = "neq"
+	OperatorGt    FilterOperator = "gt"
+	OperatorGte   FilterOperator = "gte"
+	OperatorLt    FilterOperator = "lt"
+	OperatorLte   FilterOperator = "lte"
+	OperatorLike  FilterOperator = "like"
+	OperatorIlike FilterOperator = "ilike"
+)
+
+// Filter is a condition on a meeting field, Value is a string or a time.Time depending on the field
+type Filter struct {
+	Field    string
+	Operator FilterOperator
+	Value    any
+}
+
+// SortField orders meetings by a field
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// MeetingsQuery selects a sorted page of the meetings matching all the filters
+type MeetingsQuery struct {
+	Offset  int
+	Limit   int
+	Filters []Filter
+	Sort    []SortField
+}
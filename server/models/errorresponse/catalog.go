@@ -0,0 +1,105 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package errorresponse
+
+import (
+	"meeting-analyzer/server/api/rest/generated"
+	"strconv"
+	"strings"
+)
+
+// Error is an error of the catalog of the meeting analyzer. Its code is sent as the code of the error messages and
+// never changes once released, clients and the support documentation rely on it.
+type Error struct {
+	Code   string
+	Status generated.HTTPStatusEnum
+	// Message is the English message of the responses, its {0}, {1}... placeholders are replaced by the arguments
+	// attached with WithArgs. When it is empty the text of the whole error chain is sent, for the errors whose
+	// details tell the client what to fix.
+	Message string
+	text    string
+}
+
+// errorsByCode indexes the errors of the catalog by code
+var errorsByCode = map[string]*Error{}
+
+func newError(code string, status generated.HTTPStatusEnum, text string, message string) *Error {
+	err := &Error{Code: code, Status: status, Message: message, text: text}
+	errorsByCode[code] = err
+	return err
+}
+
+func (e *Error) Error() string {
+	return e.text
+}
+
+// render returns the message with the arguments in place of their placeholders
+func (e *Error) render(args []string) string {
+	return render(e.Message, args)
+}
+
+// render replaces the {0}, {1}... placeholders of the template by the arguments
+func render(template string, args []string) string {
+	replacements := make([]string, 0, 2*len(args))
+	for i, arg := range args {
+		replacements = append(replacements, "{"+strconv.Itoa(i)+"}", arg)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// argumentsError attaches the arguments of the message to a catalog error
+type argumentsError struct {
+	err  *Error
+	args []string
+}
+
+// WithArgs returns the catalog error with the arguments of its message, e.g. WithArgs(ErrMeetingIDNotFound, meetingID)
+func WithArgs(err *Error, args ...string) error {
+	return &argumentsError{err: err, args: args}
+}
+
+func (e *argumentsError) Error() string {
+	return e.err.Error() + ": " + strings.Join(e.args, ", ")
+}
+
+func (e *argumentsError) Unwrap() error {
+	return e.err
+}
+
+// catalog of the errors
+var (
+	ErrInvalidRequest          = newError("INVALID_REQUEST", generated.N400, "invalid request", "")
+	ErrBadPaginationParams     = newError("INVALID_PAGINATION", generated.N400, "offset must be non-negative and limit must be positive and less than or equal to 1000", "")
+	ErrInvalidFilterCategory   = newError("INVALID_FILTER_CATEGORY", generated.N400, "invalid category", "")
+	ErrInvalidFilterOperator   = newError("INVALID_FILTER_OPERATOR", generated.N400, "invalid operator", "")
+	ErrInvalidFilterField      = newError("INVALID_FILTER_FIELD", generated.N400, "invalid filter field", "")
+	ErrInvalidFilterValue      = newError("INVALID_FILTER_VALUE", generated.N400, "invalid filter value", "")
+	ErrInvalidSortField        = newError("INVALID_SORT_FIELD", generated.N400, "invalid sort field", "")
+	ErrInvalidTranscriptUpload = newError("INVALID_TRANSCRIPT_UPLOAD", generated.N400, "invalid transcript upload", "")
+	ErrUnknownSummaryStyle     = newError("UNKNOWN_SUMMARY_STYLE", generated.N400, "unknown summary style", "")
+	ErrUnknownSummaryEngine    = newError("UNKNOWN_SUMMARY_ENGINE", generated.N400, "unknown summary engine", "")
+
+	ErrUnauthenticated = newError("UNAUTHENTICATED", generated.N401, "request has no estate", "The request does not identify its estate")
+
+	ErrForbidden = newError("FORBIDDEN", generated.N403, "initiator is not allowed to access the meeting", "You are not allowed to do this on meeting {0}")
+
+	ErrMeetingIDNotFound  = newError("MEETING_NOT_FOUND", generated.N404, "meeting id not found", "Meeting {0} was not found")
+	ErrSummaryNotFound    = newError("SUMMARY_NOT_FOUND", generated.N404, "meeting summary not found", "Meeting {0} has no summary")
+	ErrJobIDNotFound      = newError("JOB_NOT_FOUND", generated.N404, "job id not found", "Summary job {0} was not found")
+	ErrActionItemNotFound = newError("ACTION_ITEM_NOT_FOUND", generated.N404, "action item not found", "Action item {1} of meeting {0} was not found")
+	ErrShareNotFound      = newError("SHARE_NOT_FOUND", generated.N404, "meeting share not found", "Meeting {0} is not shared with {1}")
+
+	ErrSummaryInProgress    = newError("SUMMARY_JOB_CONFLICT", generated.N409, "meeting summary is already being generated", "A summary of meeting {0} is already being generated")
+	ErrSummaryAlreadyExists = newError("SUMMARY_ALREADY_EXISTS", generated.N409, "meeting summary already exists", "Meeting {0} already has a summary, use force to regenerate it")
+	ErrIdempotencyKeyReused = newError("IDEMPOTENCY_KEY_REUSED", generated.N409, "idempotency key was used for another request", "The idempotency key {0} was already used for another request")
+
+	ErrTranscriptTooLarge          = newError("TRANSCRIPT_TOO_LARGE", generated.N422, "transcript file is too large", "The transcript file exceeds the maximum size of {0} bytes")
+	ErrUnsupportedTranscriptFormat = newError("UNSUPPORTED_TRANSCRIPT_FORMAT", generated.N422, "unsupported transcript format", "The transcript file is not a valid WebVTT (.vtt) or SubRip (.srt) file: {0}")
+	ErrLLMOutputInvalid            = newError("LLM_OUTPUT_INVALID", generated.N422, "llm returned an invalid summary", "The summarization model returned an invalid summary of meeting {0}")
+
+	ErrJobQueueFull   = newError("JOB_QUEUE_FULL", generated.N503, "summary job queue is full", "Too many summaries are being generated, try again later")
+	ErrLLMUnavailable = newError("LLM_UNAVAILABLE", generated.N503, "llm provider is unavailable", "The summarization model is unavailable, try again later")
+
+	// ErrInternal is the catalog error of the errors outside of the catalog
+	ErrInternal = newError("INTERNAL_ERROR", generated.N500, "internal error", "")
+)
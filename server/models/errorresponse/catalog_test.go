@@ -0,0 +1,39 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package errorresponse
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var catalog = []*Error{
+	ErrInvalidRequest, ErrBadPaginationParams, ErrInvalidFilterCategory, ErrInvalidFilterOperator, ErrInvalidFilterField,
+	ErrInvalidFilterValue, ErrInvalidSortField, ErrInvalidTranscriptUpload, ErrUnknownSummaryStyle,
+	ErrUnknownSummaryEngine, ErrUnauthenticated, ErrForbidden, ErrMeetingIDNotFound, ErrSummaryNotFound, ErrJobIDNotFound,
+	ErrActionItemNotFound, ErrShareNotFound, ErrSummaryInProgress, ErrSummaryAlreadyExists, ErrIdempotencyKeyReused,
+	ErrTranscriptTooLarge,
+	ErrUnsupportedTranscriptFormat, ErrLLMOutputInvalid, ErrJobQueueFull, ErrLLMUnavailable, ErrInternal,
+}
+
+func TestCatalog(t *testing.T) {
+	codePattern := regexp.MustCompile(`^[A-Z]+(_[A-Z]+)*$`)
+	codes := make(map[string]bool)
+	for _, err := range catalog {
+		assert.Regexp(t, codePattern, err.Code)
+		assert.False(t, codes[err.Code], "duplicate code %s", err.Code)
+		codes[err.Code] = true
+		assert.NotEmpty(t, err.Error())
+	}
+}
+
+func TestWithArgs(t *testing.T) {
+	err := fmt.Errorf("failed to delete: %w", WithArgs(ErrActionItemNotFound, "m1", "a1"))
+
+	assert.ErrorIs(t, err, ErrActionItemNotFound)
+	assert.EqualError(t, err, "failed to delete: action item not found: m1, a1")
+	assert.Equal(t, "Action item a1 of meeting m1 was not found", ErrActionItemNotFound.render([]string{"m1", "a1"}))
+}
@@ -3,6 +3,7 @@ package errorresponse
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"meeting-analyzer/server/commons/utils"
 	"net/http"
 	"time"
@@ -12,17 +13,6 @@ import (
 	"eos2git.cec.lab.emc.com/ISG-Edge/hzp-powerapi-lib-go/powerapi"
 )
 
-// errors
-var (
-	ErrBadPaginationParams       = errors.New("offset must be non-negative and limit must be positive and less than or equal to 1000")
-	ErrCheckDriftConflict        = errors.New("another check drift was invoked")
-	ErrDeploymentIDNotFound      = errors.New("deployment id not found")
-	ErrExecutionIDNotFound       = errors.New("execution id not found")
-	ErrInvalidFilterCategory     = errors.New("invalid category")
-	ErrInvalidFilterOperator     = errors.New("invalid operator")
-	ErrBlueprintRevisionNotFound = errors.New("blueprint revision not found")
-)
-
 type ServiceErrorResponse generated.ErrorResponse
 
 func (e *ServiceErrorResponse) Error() string {
@@ -35,16 +25,23 @@ func (e *ServiceErrorResponse) Error() string {
 
 var StrictHTTPServerOptions = generated.StrictHTTPServerOptions{
 	RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		ResponseErrorHandlerFunc(w, r, fmt.Errorf("%w: %w", ErrInvalidRequest, err))
 	},
 	ResponseErrorHandlerFunc: ResponseErrorHandlerFunc,
 }
 
-func ResponseErrorHandlerFunc(w http.ResponseWriter, _ *http.Request, err error) {
-	errorResponse := CreateErrorResponse(err)
+// ResponseErrorHandlerFunc writes the error response of the error, its messages are localized in the language
+// negotiated from the Accept-Language header of the request
+func ResponseErrorHandlerFunc(w http.ResponseWriter, r *http.Request, err error) {
+	language := DefaultLanguage
+	if r != nil {
+		language = NegotiateLanguage(r.Header.Get("Accept-Language"))
+	}
+	errorResponse := Localize(CreateErrorResponse(err), language)
 
 	// Set the content type to application/json
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", language)
 
 	// Set the status code in the response header
 	w.WriteHeader(GetStatusCode(errorResponse))
@@ -55,34 +52,56 @@ func ResponseErrorHandlerFunc(w http.ResponseWriter, _ *http.Request, err error)
 	}
 }
 
-// CreateErrorResponse creates an ErrorResponse based on the provided error.
+// CreateErrorResponse creates an ErrorResponse based on the provided error, the errors of the catalog are sent with
+// their code, status and arguments and the other errors as a 500.
 // NOTE: If you add a new API, ensure that all possible status codes returned by the backend
-// are defined in the openapi specification and handled in this function.
+// are defined in the openapi specification and added to the catalog.
 func CreateErrorResponse(err error) *ServiceErrorResponse {
-	var errMsg string
-	var statusCode generated.HTTPStatusEnum
 	var errorResponse *ServiceErrorResponse
 	var parseError *powerapi.ParseFilterError
+	var catalogErr *Error
 
 	switch {
 	case errors.As(err, &errorResponse):
 		return errorResponse
-	case errors.Is(err, ErrBadPaginationParams), errors.As(err, &parseError), errors.Is(err, ErrInvalidFilterCategory), errors.Is(err, ErrInvalidFilterOperator):
-		errMsg = err.Error()
-		statusCode = generated.N400
-	case errors.Is(err, ErrDeploymentIDNotFound), errors.Is(err, ErrExecutionIDNotFound), errors.Is(err, ErrBlueprintRevisionNotFound):
-		errMsg = "No data found"
-		statusCode = generated.N404
-	case errors.Is(err, ErrCheckDriftConflict):
-		errMsg = "Check drift was already invoked and is in progress"
-		statusCode = generated.N409
+	case errors.As(err, &catalogErr):
+	case errors.As(err, &parseError):
+		catalogErr = ErrInvalidFilterValue
 	default:
-		errMsg = err.Error()
-		statusCode = generated.N500
+		catalogErr = ErrInternal
+	}
+
+	var args []string
+	var withArgs *argumentsError
+	if errors.As(err, &withArgs) && withArgs.err == catalogErr {
+		args = withArgs.args
+	}
+	errMsg := err.Error()
+	if catalogErr.Message != "" {
+		errMsg = catalogErr.render(args)
+	}
+	messages := CreateErrorMessages(errMsg)
+	(*messages)[0].Code = utils.ToPointer(catalogErr.Code)
+	if len(args) > 0 {
+		(*messages)[0].Arguments = &args
+	}
+	return &ServiceErrorResponse{
+		HttpStatusCode: utils.ToPointer(catalogErr.Status),
+		Messages:       messages,
+	}
+}
+
+// CreateValidationErrorResponse creates the 400 ErrorResponse of an invalid request, with a message per invalid field
+func CreateValidationErrorResponse(messages []string) *ServiceErrorResponse {
+	errorMessages := make([]generated.ErrorMessage, 0, len(messages))
+	for _, message := range messages {
+		errorMessage := (*CreateErrorMessages(message))[0]
+		errorMessage.Code = utils.ToPointer(ErrInvalidRequest.Code)
+		errorMessages = append(errorMessages, errorMessage)
 	}
 	return &ServiceErrorResponse{
-		HttpStatusCode: utils.ToPointer(statusCode),
-		Messages:       CreateErrorMessages(errMsg),
+		HttpStatusCode: utils.ToPointer(generated.N400),
+		Messages:       &errorMessages,
 	}
 }
 
@@ -3,6 +3,7 @@ package errorresponse
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"meeting-analyzer/server/api/rest/generated"
 	"meeting-analyzer/server/commons/utils"
 	"net/http"
@@ -28,6 +29,7 @@ func TestResponseErrorHandlerFunc(t *testing.T) {
 				HttpStatusCode: utils.ToPointer(generated.N400),
 				Messages: &[]generated.ErrorMessage{
 					{
+						Code:      utils.ToPointer("INVALID_PAGINATION"),
 						Message:   utils.ToPointer("offset must be non-negative and limit must be positive and less than or equal to 1000"),
 						Severity:  utils.ToPointer(generated.ERROR),
 						Timestamp: utils.ToPointer(time.Now()),
@@ -36,29 +38,16 @@ func TestResponseErrorHandlerFunc(t *testing.T) {
 			},
 		},
 		{
-			name:           "DeploymentNotFound",
-			err:            ErrDeploymentIDNotFound,
+			name:           "MeetingIDNotFound",
+			err:            fmt.Errorf("failed to load meeting: %w", WithArgs(ErrMeetingIDNotFound, "m1")),
 			expectedStatus: http.StatusNotFound,
 			expectedBody: &generated.ErrorResponse{
 				HttpStatusCode: utils.ToPointer(generated.N404),
 				Messages: &[]generated.ErrorMessage{
 					{
-						Message:   utils.ToPointer("No data found"),
-						Severity:  utils.ToPointer(generated.ERROR),
-						Timestamp: utils.ToPointer(time.Now()),
-					},
-				},
-			},
-		},
-		{
-			name:           "CheckDriftConflict",
-			err:            ErrCheckDriftConflict,
-			expectedStatus: http.StatusConflict,
-			expectedBody: &generated.ErrorResponse{
-				HttpStatusCode: utils.ToPointer(generated.N409),
-				Messages: &[]generated.ErrorMessage{
-					{
-						Message:   utils.ToPointer("Check drift was already invoked and is in progress"),
+						Code:      utils.ToPointer("MEETING_NOT_FOUND"),
+						Message:   utils.ToPointer("Meeting m1 was not found"),
+						Arguments: &[]string{"m1"},
 						Severity:  utils.ToPointer(generated.ERROR),
 						Timestamp: utils.ToPointer(time.Now()),
 					},
@@ -73,7 +62,10 @@ func TestResponseErrorHandlerFunc(t *testing.T) {
 				HttpStatusCode: utils.ToPointer(generated.N500),
 				Messages: &[]generated.ErrorMessage{
 					{
-						Message: utils.ToPointer("internal server error"),
+						Code:      utils.ToPointer("INTERNAL_ERROR"),
+						Message:   utils.ToPointer("internal server error"),
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
 					},
 				},
 			},
@@ -86,6 +78,7 @@ func TestResponseErrorHandlerFunc(t *testing.T) {
 				HttpStatusCode: utils.ToPointer(generated.N400),
 				Messages: &[]generated.ErrorMessage{
 					{
+						Code:      utils.ToPointer("INVALID_FILTER_CATEGORY"),
 						Message:   utils.ToPointer("invalid category"),
 						Severity:  utils.ToPointer(generated.ERROR),
 						Timestamp: utils.ToPointer(time.Now()),
@@ -101,6 +94,7 @@ func TestResponseErrorHandlerFunc(t *testing.T) {
 				HttpStatusCode: utils.ToPointer(generated.N400),
 				Messages: &[]generated.ErrorMessage{
 					{
+						Code:      utils.ToPointer("INVALID_FILTER_OPERATOR"),
 						Message:   utils.ToPointer("invalid operator"),
 						Severity:  utils.ToPointer(generated.ERROR),
 						Timestamp: utils.ToPointer(time.Now()),
@@ -109,16 +103,282 @@ func TestResponseErrorHandlerFunc(t *testing.T) {
 			},
 		},
 		{
-			name:           "BlueprintRevisionNotFound",  // New test case
-			err:            ErrBlueprintRevisionNotFound, // New test case
-			expectedStatus: http.StatusNotFound,          // New test case
+			name:           "SummaryNotFound",
+			err:            WithArgs(ErrSummaryNotFound, "m1"),
+			expectedStatus: http.StatusNotFound,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N404),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("SUMMARY_NOT_FOUND"),
+						Message:   utils.ToPointer("Meeting m1 has no summary"),
+						Arguments: &[]string{"m1"},
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "JobNotFound",
+			err:            WithArgs(ErrJobIDNotFound, "j1"),
+			expectedStatus: http.StatusNotFound,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N404),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("JOB_NOT_FOUND"),
+						Message:   utils.ToPointer("Summary job j1 was not found"),
+						Arguments: &[]string{"j1"},
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "ActionItemNotFound",
+			err:            WithArgs(ErrActionItemNotFound, "m1", "a1"),
+			expectedStatus: http.StatusNotFound,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N404),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("ACTION_ITEM_NOT_FOUND"),
+						Message:   utils.ToPointer("Action item a1 of meeting m1 was not found"),
+						Arguments: &[]string{"m1", "a1"},
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "InvalidFilterValue",
+			err:            fmt.Errorf("%w created_at=gt.yesterday", ErrInvalidFilterValue),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N400),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("INVALID_FILTER_VALUE"),
+						Message:   utils.ToPointer("invalid filter value created_at=gt.yesterday"),
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "InvalidTranscriptUpload",
+			err:            fmt.Errorf("%w: meeting_id is required", ErrInvalidTranscriptUpload),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N400),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("INVALID_TRANSCRIPT_UPLOAD"),
+						Message:   utils.ToPointer("invalid transcript upload: meeting_id is required"),
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "UnknownSummaryStyle",
+			err:            fmt.Errorf("%w: haiku", ErrUnknownSummaryStyle),
+			expectedStatus: http.StatusBadRequest,
 			expectedBody: &generated.ErrorResponse{
-				HttpStatusCode: utils.ToPointer(generated.N404), // New test case
+				HttpStatusCode: utils.ToPointer(generated.N400),
 				Messages: &[]generated.ErrorMessage{
 					{
-						Message:   utils.ToPointer("No data found"), // New test case
-						Severity:  utils.ToPointer(generated.ERROR), // New test case
-						Timestamp: utils.ToPointer(time.Now()),      // New test case
+						Code:      utils.ToPointer("UNKNOWN_SUMMARY_STYLE"),
+						Message:   utils.ToPointer("unknown summary style: haiku"),
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "UnknownSummaryEngine",
+			err:            fmt.Errorf("%w: quantum", ErrUnknownSummaryEngine),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N400),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("UNKNOWN_SUMMARY_ENGINE"),
+						Message:   utils.ToPointer("unknown summary engine: quantum"),
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "InvalidRequest",
+			err:            fmt.Errorf("%w: unexpected EOF", ErrInvalidRequest),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N400),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("INVALID_REQUEST"),
+						Message:   utils.ToPointer("invalid request: unexpected EOF"),
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "SummaryInProgress",
+			err:            WithArgs(ErrSummaryInProgress, "m1"),
+			expectedStatus: http.StatusConflict,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N409),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("SUMMARY_JOB_CONFLICT"),
+						Message:   utils.ToPointer("A summary of meeting m1 is already being generated"),
+						Arguments: &[]string{"m1"},
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "SummaryAlreadyExists",
+			err:            WithArgs(ErrSummaryAlreadyExists, "m1"),
+			expectedStatus: http.StatusConflict,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N409),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("SUMMARY_ALREADY_EXISTS"),
+						Message:   utils.ToPointer("Meeting m1 already has a summary, use force to regenerate it"),
+						Arguments: &[]string{"m1"},
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "IdempotencyKeyReused",
+			err:            WithArgs(ErrIdempotencyKeyReused, "key-1"),
+			expectedStatus: http.StatusConflict,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N409),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("IDEMPOTENCY_KEY_REUSED"),
+						Message:   utils.ToPointer("The idempotency key key-1 was already used for another request"),
+						Arguments: &[]string{"key-1"},
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "TranscriptTooLarge",
+			err:            WithArgs(ErrTranscriptTooLarge, "10485760"),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N422),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("TRANSCRIPT_TOO_LARGE"),
+						Message:   utils.ToPointer("The transcript file exceeds the maximum size of 10485760 bytes"),
+						Arguments: &[]string{"10485760"},
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "UnsupportedTranscriptFormat",
+			err:            WithArgs(ErrUnsupportedTranscriptFormat, "no cue found"),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N422),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("UNSUPPORTED_TRANSCRIPT_FORMAT"),
+						Message:   utils.ToPointer("The transcript file is not a valid WebVTT (.vtt) or SubRip (.srt) file: no cue found"),
+						Arguments: &[]string{"no cue found"},
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "LLMOutputInvalid",
+			err:            fmt.Errorf("failed after 3 attempts: %w: %w", WithArgs(ErrLLMOutputInvalid, "m1"), errors.New("missing summary")),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N422),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("LLM_OUTPUT_INVALID"),
+						Message:   utils.ToPointer("The summarization model returned an invalid summary of meeting m1"),
+						Arguments: &[]string{"m1"},
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "JobQueueFull",
+			err:            ErrJobQueueFull,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N503),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("JOB_QUEUE_FULL"),
+						Message:   utils.ToPointer("Too many summaries are being generated, try again later"),
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "LLMUnavailable",
+			err:            ErrLLMUnavailable,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N503),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("LLM_UNAVAILABLE"),
+						Message:   utils.ToPointer("The summarization model is unavailable, try again later"),
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
+					},
+				},
+			},
+		},
+		{
+			name:           "ArgumentsOfAnotherError",
+			err:            fmt.Errorf("%w: %w", ErrInvalidRequest, WithArgs(ErrMeetingIDNotFound, "m1")),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: &generated.ErrorResponse{
+				HttpStatusCode: utils.ToPointer(generated.N400),
+				Messages: &[]generated.ErrorMessage{
+					{
+						Code:      utils.ToPointer("INVALID_REQUEST"),
+						Message:   utils.ToPointer("invalid request: meeting id not found: m1"),
+						Severity:  utils.ToPointer(generated.ERROR),
+						Timestamp: utils.ToPointer(time.Now()),
 					},
 				},
 			},
@@ -138,6 +398,8 @@ func TestResponseErrorHandlerFunc(t *testing.T) {
 
 			assert.Equal(t, tt.expectedBody.HttpStatusCode, actualBody.HttpStatusCode)
 			assert.Equal(t, (*tt.expectedBody.Messages)[0].Message, (*actualBody.Messages)[0].Message)
+			assert.Equal(t, (*tt.expectedBody.Messages)[0].Code, (*actualBody.Messages)[0].Code)
+			assert.Equal(t, (*tt.expectedBody.Messages)[0].Arguments, (*actualBody.Messages)[0].Arguments)
 		})
 	}
 }
@@ -158,6 +420,22 @@ func TestCreateErrorMessages(t *testing.T) {
 	assert.WithinDuration(t, time.Now(), *(*actualMessages)[0].Timestamp, time.Second)
 }
 
+func TestCreateValidationErrorResponse(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	ResponseErrorHandlerFunc(recorder, nil, CreateValidationErrorResponse([]string{
+		"meeting_id: minimum string length is 1",
+		"transcription: minimum number of items is 1",
+	}))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	var actualBody generated.ErrorResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &actualBody))
+	assert.Equal(t, generated.N400, *actualBody.HttpStatusCode)
+	assert.Len(t, *actualBody.Messages, 2)
+	assert.Equal(t, "INVALID_REQUEST", *(*actualBody.Messages)[1].Code)
+	assert.Equal(t, "transcription: minimum number of items is 1", *(*actualBody.Messages)[1].Message)
+}
+
 func TestGetStatusCode_NilPointer(t *testing.T) {
 	actualStatusCode := GetStatusCode(nil)
 	assert.Equal(t, http.StatusInternalServerError, actualStatusCode)
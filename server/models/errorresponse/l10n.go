@@ -0,0 +1,144 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package errorresponse
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage is the language of the messages of the catalog, the localized messages fall back to it when no
+// bundle matches the Accept-Language header of the request
+const DefaultLanguage = "en"
+
+// messages/<language>.json holds the localized message of each error code, with the {0}, {1}... placeholders of the
+// arguments of the error
+//
+//go:embed messages/*.json
+var bundleFiles embed.FS
+
+// bundles maps the languages to their messages by error code, the bundles are checked by the tests so that loading
+// them cannot fail at runtime
+var bundles = mustLoadBundles(bundleFiles)
+
+func mustLoadBundles(fsys fs.FS) map[string]map[string]string {
+	loaded, err := loadBundles(fsys)
+	if err != nil {
+		panic(err)
+	}
+	return loaded
+}
+
+func loadBundles(fsys fs.FS) (map[string]map[string]string, error) {
+	files, err := fs.Glob(fsys, "messages/*.json")
+	if err != nil {
+		return nil, err
+	}
+	loaded := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var messages map[string]string
+		if err = json.Unmarshal(content, &messages); err != nil {
+			return nil, fmt.Errorf("invalid message bundle %s: %w", file, err)
+		}
+		loaded[strings.ToLower(strings.TrimSuffix(path.Base(file), ".json"))] = messages
+	}
+	return loaded, nil
+}
+
+// NegotiateLanguage returns the language best matching an Accept-Language header, e.g. "fr-CH, fr;q=0.9, en;q=0.8".
+// A language range matches the bundle of its language, "de-AT" matches "de", and "*" or no match at all selects
+// DefaultLanguage.
+func NegotiateLanguage(acceptLanguage string) string {
+	type languageRange struct {
+		tag     string
+		quality float64
+	}
+	ranges := make([]languageRange, 0)
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if tag != "" && quality > 0 {
+			ranges = append(ranges, languageRange{tag: tag, quality: quality})
+		}
+	}
+	slices.SortStableFunc(ranges, func(a, b languageRange) int {
+		switch {
+		case a.quality > b.quality:
+			return -1
+		case a.quality < b.quality:
+			return 1
+		}
+		return 0
+	})
+
+	for _, r := range ranges {
+		primary, _, _ := strings.Cut(r.tag, "-")
+		if r.tag == "*" || primary == DefaultLanguage {
+			return DefaultLanguage
+		}
+		if _, ok := bundles[primary]; ok {
+			return primary
+		}
+	}
+	return DefaultLanguage
+}
+
+// Localize returns a copy of the error response whose messages have their localized message in the language: the
+// message of their code in the bundle of the language rendered with their arguments, else their English message.
+// The details of the messages of the errors without template, like the invalid field and reason of each message of a
+// validation error, are not localized and follow the localized message.
+func Localize(errorResponse *ServiceErrorResponse, language string) *ServiceErrorResponse {
+	if errorResponse == nil || errorResponse.Messages == nil {
+		return errorResponse
+	}
+	localized := *errorResponse
+	messages := slices.Clone(*errorResponse.Messages)
+	for i, message := range messages {
+		messages[i].MessageL10n = message.Message
+		if message.Code == nil {
+			continue
+		}
+		if template, ok := bundles[language][*message.Code]; ok {
+			var args []string
+			if message.Arguments != nil {
+				args = *message.Arguments
+			}
+			text := render(template, args)
+			if detail := details(*message.Code, message.Message); detail != "" {
+				text += ": " + detail
+			}
+			messages[i].MessageL10n = &text
+		}
+	}
+	localized.Messages = &messages
+	return &localized
+}
+
+// details returns the details of the English message of the error of the code when the error has no template, e.g.
+// "meeting_id: minimum string length is 1" for INVALID_REQUEST, and "" when the message has no details
+func details(code string, message *string) string {
+	err, ok := errorsByCode[code]
+	if !ok || err.Message != "" || message == nil {
+		return ""
+	}
+	detail, _ := strings.CutPrefix(*message, err.text)
+	return strings.TrimPrefix(detail, ": ")
+}
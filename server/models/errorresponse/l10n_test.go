@@ -0,0 +1,137 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package errorresponse
+
+import (
+	"encoding/json"
+	"fmt"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/utils"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundles(t *testing.T) {
+	placeholderPattern := regexp.MustCompile(`\{\d+\}`)
+	placeholders := func(template string) []string {
+		found := placeholderPattern.FindAllString(template, -1)
+		slices.Sort(found)
+		return found
+	}
+	require.NotEmpty(t, bundles)
+	for language, messages := range bundles {
+		assert.Len(t, messages, len(catalog), language)
+		for _, err := range catalog {
+			message, ok := messages[err.Code]
+			if assert.True(t, ok, "%s has no message for %s", language, err.Code) {
+				assert.Equal(t, placeholders(err.Message), placeholders(message), "%s %s", language, err.Code)
+			}
+		}
+	}
+}
+
+func TestLoadBundles_Invalid(t *testing.T) {
+	_, err := loadBundles(fstest.MapFS{"messages/de.json": {Data: []byte(`{"MEETING_NOT_FOUND":`)}})
+
+	assert.ErrorContains(t, err, "messages/de.json")
+}
+
+func TestNegotiateLanguage(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		expected       string
+	}{
+		{acceptLanguage: "", expected: "en"},
+		{acceptLanguage: "de", expected: "de"},
+		{acceptLanguage: "de-AT", expected: "de"},
+		{acceptLanguage: "FR-ch, fr;q=0.9", expected: "fr"},
+		{acceptLanguage: "pt-BR, es;q=0.5", expected: "es"},
+		{acceptLanguage: "en;q=0.4, ja;q=0.8", expected: "ja"},
+		{acceptLanguage: "en-US, de;q=0.9", expected: "en"},
+		{acceptLanguage: "de;q=0, fr;q=0.1", expected: "fr"},
+		{acceptLanguage: "pt-BR, *;q=0.5", expected: "en"},
+		{acceptLanguage: "de;q=high, es", expected: "es"},
+		{acceptLanguage: "zh-CN", expected: "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NegotiateLanguage(tt.acceptLanguage))
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	errorResponse := CreateErrorResponse(WithArgs(ErrActionItemNotFound, "m1", "a1"))
+
+	localized := Localize(errorResponse, "fr")
+	fallback := Localize(errorResponse, "en")
+
+	assert.Equal(t, "L'action a1 de la réunion m1 est introuvable", *(*localized.Messages)[0].MessageL10n)
+	assert.Equal(t, "Action item a1 of meeting m1 was not found", *(*fallback.Messages)[0].MessageL10n)
+	assert.Nil(t, (*errorResponse.Messages)[0].MessageL10n)
+	assert.Nil(t, Localize(nil, "fr"))
+}
+
+func TestLocalize_Details(t *testing.T) {
+	validation := Localize(CreateValidationErrorResponse([]string{"meeting_id: minimum string length is 1",
+		"transcription: minimum number of items is 1"}), "fr")
+	detailed := Localize(CreateErrorResponse(fmt.Errorf("%w: unknown field owner", ErrInvalidSortField)), "de")
+	generic := Localize(CreateErrorResponse(ErrInvalidSortField), "de")
+
+	// each field keeps its own message
+	assert.Equal(t, "Requête invalide: meeting_id: minimum string length is 1", *(*validation.Messages)[0].MessageL10n)
+	assert.Equal(t, "Requête invalide: transcription: minimum number of items is 1",
+		*(*validation.Messages)[1].MessageL10n)
+	assert.Equal(t, "meeting_id: minimum string length is 1", *(*validation.Messages)[0].Message)
+	assert.Equal(t, "Ungültiges Sortierfeld: unknown field owner", *(*detailed.Messages)[0].MessageL10n)
+	assert.Equal(t, "Ungültiges Sortierfeld", *(*generic.Messages)[0].MessageL10n)
+}
+
+func TestLocalize_WithoutCode(t *testing.T) {
+	errorResponse := &ServiceErrorResponse{HttpStatusCode: utils.ToPointer(generated.N500),
+		Messages: CreateErrorMessages("failure")}
+
+	localized := Localize(errorResponse, "de")
+
+	assert.Equal(t, "failure", *(*localized.Messages)[0].MessageL10n)
+}
+
+func TestResponseErrorHandlerFunc_Localized(t *testing.T) {
+	tests := []struct {
+		name             string
+		acceptLanguage   string
+		err              error
+		expectedLanguage string
+		expectedL10n     string
+	}{
+		{name: "Arguments", acceptLanguage: "de-DE, de;q=0.9, en;q=0.8", err: WithArgs(ErrMeetingIDNotFound, "m1"),
+			expectedLanguage: "de", expectedL10n: "Die Besprechung m1 wurde nicht gefunden"},
+		{name: "DetailedMessage", acceptLanguage: "es", err: ErrInvalidSortField,
+			expectedLanguage: "es", expectedL10n: "Campo de ordenación no válido"},
+		{name: "Validation", acceptLanguage: "ja", err: CreateValidationErrorResponse([]string{"meeting_id: required"}),
+			expectedLanguage: "ja", expectedL10n: "無効なリクエストです: meeting_id: required"},
+		{name: "Fallback", acceptLanguage: "pt-BR", err: WithArgs(ErrMeetingIDNotFound, "m1"),
+			expectedLanguage: "en", expectedL10n: "Meeting m1 was not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/meetings/summary/m1", nil)
+			request.Header.Set("Accept-Language", tt.acceptLanguage)
+			recorder := httptest.NewRecorder()
+
+			ResponseErrorHandlerFunc(recorder, request, tt.err)
+
+			var actualBody generated.ErrorResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &actualBody))
+			assert.Equal(t, tt.expectedLanguage, recorder.Header().Get("Content-Language"))
+			assert.Equal(t, tt.expectedL10n, *(*actualBody.Messages)[0].MessageL10n)
+		})
+	}
+}
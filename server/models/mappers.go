@@ -0,0 +1,76 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package models
+
+import (
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/utils"
+	"meeting-analyzer/server/models/dbmodels"
+	"time"
+)
+
+// MeetingDetailsFromRequest converts the body of a summary request to the meeting details
+func MeetingDetailsFromRequest(request *generated.GenerateMeetingSummaryRequest) *MeetingDetails {
+	transcription := make([]Transcription, 0, len(request.Transcription))
+	for _, t := range request.Transcription {
+		timestamp := t.Timestamp
+		transcription = append(transcription, Transcription{
+			MemberName: t.MemberName,
+			MemberID:   utils.GetPtrValue(t.MemberId, ""),
+			Timestamp:  &timestamp,
+			Content:    t.Content,
+		})
+	}
+	return &MeetingDetails{
+		MeetingID:     request.MeetingId,
+		MeetingTitle:  request.MeetingTitle,
+		Transcription: transcription,
+	}
+}
+
+// ToDBMeeting converts the meeting details to the rows stored in the repository, the segments are numbered in
+// the order of the transcription
+func ToDBMeeting(meetingDetails *MeetingDetails, now time.Time) (*dbmodels.Meeting, []dbmodels.TranscriptSegment) {
+	segments := make([]dbmodels.TranscriptSegment, 0, len(meetingDetails.Transcription))
+	for i, t := range meetingDetails.Transcription {
+		segments = append(segments, dbmodels.TranscriptSegment{
+			MeetingID:  meetingDetails.MeetingID,
+			Seq:        i,
+			MemberName: t.MemberName,
+			Timestamp:  copyTime(t.Timestamp),
+			Content:    t.Content,
+		})
+	}
+	return &dbmodels.Meeting{
+		MeetingID: meetingDetails.MeetingID,
+		Title:     meetingDetails.MeetingTitle,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, segments
+}
+
+// FromDBMeeting converts the stored rows back to the meeting details
+func FromDBMeeting(meeting *dbmodels.Meeting, segments []dbmodels.TranscriptSegment) *MeetingDetails {
+	transcription := make([]Transcription, 0, len(segments))
+	for _, segment := range segments {
+		transcription = append(transcription, Transcription{
+			MemberName: segment.MemberName,
+			Timestamp:  copyTime(segment.Timestamp),
+			Content:    segment.Content,
+		})
+	}
+	return &MeetingDetails{
+		MeetingID:     meeting.MeetingID,
+		MeetingTitle:  meeting.Title,
+		Transcription: transcription,
+	}
+}
+
+// copyTime keeps the converted models from sharing their timestamps
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
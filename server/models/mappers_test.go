@@ -0,0 +1,75 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package models
+
+import (
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models/dbmodels"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	start = time.Date(2024, 1, 1, 10, 0, 0, 123456789, time.UTC)
+	paris = time.FixedZone("CET", 3600)
+	bobID = "user-bob"
+)
+
+func testRequest() *generated.GenerateMeetingSummaryRequest {
+	return &generated.GenerateMeetingSummaryRequest{
+		MeetingId:    "meeting-1",
+		MeetingTitle: "Weekly sync",
+		Transcription: []generated.MemberTranscription{
+			{MemberName: "Alice", Timestamp: start, Content: "Hello"},
+			{MemberName: "Bob", MemberId: &bobID, Timestamp: start.Add(1500 * time.Millisecond).In(paris), Content: "Hi"},
+		},
+	}
+}
+
+func TestMeetingDetailsFromRequest(t *testing.T) {
+	request := testRequest()
+
+	details := MeetingDetailsFromRequest(request)
+
+	assert.Equal(t, "meeting-1", details.MeetingID)
+	assert.Equal(t, "Weekly sync", details.MeetingTitle)
+	require.Len(t, details.Transcription, 2)
+	assert.Equal(t, "Alice", details.Transcription[0].MemberName)
+	assert.Equal(t, "Bob", details.Transcription[1].MemberName)
+	assert.Empty(t, details.Transcription[0].MemberID)
+	assert.Equal(t, "user-bob", details.Transcription[1].MemberID)
+	assert.Equal(t, "Hi", details.Transcription[1].Content)
+	require.NotNil(t, details.Transcription[1].Timestamp)
+	assert.True(t, start.Add(1500*time.Millisecond).Equal(*details.Transcription[1].Timestamp))
+
+	// the details do not share the timestamps of the request
+	request.Transcription[0].Timestamp = time.Time{}
+	assert.Equal(t, start, *details.Transcription[0].Timestamp)
+}
+
+func TestDBMeeting_RoundTrip(t *testing.T) {
+	now := time.Now()
+	details := MeetingDetailsFromRequest(testRequest())
+	details.Transcription = append(details.Transcription, Transcription{MemberName: "Alice", Content: "Bye"})
+
+	meeting, segments := ToDBMeeting(details, now)
+
+	assert.Equal(t, &dbmodels.Meeting{MeetingID: "meeting-1", Title: "Weekly sync", CreatedAt: now, UpdatedAt: now}, meeting)
+	require.Len(t, segments, 3)
+	assert.Equal(t, dbmodels.TranscriptSegment{MeetingID: "meeting-1", Seq: 0, MemberName: "Alice", Timestamp: &start,
+		Content: "Hello"}, segments[0])
+	assert.Equal(t, 2, segments[2].Seq)
+	assert.Nil(t, segments[2].Timestamp)
+
+	// the initiators of the speakers are stored as shares of the meeting, not with the segments
+	details.Transcription[1].MemberID = ""
+	assert.Equal(t, details, FromDBMeeting(meeting, segments))
+}
+
+func TestFormattedTimestamp(t *testing.T) {
+	assert.Equal(t, "2024-01-01T10:00:00.123456789Z", Transcription{Timestamp: &start}.FormattedTimestamp())
+	assert.Equal(t, "", Transcription{}.FormattedTimestamp())
+}
@@ -1,13 +1,63 @@
 package models
 
+import "time"
+
+// Transcription is a segment of the transcription of a meeting, Timestamp is nil when the segment is not timed and
+// MemberID empty when the initiator of the speaker is unknown
 type Transcription struct {
-	Member    string `json:"member"`
-	Timestamp string `json:"timestamp"`
-	Content   string `json:"content"`
+	MemberName string
+	MemberID   string
+	Timestamp  *time.Time
+	Content    string
+}
+
+// FormattedTimestamp returns the timestamp in RFC 3339 with its fractional seconds, or an empty string when the
+// segment is not timed
+func (t Transcription) FormattedTimestamp() string {
+	if t.Timestamp == nil {
+		return ""
+	}
+	return t.Timestamp.Format(time.RFC3339Nano)
 }
 
+// MeetingDetails is a meeting and its transcription, in the order of the segments
 type MeetingDetails struct {
-	MeetingID     string          `json:"meeting_id"`
-	MeetingTitle  string          `json:"meeting_title"`
-	Transcription []Transcription `json:"transcription"`
+	MeetingID     string
+	MeetingTitle  string
+	Transcription []Transcription
+}
+
+// GenerateOptions are the options of a summary request. A request sent again with the same IdempotencyKey gets the
+// response of the first one, and Force generates the summary of a meeting already summarized. SummaryStyle names the
+// prompt templates of the summary and SummaryEngine the engine generating it, empty for the defaults.
+type GenerateOptions struct {
+	IdempotencyKey string
+	Force          bool
+	SummaryStyle   string
+	SummaryEngine  string
+}
+
+// TranscriptUpload is a transcript file uploaded for a meeting, StartedAt is zero when unknown, SummaryStyle and
+// SummaryEngine empty when not chosen
+type TranscriptUpload struct {
+	MeetingID     string
+	MeetingTitle  string
+	StartedAt     time.Time
+	SummaryStyle  string
+	SummaryEngine string
+	Content       []byte
+}
+
+// names of the summary events
+const (
+	SummaryEventSummary  = "summary"
+	SummaryEventProgress = "progress"
+	SummaryEventDelta    = "delta"
+)
+
+// SummaryEvent is an event of the summary stream of a meeting, Data is a generated.MeetingSummary,
+// generated.SummaryProgress or generated.SummaryDelta according to Name
+type SummaryEvent struct {
+	Name string
+	Data any
 }
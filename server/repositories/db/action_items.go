@@ -0,0 +1,174 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"strings"
+	"time"
+)
+
+const (
+	selectDoneActionItemsQuery = `SELECT item_id, description, done_at FROM action_items
+		WHERE meeting_id = $1 AND estate_id = $2 AND done`
+	deleteActionItemsQuery = `DELETE FROM action_items WHERE meeting_id = $1 AND estate_id = $2`
+	insertActionItemQuery  = `INSERT INTO action_items (item_id, meeting_id, estate_id, seq, description, owner,
+		due_date, due_phrase, segment_seq, done, done_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	selectActionItemsQuery = `SELECT a.item_id, a.meeting_id, a.seq, a.description, a.owner, a.due_date, a.due_phrase,
+		a.segment_seq, a.done, a.done_at, a.created_at, a.updated_at, s.member_name, s."timestamp", s.content
+		FROM action_items a
+		LEFT JOIN transcript_segments s ON s.estate_id = a.estate_id AND s.meeting_id = a.meeting_id
+			AND s.seq = a.segment_seq`
+	selectMeetingActionItemsQuery = selectActionItemsQuery + ` WHERE a.meeting_id = $1 AND a.estate_id = $2
+		ORDER BY a.seq`
+	selectActionItemQuery = selectActionItemsQuery + ` WHERE a.meeting_id = $1 AND a.estate_id = $2
+		AND a.item_id = $3`
+	updateActionItemDoneQuery = `UPDATE action_items SET done = $4,
+		done_at = CASE WHEN $4 THEN COALESCE(done_at, $5) ELSE NULL END, updated_at = $5
+		WHERE meeting_id = $1 AND estate_id = $2 AND item_id = $3`
+)
+
+// ReplaceActionItems replaces the action items of the meeting. The items done before keep their status when the
+// new extraction contains an item with the same id or description, so that regenerating a summary does not reopen
+// them.
+func (r *repository) ReplaceActionItems(ctx context.Context, meetingID string, items []dbmodels.MeetingActionItem) error {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := r.dbCon.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	doneAt, doneAtByDescription, err := selectDoneActionItems(ctx, tx, meetingID, estateID)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, deleteActionItemsQuery, meetingID, estateID); err != nil {
+		return fmt.Errorf("failed to delete action items of meeting %s: %w", meetingID, err)
+	}
+	for _, item := range items {
+		if at, ok := doneAt[item.ItemID]; ok {
+			item.Done, item.DoneAt = true, at
+		} else if at, ok = doneAtByDescription[strings.ToLower(item.Description)]; ok {
+			item.Done, item.DoneAt = true, at
+		}
+		if _, err = tx.ExecContext(ctx, insertActionItemQuery, item.ItemID, meetingID, estateID, item.Seq, item.Description,
+			item.Owner, item.DueDate, item.DuePhrase, item.SegmentSeq, item.Done, item.DoneAt, item.CreatedAt,
+			item.UpdatedAt); err != nil {
+			return fmt.Errorf("failed to insert action item %d of meeting %s: %w", item.Seq, meetingID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// selectDoneActionItems returns the completion time of the done items of the meeting by id and by lower case
+// description
+func selectDoneActionItems(ctx context.Context, tx *sql.Tx, meetingID string,
+	estateID string) (map[string]*time.Time, map[string]*time.Time, error) {
+	rows, err := tx.QueryContext(ctx, selectDoneActionItemsQuery, meetingID, estateID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to select action items of meeting %s: %w", meetingID, err)
+	}
+	defer rows.Close()
+
+	doneAt := make(map[string]*time.Time)
+	doneAtByDescription := make(map[string]*time.Time)
+	for rows.Next() {
+		var itemID, description string
+		var at *time.Time
+		if err = rows.Scan(&itemID, &description, &at); err != nil {
+			return nil, nil, err
+		}
+		doneAt[itemID] = at
+		doneAtByDescription[strings.ToLower(description)] = at
+	}
+	return doneAt, doneAtByDescription, rows.Err()
+}
+
+func (r *repository) ListActionItems(ctx context.Context, meetingID string) ([]dbmodels.MeetingActionItem, error) {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.dbCon.QueryContext(ctx, selectMeetingActionItemsQuery, meetingID, estateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]dbmodels.MeetingActionItem, 0)
+	for rows.Next() {
+		item, err := scanActionItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	return items, rows.Err()
+}
+
+func (r *repository) SetActionItemDone(ctx context.Context, meetingID string, itemID string, done bool) (*dbmodels.MeetingActionItem, error) {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.dbCon.ExecContext(ctx, updateActionItemDoneQuery, meetingID, estateID, itemID, done,
+		time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to update action item %s: %w", itemID, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, errorresponse.WithArgs(errorresponse.ErrActionItemNotFound, meetingID, itemID)
+	}
+
+	item, err := scanActionItem(r.dbCon.QueryRowContext(ctx, selectActionItemQuery, meetingID, estateID, itemID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorresponse.WithArgs(errorresponse.ErrActionItemNotFound, meetingID, itemID)
+	}
+	return item, err
+}
+
+// rowScanner is implemented by both sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanActionItem reads a row of selectActionItemsQuery, the segment columns are null when the item has no segment
+func scanActionItem(row rowScanner) (*dbmodels.MeetingActionItem, error) {
+	var item dbmodels.MeetingActionItem
+	var memberName, content sql.NullString
+	var timestamp sql.NullTime
+	err := row.Scan(&item.ItemID, &item.MeetingID, &item.Seq, &item.Description, &item.Owner, &item.DueDate,
+		&item.DuePhrase, &item.SegmentSeq, &item.Done, &item.DoneAt, &item.CreatedAt, &item.UpdatedAt,
+		&memberName, &timestamp, &content)
+	if err != nil {
+		return nil, err
+	}
+	if item.SegmentSeq != nil && memberName.Valid {
+		item.Segment = &dbmodels.TranscriptSegment{
+			MeetingID:  item.MeetingID,
+			Seq:        *item.SegmentSeq,
+			MemberName: memberName.String,
+			Content:    content.String,
+		}
+		if timestamp.Valid {
+			item.Segment.Timestamp = &timestamp.Time
+		}
+	}
+	return &item, nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package db
+
+import (
+	"database/sql/driver"
+	"meeting-analyzer/server/commons/utils"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var actionItemColumns = []string{"item_id", "meeting_id", "seq", "description", "owner", "due_date", "due_phrase",
+	"segment_seq", "done", "done_at", "created_at", "updated_at", "member_name", "timestamp", "content"}
+
+func TestReplaceActionItems_KeepsDoneItems(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+	doneAt := now.Add(-time.Hour)
+	dueDate := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	items := []dbmodels.MeetingActionItem{
+		{ItemID: "i1", Seq: 0, Description: "Send the notes", Owner: utils.ToPointer("Bob"), DueDate: &dueDate,
+			DuePhrase: utils.ToPointer("by Friday"), SegmentSeq: utils.ToPointer(1), CreatedAt: now, UpdatedAt: now},
+		{ItemID: "i2", Seq: 1, Description: "Book a room", CreatedAt: now, UpdatedAt: now},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectDoneActionItemsQuery)).WithArgs("m1", "e1").
+		WillReturnRows(sqlmock.NewRows([]string{"item_id", "description", "done_at"}).
+			AddRow("i0", "send the notes", doneAt).AddRow("i2", "Book the room", doneAt))
+	mock.ExpectExec(regexp.QuoteMeta(deleteActionItemsQuery)).WithArgs("m1", "e1").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO action_items")).
+		WithArgs("i1", "m1", "e1", 0, "Send the notes", utils.ToPointer("Bob"), &dueDate, utils.ToPointer("by Friday"),
+			utils.ToPointer(1), true, &doneAt, now, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO action_items")).
+		WithArgs("i2", "m1", "e1", 1, "Book a room", nil, nil, nil, nil, true, &doneAt, now, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.ReplaceActionItems(tenantContext, "m1", items))
+}
+
+func TestListActionItems(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+	dueDate := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectMeetingActionItemsQuery)).WithArgs("m1", "e1").
+		WillReturnRows(sqlmock.NewRows(actionItemColumns).
+			AddRow("i1", "m1", 0, "Send the notes", "Bob", dueDate, "by Friday", 1, true, now, now, now, "Bob", now, "Hi").
+			AddRow("i2", "m1", 1, "Book a room", nil, nil, nil, nil, false, nil, now, now, nil, nil, nil))
+
+	items, err := r.ListActionItems(tenantContext, "m1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []dbmodels.MeetingActionItem{
+		{
+			ItemID: "i1", MeetingID: "m1", Seq: 0, Description: "Send the notes", Owner: utils.ToPointer("Bob"),
+			DueDate: &dueDate, DuePhrase: utils.ToPointer("by Friday"), SegmentSeq: utils.ToPointer(1),
+			Segment: &dbmodels.TranscriptSegment{MeetingID: "m1", Seq: 1, MemberName: "Bob", Timestamp: &now, Content: "Hi"},
+			Done:    true, DoneAt: &now, CreatedAt: now, UpdatedAt: now,
+		},
+		{ItemID: "i2", MeetingID: "m1", Seq: 1, Description: "Book a room", CreatedAt: now, UpdatedAt: now},
+	}, items)
+}
+
+func TestSetActionItemDone(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE action_items SET done")).
+		WithArgs("m1", "e1", "i1", true, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectActionItemQuery)).WithArgs("m1", "e1", "i1").
+		WillReturnRows(sqlmock.NewRows(actionItemColumns).
+			AddRow("i1", "m1", 0, "Send the notes", nil, nil, nil, nil, true, now, now, now, nil, nil, nil))
+
+	item, err := r.SetActionItemDone(tenantContext, "m1", "i1", true)
+
+	require.NoError(t, err)
+	assert.True(t, item.Done)
+	assert.Equal(t, &now, item.DoneAt)
+}
+
+func TestSetActionItemDone_NotFound(t *testing.T) {
+	r, mock := newMockRepository(t)
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE action_items SET done")).
+		WithArgs("m1", "e1", "unknown", false, sqlmock.AnyArg()).WillReturnResult(driver.RowsAffected(0))
+
+	_, err := r.SetActionItemDone(tenantContext, "m1", "unknown", false)
+
+	assert.ErrorIs(t, err, errorresponse.ErrActionItemNotFound)
+}
@@ -0,0 +1,53 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"meeting-analyzer/server/models/dbmodels"
+	"time"
+)
+
+const (
+	selectIdempotencyKeyQuery = `SELECT estate_id, idempotency_key, request_hash, meeting_id, job_id, status, created_at
+		FROM idempotency_keys WHERE estate_id = $1 AND idempotency_key = $2 AND created_at > $3`
+	deleteExpiredIdempotencyKeysQuery = `DELETE FROM idempotency_keys WHERE created_at < $1`
+	upsertIdempotencyKeyQuery         = `INSERT INTO idempotency_keys
+		(estate_id, idempotency_key, request_hash, meeting_id, job_id, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (estate_id, idempotency_key) DO UPDATE SET request_hash = EXCLUDED.request_hash,
+			meeting_id = EXCLUDED.meeting_id, job_id = EXCLUDED.job_id, status = EXCLUDED.status,
+			created_at = EXCLUDED.created_at`
+)
+
+func (r *repository) GetIdempotencyKey(ctx context.Context, key string, createdAfter time.Time) (*dbmodels.IdempotencyKey, error) {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var stored dbmodels.IdempotencyKey
+	err = r.dbCon.QueryRowContext(ctx, selectIdempotencyKeyQuery, estateID, key, createdAfter).Scan(&stored.EstateID,
+		&stored.Key, &stored.RequestHash, &stored.MeetingID, &stored.JobID, &stored.Status, &stored.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read idempotency key: %w", err)
+	}
+	return &stored, nil
+}
+
+func (r *repository) SaveIdempotencyKey(ctx context.Context, key *dbmodels.IdempotencyKey, expiredBefore time.Time) error {
+	if _, err := r.dbCon.ExecContext(ctx, deleteExpiredIdempotencyKeysQuery, expiredBefore); err != nil {
+		return fmt.Errorf("failed to delete the expired idempotency keys: %w", err)
+	}
+	_, err := r.dbCon.ExecContext(ctx, upsertIdempotencyKeyQuery, key.EstateID, key.Key, key.RequestHash, key.MeetingID,
+		key.JobID, key.Status, key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store idempotency key of job %s: %w", key.JobID, err)
+	}
+	return nil
+}
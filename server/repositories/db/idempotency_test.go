@@ -0,0 +1,52 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package db
+
+import (
+	"database/sql"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models/dbmodels"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIdempotencyKey(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+	expiry := now.Add(-24 * time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectIdempotencyKeyQuery)).WithArgs("e1", "k1", expiry).
+		WillReturnRows(sqlmock.NewRows([]string{"estate_id", "idempotency_key", "request_hash", "meeting_id", "job_id",
+			"status", "created_at"}).AddRow("e1", "k1", "hash", "m1", "j1", "PENDING", now))
+	mock.ExpectQuery(regexp.QuoteMeta(selectIdempotencyKeyQuery)).WithArgs("e1", "k2", expiry).
+		WillReturnError(sql.ErrNoRows)
+
+	stored, err := r.GetIdempotencyKey(tenantContext, "k1", expiry)
+	require.NoError(t, err)
+	assert.Equal(t, &dbmodels.IdempotencyKey{EstateID: "e1", Key: "k1", RequestHash: "hash", MeetingID: "m1", JobID: "j1",
+		Status: generated.PENDING, CreatedAt: now}, stored)
+
+	stored, err = r.GetIdempotencyKey(tenantContext, "k2", expiry)
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestSaveIdempotencyKey(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+	expiry := now.Add(-24 * time.Hour)
+	key := &dbmodels.IdempotencyKey{EstateID: "e1", Key: "k1", RequestHash: "hash", MeetingID: "m1", JobID: "j1",
+		Status: generated.PENDING, CreatedAt: now}
+
+	mock.ExpectExec(regexp.QuoteMeta(deleteExpiredIdempotencyKeysQuery)).WithArgs(expiry).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_keys")).
+		WithArgs("e1", "k1", "hash", "m1", "j1", generated.PENDING, now).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	assert.NoError(t, r.SaveIdempotencyKey(tenantContext, key, expiry))
+}
@@ -0,0 +1,119 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	insertJobQuery = `INSERT INTO summary_jobs (job_id, meeting_id, estate_id, created_by, status, error, owner,
+		heartbeat_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	selectInFlightJobQuery = `SELECT EXISTS (SELECT 1 FROM summary_jobs
+		WHERE meeting_id = $1 AND estate_id = $2 AND status IN ('PENDING', 'IN_PROGRESS'))`
+	selectJobQuery = `SELECT job_id, meeting_id, estate_id, created_by, status, error, owner, heartbeat_at, created_at,
+		updated_at FROM summary_jobs WHERE job_id = $1 AND estate_id = $2`
+	selectLatestJobQuery = `SELECT job_id, meeting_id, estate_id, created_by, status, error, owner, heartbeat_at,
+		created_at, updated_at FROM summary_jobs WHERE meeting_id = $1 AND estate_id = $2 ORDER BY created_at DESC LIMIT 1`
+	updateJobStatusQuery = `UPDATE summary_jobs SET status = $3, error = $4, updated_at = $5
+		WHERE job_id = $1 AND estate_id = $2`
+	renewJobLeasesQuery = `UPDATE summary_jobs SET heartbeat_at = $2
+		WHERE owner = $1 AND status IN ('PENDING', 'IN_PROGRESS')`
+	failInterruptedJobsQuery = `UPDATE summary_jobs SET status = 'FAILED', error = $3, updated_at = $4
+		WHERE status IN ('PENDING', 'IN_PROGRESS') AND ((owner = $1 AND $1 <> '') OR heartbeat_at < $2)`
+
+	uniqueViolationCode = "23505"
+	// inFlightJobConstraint is the unique index allowing a single unfinished job per meeting of an estate
+	inFlightJobConstraint = "summary_jobs_in_flight_idx"
+)
+
+// insertJob inserts the job in the transaction creating its meeting
+func insertJob(ctx context.Context, tx *sql.Tx, job *dbmodels.SummaryJob) error {
+	_, err := tx.ExecContext(ctx, insertJobQuery, job.JobID, job.MeetingID, job.EstateID, job.CreatedBy,
+		job.Status, job.Error, job.Owner, job.HeartbeatAt, job.CreatedAt, job.UpdatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode && pqErr.Constraint == inFlightJobConstraint {
+		return errorresponse.WithArgs(errorresponse.ErrSummaryInProgress, job.MeetingID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert job %s: %w", job.JobID, err)
+	}
+	return nil
+}
+
+func (r *repository) GetJob(ctx context.Context, jobID string) (*dbmodels.SummaryJob, error) {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job, err := scanJob(r.dbCon.QueryRowContext(ctx, selectJobQuery, jobID, estateID))
+	if errors.Is(err, errorresponse.ErrJobIDNotFound) {
+		return nil, errorresponse.WithArgs(errorresponse.ErrJobIDNotFound, jobID)
+	}
+	return job, err
+}
+
+func (r *repository) GetLatestJob(ctx context.Context, meetingID string) (*dbmodels.SummaryJob, error) {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return scanJob(r.dbCon.QueryRowContext(ctx, selectLatestJobQuery, meetingID, estateID))
+}
+
+func scanJob(row *sql.Row) (*dbmodels.SummaryJob, error) {
+	var job dbmodels.SummaryJob
+	err := row.Scan(&job.JobID, &job.MeetingID, &job.EstateID, &job.CreatedBy, &job.Status, &job.Error, &job.Owner,
+		&job.HeartbeatAt, &job.CreatedAt, &job.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorresponse.ErrJobIDNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *repository) UpdateJobStatus(ctx context.Context, jobID string, status generated.JobStatusEnum, errMsg *string) error {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.dbCon.ExecContext(ctx, updateJobStatusQuery, jobID, estateID, status, errMsg, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to update job %s: %w", jobID, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errorresponse.WithArgs(errorresponse.ErrJobIDNotFound, jobID)
+	}
+	return nil
+}
+
+func (r *repository) RenewJobLeases(ctx context.Context, owner string, now time.Time) error {
+	if _, err := r.dbCon.ExecContext(ctx, renewJobLeasesQuery, owner, now); err != nil {
+		return fmt.Errorf("failed to renew the leases of the jobs of %s: %w", owner, err)
+	}
+	return nil
+}
+
+func (r *repository) FailInterruptedJobs(ctx context.Context, owner string, expiredBefore time.Time, errMsg string) (int, error) {
+	res, err := r.dbCon.ExecContext(ctx, failInterruptedJobsQuery, owner, expiredBefore, errMsg, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	affected, err := res.RowsAffected()
+	return int(affected), err
+}
@@ -0,0 +1,89 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package db
+
+import (
+	"database/sql"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var jobColumns = []string{"job_id", "meeting_id", "estate_id", "created_by", "status", "error", "owner",
+	"heartbeat_at", "created_at", "updated_at"}
+
+func TestGetJob(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectJobQuery)).WithArgs("j1", "e1").
+		WillReturnRows(sqlmock.NewRows(jobColumns).AddRow("j1", "m1", "e1", "u1", "PENDING", nil, "instance-1", now, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta(selectJobQuery)).WithArgs("j2", "e1").WillReturnError(sql.ErrNoRows)
+
+	stored, err := r.GetJob(tenantContext, "j1")
+	require.NoError(t, err)
+	assert.Equal(t, &dbmodels.SummaryJob{JobID: "j1", MeetingID: "m1", EstateID: "e1", CreatedBy: "u1",
+		Status: generated.PENDING, Owner: "instance-1", HeartbeatAt: now, CreatedAt: now, UpdatedAt: now}, stored)
+	_, err = r.GetJob(tenantContext, "j2")
+	assert.ErrorIs(t, err, errorresponse.ErrJobIDNotFound)
+}
+
+func TestGetLatestJob(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+	errMsg := "llm unavailable"
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectLatestJobQuery)).WithArgs("m1", "e1").
+		WillReturnRows(sqlmock.NewRows(jobColumns).AddRow("j2", "m1", "e1", "u1", "FAILED", errMsg, "instance-1", now, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta(selectLatestJobQuery)).WithArgs("m2", "e1").WillReturnError(sql.ErrNoRows)
+
+	job, err := r.GetLatestJob(tenantContext, "m1")
+	require.NoError(t, err)
+	assert.Equal(t, &dbmodels.SummaryJob{JobID: "j2", MeetingID: "m1", EstateID: "e1", CreatedBy: "u1",
+		Status: generated.FAILED, Error: &errMsg, Owner: "instance-1", HeartbeatAt: now, CreatedAt: now, UpdatedAt: now}, job)
+	_, err = r.GetLatestJob(tenantContext, "m2")
+	assert.ErrorIs(t, err, errorresponse.ErrJobIDNotFound)
+}
+
+func TestUpdateJobStatus(t *testing.T) {
+	r, mock := newMockRepository(t)
+	errMsg := "llm unavailable"
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE summary_jobs SET status")).
+		WithArgs("j1", "e1", generated.FAILED, &errMsg, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE summary_jobs SET status")).
+		WithArgs("j2", "e1", generated.DONE, nil, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, r.UpdateJobStatus(tenantContext, "j1", generated.FAILED, &errMsg))
+	assert.ErrorIs(t, r.UpdateJobStatus(tenantContext, "j2", generated.DONE, nil), errorresponse.ErrJobIDNotFound)
+}
+
+func TestRenewJobLeases(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+
+	mock.ExpectExec(regexp.QuoteMeta(renewJobLeasesQuery)).WithArgs("instance-1", now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	assert.NoError(t, r.RenewJobLeases(tenantContext, "instance-1", now))
+}
+
+func TestFailInterruptedJobs(t *testing.T) {
+	r, mock := newMockRepository(t)
+	expiredBefore := time.Now().Add(-time.Minute)
+
+	mock.ExpectExec(regexp.QuoteMeta(failInterruptedJobsQuery)).
+		WithArgs("instance-1", expiredBefore, "restarted", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	count, err := r.FailInterruptedJobs(tenantContext, "instance-1", expiredBefore, "restarted")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,124 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package db
+
+import (
+	"fmt"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+const (
+	selectMeetingListQuery = `SELECT m.meeting_id, m.estate_id, m.created_by, m.title, m.created_at, m.updated_at,
+		COALESCE((SELECT array_agg(p.member_name ORDER BY p.first_seq) FROM (
+			SELECT member_name, MIN(seq) AS first_seq FROM transcript_segments
+			WHERE estate_id = m.estate_id AND meeting_id = m.meeting_id AND member_name <> ''
+			GROUP BY member_name) p), '{}'),
+		s.created_by, s.content, s.key_points, s.decisions, s.action_items, s.analytics, s.redactions, s.model,
+		s.prompt_template, s.prompt_version, s.created_at, s.updated_at,
+		j.job_id, j.created_by, j.status, j.error, j.created_at, j.updated_at
+		FROM meetings m
+		LEFT JOIN summaries s ON s.estate_id = m.estate_id AND s.meeting_id = m.meeting_id
+		LEFT JOIN LATERAL (SELECT job_id, created_by, status, error, created_at, updated_at FROM summary_jobs
+			WHERE estate_id = m.estate_id AND meeting_id = m.meeting_id ORDER BY created_at DESC LIMIT 1) j ON true`
+	countMeetingListQuery = `SELECT COUNT(*) FROM meetings m`
+	participantCondition  = `EXISTS (SELECT 1 FROM transcript_segments t WHERE t.estate_id = m.estate_id AND t.meeting_id = m.meeting_id AND t.member_name %s $%d)`
+	// readerCondition selects the meetings the initiator, its first argument, may read: the initiator created them or
+	// they are shared with it, as with the participants, or one of its groups, its second argument, see access.Allowed
+	readerCondition = `(($%[1]d <> '' AND (m.created_by = $%[1]d
+		OR EXISTS (SELECT 1 FROM meeting_shares sh WHERE sh.estate_id = m.estate_id AND sh.meeting_id = m.meeting_id
+			AND sh.principal_type = 'user' AND sh.principal_id = $%[1]d)))
+		OR EXISTS (SELECT 1 FROM meeting_shares sh WHERE sh.estate_id = m.estate_id AND sh.meeting_id = m.meeting_id
+			AND sh.principal_type = 'group' AND sh.principal_id = ANY($%[2]d)))`
+)
+
+// meetingColumns maps the filterable and sortable fields to their column
+var meetingColumns = map[string]string{
+	"meeting_id": "m.meeting_id",
+	"title":      "m.title",
+	"created_at": "m.created_at",
+	"updated_at": "m.updated_at",
+}
+
+const participantField = "participant"
+
+var sqlOperators = map[dbmodels.FilterOperator]string{
+	dbmodels.OperatorEq:    "=",
+	dbmodels.OperatorNeq:   "<>",
+	dbmodels.OperatorGt:    ">",
+	dbmodels.OperatorGte:   ">=",
+	dbmodels.OperatorLt:    "<",
+	dbmodels.OperatorLte:   "<=",
+	dbmodels.OperatorLike:  "LIKE",
+	dbmodels.OperatorIlike: "ILIKE",
+}
+
+// buildMeetingsQuery returns the page and count statements of the query on the meetings of the estate of the tenant
+// with their arguments
+func buildMeetingsQuery(tenant tenancy.Tenant, query *dbmodels.MeetingsQuery) (string, string, []any, error) {
+	where, args, err := buildMeetingsWhere(tenant, query.Filters)
+	if err != nil {
+		return "", "", nil, err
+	}
+	orderBy, err := buildMeetingsOrderBy(query.Sort)
+	if err != nil {
+		return "", "", nil, err
+	}
+	pageQuery := fmt.Sprintf("%s%s ORDER BY %s OFFSET $%d LIMIT $%d", selectMeetingListQuery, where, orderBy,
+		len(args)+1, len(args)+2)
+	return pageQuery, countMeetingListQuery + where, args, nil
+}
+
+// buildMeetingsWhere always selects the meetings of the estate first, then the ones the initiator may read unless it
+// is an admin of the estate
+func buildMeetingsWhere(tenant tenancy.Tenant, filters []dbmodels.Filter) (string, []any, error) {
+	conditions := make([]string, 0, len(filters)+2)
+	args := make([]any, 0, len(filters)+3)
+	args = append(args, tenant.EstateID)
+	conditions = append(conditions, fmt.Sprintf("m.estate_id = $%d", len(args)))
+	if !tenant.IsAdmin() {
+		args = append(args, tenant.InitiatorID, pq.Array(tenant.Groups))
+		conditions = append(conditions, fmt.Sprintf(readerCondition, len(args)-1, len(args)))
+	}
+	for _, filter := range filters {
+		operator, ok := sqlOperators[filter.Operator]
+		if !ok {
+			return "", nil, fmt.Errorf("%w %s on %s", errorresponse.ErrInvalidFilterOperator, filter.Operator, filter.Field)
+		}
+		args = append(args, filter.Value)
+		if filter.Field == participantField {
+			conditions = append(conditions, fmt.Sprintf(participantCondition, operator, len(args)))
+			continue
+		}
+		column, ok := meetingColumns[filter.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("%w %s", errorresponse.ErrInvalidFilterField, filter.Field)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s %s $%d", column, operator, len(args)))
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+// buildMeetingsOrderBy always appends the meeting id so that pages are stable
+func buildMeetingsOrderBy(sort []dbmodels.SortField) (string, error) {
+	if len(sort) == 0 {
+		sort = []dbmodels.SortField{{Field: "created_at", Descending: true}}
+	}
+	terms := make([]string, 0, len(sort)+1)
+	for _, field := range sort {
+		column, ok := meetingColumns[field.Field]
+		if !ok {
+			return "", fmt.Errorf("%w %s", errorresponse.ErrInvalidSortField, field.Field)
+		}
+		direction := "ASC"
+		if field.Descending {
+			direction = "DESC"
+		}
+		terms = append(terms, column+" "+direction)
+	}
+	return strings.Join(append(terms, "m.meeting_id ASC"), ", "), nil
+}
@@ -0,0 +1,10 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the tables if they exist
+DROP TABLE IF EXISTS summaries;
+
+DROP TABLE IF EXISTS transcript_segments;
+
+DROP TABLE IF EXISTS meetings;
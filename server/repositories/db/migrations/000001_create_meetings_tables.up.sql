@@ -0,0 +1,36 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Create the meetings table
+CREATE TABLE IF NOT EXISTS meetings (
+    meeting_id VARCHAR(256) PRIMARY KEY NOT NULL,
+    title TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS meetings_created_at_idx ON meetings (created_at);
+
+-- Create the transcript_segments table, seq keeps the order of the segments within a meeting
+CREATE TABLE IF NOT EXISTS transcript_segments (
+    meeting_id VARCHAR(256) NOT NULL,
+    seq INTEGER NOT NULL,
+    member_name VARCHAR(256) NOT NULL,
+    "timestamp" TIMESTAMPTZ,
+    content TEXT NOT NULL,
+    PRIMARY KEY (meeting_id, seq),
+    FOREIGN KEY (meeting_id) REFERENCES meetings(meeting_id)
+    ON DELETE CASCADE
+);
+
+-- Create the summaries table
+CREATE TABLE IF NOT EXISTS summaries (
+    meeting_id VARCHAR(256) PRIMARY KEY NOT NULL,
+    content TEXT NOT NULL,
+    model VARCHAR(256) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (meeting_id) REFERENCES meetings(meeting_id)
+    ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the table if it exists
+DROP TABLE IF EXISTS summary_jobs;
+
+-- Drop the ENUM type if it exists
+DROP TYPE IF EXISTS job_status_enum;
@@ -0,0 +1,20 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Create ENUM type for the job status
+CREATE TYPE job_status_enum AS ENUM ('PENDING', 'IN_PROGRESS', 'DONE', 'FAILED');
+
+-- Create the summary_jobs table
+CREATE TABLE IF NOT EXISTS summary_jobs (
+    job_id VARCHAR(256) PRIMARY KEY NOT NULL,
+    meeting_id VARCHAR(256) NOT NULL,
+    status job_status_enum NOT NULL,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (meeting_id) REFERENCES meetings(meeting_id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS summary_jobs_meeting_id_idx ON summary_jobs (meeting_id, created_at);
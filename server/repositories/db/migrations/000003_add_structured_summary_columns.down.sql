@@ -0,0 +1,9 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the structured summary columns
+ALTER TABLE summaries
+    DROP COLUMN IF EXISTS action_items,
+    DROP COLUMN IF EXISTS decisions,
+    DROP COLUMN IF EXISTS key_points;
@@ -0,0 +1,9 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Store the structured fields parsed from the answer of the model, content keeps the prose summary
+ALTER TABLE summaries
+    ADD COLUMN IF NOT EXISTS key_points JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS decisions JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS action_items JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,6 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the action_items table
+DROP TABLE IF EXISTS action_items;
@@ -0,0 +1,24 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Create the action_items table, seq keeps the order of the items within a meeting and segment_seq references
+-- the transcript segment where the task was agreed
+CREATE TABLE IF NOT EXISTS action_items (
+    item_id VARCHAR(256) PRIMARY KEY NOT NULL,
+    meeting_id VARCHAR(256) NOT NULL,
+    seq INTEGER NOT NULL,
+    description TEXT NOT NULL,
+    owner VARCHAR(256),
+    due_date DATE,
+    due_phrase TEXT,
+    segment_seq INTEGER,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    done_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (meeting_id) REFERENCES meetings(meeting_id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS action_items_meeting_id_idx ON action_items (meeting_id, seq);
@@ -0,0 +1,7 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the speaker analytics column
+ALTER TABLE summaries
+    DROP COLUMN IF EXISTS analytics;
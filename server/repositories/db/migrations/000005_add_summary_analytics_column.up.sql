@@ -0,0 +1,7 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Store the speaker analytics computed from the transcript together with the summary
+ALTER TABLE summaries
+    ADD COLUMN IF NOT EXISTS analytics JSONB;
@@ -0,0 +1,9 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the idempotency_keys table
+DROP TABLE IF EXISTS idempotency_keys;
+
+-- Drop the index allowing a single unfinished job per meeting
+DROP INDEX IF EXISTS summary_jobs_in_flight_idx;
@@ -0,0 +1,30 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Keep only the latest unfinished job of each meeting before allowing a single one
+UPDATE summary_jobs SET status = 'FAILED', error = 'superseded by a newer summary job', updated_at = NOW()
+WHERE status IN ('PENDING', 'IN_PROGRESS')
+  AND job_id NOT IN (
+    SELECT DISTINCT ON (meeting_id) job_id FROM summary_jobs
+    WHERE status IN ('PENDING', 'IN_PROGRESS')
+    ORDER BY meeting_id, created_at DESC
+  );
+
+-- A meeting has at most one summary being generated
+CREATE UNIQUE INDEX IF NOT EXISTS summary_jobs_in_flight_idx ON summary_jobs (meeting_id)
+    WHERE status IN ('PENDING', 'IN_PROGRESS');
+
+-- Create the idempotency_keys table, the responses replayed to the summary requests sent again with the same key
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(256) PRIMARY KEY NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    meeting_id VARCHAR(256) NOT NULL,
+    job_id VARCHAR(256) NOT NULL,
+    status job_status_enum NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (meeting_id) REFERENCES meetings(meeting_id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_created_at_idx ON idempotency_keys (created_at);
@@ -0,0 +1,7 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the prompt template columns of the summaries
+ALTER TABLE summaries DROP COLUMN IF EXISTS prompt_version;
+ALTER TABLE summaries DROP COLUMN IF EXISTS prompt_template;
@@ -0,0 +1,7 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Record the prompt templates which generated each summary, the existing summaries used the first default prompt
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS prompt_template VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE summaries ADD COLUMN IF NOT EXISTS prompt_version INTEGER NOT NULL DEFAULT 1;
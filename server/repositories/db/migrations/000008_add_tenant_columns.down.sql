@@ -0,0 +1,17 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Keep a single idempotency key of each value before making the key the primary key again
+DELETE FROM idempotency_keys WHERE ctid NOT IN (
+    SELECT DISTINCT ON (idempotency_key) ctid FROM idempotency_keys ORDER BY idempotency_key, created_at DESC
+);
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (idempotency_key);
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS estate_id;
+
+DROP INDEX IF EXISTS meetings_estate_id_idx;
+
+ALTER TABLE summary_jobs DROP COLUMN IF EXISTS estate_id, DROP COLUMN IF EXISTS created_by;
+ALTER TABLE summaries DROP COLUMN IF EXISTS estate_id, DROP COLUMN IF EXISTS created_by;
+ALTER TABLE meetings DROP COLUMN IF EXISTS estate_id, DROP COLUMN IF EXISTS created_by;
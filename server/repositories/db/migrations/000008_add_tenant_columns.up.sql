@@ -0,0 +1,22 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Stamp the meetings, summaries and jobs with the estate owning them and the initiator which created them, the rows
+-- created before have no estate and are not visible to any tenant
+ALTER TABLE meetings
+    ADD COLUMN IF NOT EXISTS estate_id VARCHAR(256) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS created_by VARCHAR(256) NOT NULL DEFAULT '';
+ALTER TABLE summaries
+    ADD COLUMN IF NOT EXISTS estate_id VARCHAR(256) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS created_by VARCHAR(256) NOT NULL DEFAULT '';
+ALTER TABLE summary_jobs
+    ADD COLUMN IF NOT EXISTS estate_id VARCHAR(256) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS created_by VARCHAR(256) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS meetings_estate_id_idx ON meetings (estate_id, created_at);
+
+-- The idempotency keys are chosen by the clients, each estate has its own keys
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS estate_id VARCHAR(256) NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (estate_id, idempotency_key);
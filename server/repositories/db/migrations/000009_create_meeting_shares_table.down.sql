@@ -0,0 +1,9 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the table if it exists
+DROP TABLE IF EXISTS meeting_shares;
+
+-- Drop the ENUM type if it exists
+DROP TYPE IF EXISTS principal_type_enum;
@@ -0,0 +1,21 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Create ENUM type for the kind of principal a meeting is shared with
+CREATE TYPE principal_type_enum AS ENUM ('user', 'group');
+
+-- Create the meeting_shares table, the users and groups of the estate of the meeting which may read it besides its
+-- owner and participants
+CREATE TABLE IF NOT EXISTS meeting_shares (
+    meeting_id VARCHAR(256) NOT NULL,
+    principal_type principal_type_enum NOT NULL,
+    principal_id VARCHAR(256) NOT NULL,
+    created_by VARCHAR(256) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (meeting_id, principal_type, principal_id),
+    FOREIGN KEY (meeting_id) REFERENCES meetings(meeting_id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS meeting_shares_principal_idx ON meeting_shares (principal_type, principal_id);
@@ -0,0 +1,7 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the redacted values column
+ALTER TABLE summaries
+    DROP COLUMN IF EXISTS redactions;
@@ -0,0 +1,7 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Store the values redacted from the transcript, with their placeholders, together with the summary
+ALTER TABLE summaries
+    ADD COLUMN IF NOT EXISTS redactions JSONB NOT NULL DEFAULT '[]';
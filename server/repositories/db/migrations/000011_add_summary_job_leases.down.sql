@@ -0,0 +1,9 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the leases of the jobs
+DROP INDEX IF EXISTS summary_jobs_lease_idx;
+ALTER TABLE summary_jobs
+    DROP COLUMN IF EXISTS owner,
+    DROP COLUMN IF EXISTS heartbeat_at;
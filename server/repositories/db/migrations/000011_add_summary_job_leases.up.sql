@@ -0,0 +1,12 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Lease the unfinished jobs to the instance running them, which renews the lease until the job is finished. The jobs
+-- created before have an expired lease.
+ALTER TABLE summary_jobs
+    ADD COLUMN IF NOT EXISTS owner VARCHAR(256) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS heartbeat_at TIMESTAMPTZ NOT NULL DEFAULT 'epoch';
+
+CREATE INDEX IF NOT EXISTS summary_jobs_lease_idx ON summary_jobs (owner, heartbeat_at)
+    WHERE status IN ('PENDING', 'IN_PROGRESS');
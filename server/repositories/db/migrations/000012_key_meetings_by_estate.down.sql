@@ -0,0 +1,50 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Keep the meeting of the first estate using each id before making the id the primary key again, the cascade
+-- deletes the rows of the other meetings
+DELETE FROM meetings WHERE ctid NOT IN (
+    SELECT DISTINCT ON (meeting_id) ctid FROM meetings ORDER BY meeting_id, created_at
+);
+
+DROP INDEX IF EXISTS action_items_meeting_id_idx;
+CREATE INDEX IF NOT EXISTS action_items_meeting_id_idx ON action_items (meeting_id, seq);
+DROP INDEX IF EXISTS summary_jobs_meeting_id_idx;
+CREATE INDEX IF NOT EXISTS summary_jobs_meeting_id_idx ON summary_jobs (meeting_id, created_at);
+DROP INDEX IF EXISTS summary_jobs_in_flight_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS summary_jobs_in_flight_idx ON summary_jobs (meeting_id)
+    WHERE status IN ('PENDING', 'IN_PROGRESS');
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_meeting_fkey;
+ALTER TABLE meeting_shares DROP CONSTRAINT IF EXISTS meeting_shares_meeting_fkey;
+ALTER TABLE action_items DROP CONSTRAINT IF EXISTS action_items_meeting_fkey;
+ALTER TABLE summary_jobs DROP CONSTRAINT IF EXISTS summary_jobs_meeting_fkey;
+ALTER TABLE summaries DROP CONSTRAINT IF EXISTS summaries_meeting_fkey;
+ALTER TABLE transcript_segments DROP CONSTRAINT IF EXISTS transcript_segments_meeting_fkey;
+
+ALTER TABLE meeting_shares DROP CONSTRAINT IF EXISTS meeting_shares_pkey;
+ALTER TABLE meeting_shares ADD PRIMARY KEY (meeting_id, principal_type, principal_id);
+ALTER TABLE summaries DROP CONSTRAINT IF EXISTS summaries_pkey;
+ALTER TABLE summaries ADD PRIMARY KEY (meeting_id);
+ALTER TABLE transcript_segments DROP CONSTRAINT IF EXISTS transcript_segments_pkey;
+ALTER TABLE transcript_segments ADD PRIMARY KEY (meeting_id, seq);
+ALTER TABLE meetings DROP CONSTRAINT IF EXISTS meetings_pkey;
+ALTER TABLE meetings ADD PRIMARY KEY (meeting_id);
+
+ALTER TABLE transcript_segments ADD CONSTRAINT transcript_segments_meeting_id_fkey FOREIGN KEY (meeting_id)
+    REFERENCES meetings(meeting_id) ON DELETE CASCADE;
+ALTER TABLE summaries ADD CONSTRAINT summaries_meeting_id_fkey FOREIGN KEY (meeting_id)
+    REFERENCES meetings(meeting_id) ON DELETE CASCADE;
+ALTER TABLE summary_jobs ADD CONSTRAINT summary_jobs_meeting_id_fkey FOREIGN KEY (meeting_id)
+    REFERENCES meetings(meeting_id) ON DELETE CASCADE;
+ALTER TABLE action_items ADD CONSTRAINT action_items_meeting_id_fkey FOREIGN KEY (meeting_id)
+    REFERENCES meetings(meeting_id) ON DELETE CASCADE;
+ALTER TABLE meeting_shares ADD CONSTRAINT meeting_shares_meeting_id_fkey FOREIGN KEY (meeting_id)
+    REFERENCES meetings(meeting_id) ON DELETE CASCADE;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_meeting_id_fkey FOREIGN KEY (meeting_id)
+    REFERENCES meetings(meeting_id) ON DELETE CASCADE;
+
+ALTER TABLE meeting_shares DROP COLUMN IF EXISTS estate_id;
+ALTER TABLE action_items DROP COLUMN IF EXISTS estate_id;
+ALTER TABLE transcript_segments DROP COLUMN IF EXISTS estate_id;
@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Key the meetings by their estate and id, each estate has its own meeting ids. The tables of the meetings get the
+-- estate of their meeting and reference it by both columns.
+ALTER TABLE transcript_segments ADD COLUMN IF NOT EXISTS estate_id VARCHAR(256) NOT NULL DEFAULT '';
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS estate_id VARCHAR(256) NOT NULL DEFAULT '';
+ALTER TABLE meeting_shares ADD COLUMN IF NOT EXISTS estate_id VARCHAR(256) NOT NULL DEFAULT '';
+
+UPDATE transcript_segments t SET estate_id = m.estate_id FROM meetings m WHERE m.meeting_id = t.meeting_id;
+UPDATE summaries s SET estate_id = m.estate_id FROM meetings m WHERE m.meeting_id = s.meeting_id;
+UPDATE summary_jobs j SET estate_id = m.estate_id FROM meetings m WHERE m.meeting_id = j.meeting_id;
+UPDATE action_items a SET estate_id = m.estate_id FROM meetings m WHERE m.meeting_id = a.meeting_id;
+UPDATE meeting_shares sh SET estate_id = m.estate_id FROM meetings m WHERE m.meeting_id = sh.meeting_id;
+UPDATE idempotency_keys k SET estate_id = m.estate_id FROM meetings m WHERE m.meeting_id = k.meeting_id;
+
+ALTER TABLE transcript_segments DROP CONSTRAINT IF EXISTS transcript_segments_meeting_id_fkey;
+ALTER TABLE summaries DROP CONSTRAINT IF EXISTS summaries_meeting_id_fkey;
+ALTER TABLE summary_jobs DROP CONSTRAINT IF EXISTS summary_jobs_meeting_id_fkey;
+ALTER TABLE action_items DROP CONSTRAINT IF EXISTS action_items_meeting_id_fkey;
+ALTER TABLE meeting_shares DROP CONSTRAINT IF EXISTS meeting_shares_meeting_id_fkey;
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_meeting_id_fkey;
+
+ALTER TABLE meetings DROP CONSTRAINT IF EXISTS meetings_pkey;
+ALTER TABLE meetings ADD PRIMARY KEY (estate_id, meeting_id);
+ALTER TABLE transcript_segments DROP CONSTRAINT IF EXISTS transcript_segments_pkey;
+ALTER TABLE transcript_segments ADD PRIMARY KEY (estate_id, meeting_id, seq);
+ALTER TABLE summaries DROP CONSTRAINT IF EXISTS summaries_pkey;
+ALTER TABLE summaries ADD PRIMARY KEY (estate_id, meeting_id);
+ALTER TABLE meeting_shares DROP CONSTRAINT IF EXISTS meeting_shares_pkey;
+ALTER TABLE meeting_shares ADD PRIMARY KEY (estate_id, meeting_id, principal_type, principal_id);
+
+ALTER TABLE transcript_segments ADD CONSTRAINT transcript_segments_meeting_fkey FOREIGN KEY (estate_id, meeting_id)
+    REFERENCES meetings(estate_id, meeting_id) ON DELETE CASCADE;
+ALTER TABLE summaries ADD CONSTRAINT summaries_meeting_fkey FOREIGN KEY (estate_id, meeting_id)
+    REFERENCES meetings(estate_id, meeting_id) ON DELETE CASCADE;
+ALTER TABLE summary_jobs ADD CONSTRAINT summary_jobs_meeting_fkey FOREIGN KEY (estate_id, meeting_id)
+    REFERENCES meetings(estate_id, meeting_id) ON DELETE CASCADE;
+ALTER TABLE action_items ADD CONSTRAINT action_items_meeting_fkey FOREIGN KEY (estate_id, meeting_id)
+    REFERENCES meetings(estate_id, meeting_id) ON DELETE CASCADE;
+ALTER TABLE meeting_shares ADD CONSTRAINT meeting_shares_meeting_fkey FOREIGN KEY (estate_id, meeting_id)
+    REFERENCES meetings(estate_id, meeting_id) ON DELETE CASCADE;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_meeting_fkey FOREIGN KEY (estate_id, meeting_id)
+    REFERENCES meetings(estate_id, meeting_id) ON DELETE CASCADE;
+
+-- A meeting of an estate has at most one summary being generated
+DROP INDEX IF EXISTS summary_jobs_in_flight_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS summary_jobs_in_flight_idx ON summary_jobs (estate_id, meeting_id)
+    WHERE status IN ('PENDING', 'IN_PROGRESS');
+
+DROP INDEX IF EXISTS summary_jobs_meeting_id_idx;
+CREATE INDEX IF NOT EXISTS summary_jobs_meeting_id_idx ON summary_jobs (estate_id, meeting_id, created_at);
+DROP INDEX IF EXISTS action_items_meeting_id_idx;
+CREATE INDEX IF NOT EXISTS action_items_meeting_id_idx ON action_items (estate_id, meeting_id, seq);
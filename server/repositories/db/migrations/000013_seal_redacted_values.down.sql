@@ -0,0 +1,9 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the sealed redacted values, the values are not restored in the summaries
+DROP TABLE IF EXISTS meeting_redactions;
+
+DROP INDEX IF EXISTS meetings_unredacted_idx;
+ALTER TABLE meetings DROP COLUMN IF EXISTS redacted;
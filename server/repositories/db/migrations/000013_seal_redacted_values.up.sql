@@ -0,0 +1,33 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Create the meeting_redactions table, the values redacted from the title and transcript of the meetings, which are
+-- stored redacted. The values are sealed with the redaction key, sealed_value is null when the value was dropped. id
+-- keeps the order of redaction.
+CREATE TABLE IF NOT EXISTS meeting_redactions (
+    id BIGSERIAL NOT NULL,
+    estate_id VARCHAR(256) NOT NULL,
+    meeting_id VARCHAR(256) NOT NULL,
+    placeholder VARCHAR(64) NOT NULL,
+    category VARCHAR(32) NOT NULL,
+    sealed_value BYTEA,
+    PRIMARY KEY (estate_id, meeting_id, placeholder),
+    CONSTRAINT meeting_redactions_meeting_fkey FOREIGN KEY (estate_id, meeting_id)
+    REFERENCES meetings(estate_id, meeting_id) ON DELETE CASCADE
+);
+
+-- The plaintext values stored with the summaries cannot be sealed here, they are dropped. Their placeholders are kept
+-- so that the values found again in the transcripts stored before their redaction get new placeholders.
+INSERT INTO meeting_redactions (estate_id, meeting_id, placeholder, category)
+SELECT s.estate_id, s.meeting_id, r ->> 'placeholder', r ->> 'category'
+FROM summaries s, jsonb_array_elements(s.redactions) WITH ORDINALITY AS e(r, n)
+ORDER BY s.estate_id, s.meeting_id, e.n
+ON CONFLICT DO NOTHING;
+
+UPDATE summaries SET redactions = (SELECT jsonb_agg(r - 'value') FROM jsonb_array_elements(redactions) r)
+WHERE redactions <> '[]';
+
+-- The meetings stored before their title and transcript were redacted are redacted by the service at startup
+ALTER TABLE meetings ADD COLUMN IF NOT EXISTS redacted BOOLEAN NOT NULL DEFAULT false;
+CREATE INDEX IF NOT EXISTS meetings_unredacted_idx ON meetings (estate_id, meeting_id) WHERE NOT redacted;
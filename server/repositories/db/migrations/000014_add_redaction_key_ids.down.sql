@@ -0,0 +1,6 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Drop the ids of the redaction keys, the values are opened with the current key only
+ALTER TABLE meeting_redactions DROP COLUMN IF EXISTS key_id;
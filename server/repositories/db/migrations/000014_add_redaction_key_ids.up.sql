@@ -0,0 +1,7 @@
+/*
+ * Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+ */
+
+-- Store the id of the redaction key each value is sealed with, so that the key can be rotated. The values sealed
+-- before have no key id and are opened with any of the configured keys.
+ALTER TABLE meeting_redactions ADD COLUMN IF NOT EXISTS key_id VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,123 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"meeting-analyzer/server/models/dbmodels"
+)
+
+const (
+	selectRedactedValuesQuery = `SELECT placeholder, category, sealed_value, key_id FROM meeting_redactions
+		WHERE meeting_id = $1 AND estate_id = $2 ORDER BY id`
+	insertRedactedValueQuery = `INSERT INTO meeting_redactions (meeting_id, estate_id, placeholder, category,
+		sealed_value, key_id) VALUES ($1, $2, $3, $4, $5, $6)`
+	deleteRedactedValuesQuery = `DELETE FROM meeting_redactions WHERE meeting_id = $1 AND estate_id = $2`
+	redactMeetingQuery        = `UPDATE meetings SET title = $3, redacted = true
+		WHERE meeting_id = $1 AND estate_id = $2 AND NOT redacted`
+	selectUnredactedMeetingsQuery = `SELECT meeting_id, estate_id, created_by, title, created_at, updated_at
+		FROM meetings WHERE NOT redacted AND (estate_id, meeting_id) > ($1, $2) ORDER BY estate_id, meeting_id LIMIT $3`
+)
+
+func (r *repository) GetRedactedValues(ctx context.Context, meetingID string) ([]dbmodels.RedactedValue, error) {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.dbCon.QueryContext(ctx, selectRedactedValuesQuery, meetingID, estateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select redacted values of meeting %s: %w", meetingID, err)
+	}
+	defer rows.Close()
+
+	values := make([]dbmodels.RedactedValue, 0)
+	for rows.Next() {
+		var value dbmodels.RedactedValue
+		if err = rows.Scan(&value.Placeholder, &value.Category, &value.Sealed, &value.KeyID); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+func (r *repository) ListUnredactedMeetings(ctx context.Context, afterEstateID, afterMeetingID string,
+	limit int) ([]dbmodels.Meeting, error) {
+	rows, err := r.dbCon.QueryContext(ctx, selectUnredactedMeetingsQuery, afterEstateID, afterMeetingID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select unredacted meetings: %w", err)
+	}
+	defer rows.Close()
+
+	meetings := make([]dbmodels.Meeting, 0)
+	for rows.Next() {
+		var meeting dbmodels.Meeting
+		if err = rows.Scan(&meeting.MeetingID, &meeting.EstateID, &meeting.CreatedBy, &meeting.Title,
+			&meeting.CreatedAt, &meeting.UpdatedAt); err != nil {
+			return nil, err
+		}
+		meetings = append(meetings, meeting)
+	}
+	return meetings, rows.Err()
+}
+
+func (r *repository) RedactTranscript(ctx context.Context, meeting *dbmodels.Meeting,
+	segments []dbmodels.TranscriptSegment, values []dbmodels.RedactedValue) error {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := r.dbCon.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// the update locks the meeting row, a transcript stored meanwhile was already redacted
+	res, err := tx.ExecContext(ctx, redactMeetingQuery, meeting.MeetingID, estateID, meeting.Title)
+	if err != nil {
+		return fmt.Errorf("failed to redact title of meeting %s: %w", meeting.MeetingID, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil || affected == 0 {
+		return err
+	}
+	if err = replaceSegments(ctx, tx, meeting.MeetingID, estateID, segments); err != nil {
+		return err
+	}
+	if err = insertRedactedValues(ctx, tx, meeting.MeetingID, estateID, values); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// replaceSegments replaces the transcript segments of the meeting
+func replaceSegments(ctx context.Context, tx *sql.Tx, meetingID, estateID string,
+	segments []dbmodels.TranscriptSegment) error {
+	if _, err := tx.ExecContext(ctx, deleteSegmentsQuery, meetingID, estateID); err != nil {
+		return fmt.Errorf("failed to delete transcript of meeting %s: %w", meetingID, err)
+	}
+	for _, segment := range segments {
+		if _, err := tx.ExecContext(ctx, insertSegmentQuery, meetingID, estateID, segment.Seq, segment.MemberName,
+			segment.Timestamp, segment.Content); err != nil {
+			return fmt.Errorf("failed to insert transcript segment %d of meeting %s: %w", segment.Seq, meetingID, err)
+		}
+	}
+	return nil
+}
+
+// insertRedactedValues adds the values redacted from the meeting, in order of redaction
+func insertRedactedValues(ctx context.Context, tx *sql.Tx, meetingID, estateID string,
+	values []dbmodels.RedactedValue) error {
+	for _, value := range values {
+		if _, err := tx.ExecContext(ctx, insertRedactedValueQuery, meetingID, estateID, value.Placeholder,
+			value.Category, value.Sealed, value.KeyID); err != nil {
+			return fmt.Errorf("failed to insert redacted value %s of meeting %s: %w", value.Placeholder, meetingID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models/dbmodels"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRedactedValues(t *testing.T) {
+	r, mock := newMockRepository(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectRedactedValuesQuery)).WithArgs("m1", "e1").
+		WillReturnRows(sqlmock.NewRows([]string{"placeholder", "category", "sealed_value", "key_id"}).
+			AddRow("[EMAIL_1]", "email", []byte("sealed"), "k1").
+			AddRow("[PHONE_1]", "phone", nil, ""))
+
+	values, err := r.GetRedactedValues(tenantContext, "m1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []dbmodels.RedactedValue{
+		{Placeholder: "[EMAIL_1]", Category: generated.Email, Sealed: []byte("sealed"), KeyID: "k1"},
+		{Placeholder: "[PHONE_1]", Category: generated.Phone},
+	}, values)
+}
+
+func TestListUnredactedMeetings(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+
+	// the meetings of all the estates are listed, after the last meeting of the previous batch
+	mock.ExpectQuery(regexp.QuoteMeta(selectUnredactedMeetingsQuery)).WithArgs("e0", "m9", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"meeting_id", "estate_id", "created_by", "title", "created_at",
+			"updated_at"}).
+			AddRow("m1", "e1", "u1", "Sync", now, now).
+			AddRow("m1", "e2", "u2", "Review", now, now))
+
+	meetings, err := r.ListUnredactedMeetings(context.Background(), "e0", "m9", 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, []dbmodels.Meeting{
+		{MeetingID: "m1", EstateID: "e1", CreatedBy: "u1", Title: "Sync", CreatedAt: now, UpdatedAt: now},
+		{MeetingID: "m1", EstateID: "e2", CreatedBy: "u2", Title: "Review", CreatedAt: now, UpdatedAt: now},
+	}, meetings)
+}
+
+func TestRedactTranscript(t *testing.T) {
+	r, mock := newMockRepository(t)
+	meeting := &dbmodels.Meeting{MeetingID: "m1", EstateID: "e1", Title: "Sync with [EMAIL_1]"}
+	segments := []dbmodels.TranscriptSegment{{MeetingID: "m1", Seq: 0, MemberName: "Alice", Content: "Call [PHONE_1]"}}
+	values := []dbmodels.RedactedValue{
+		{Placeholder: "[EMAIL_1]", Category: generated.Email, Sealed: []byte("sealed email"), KeyID: "k1"},
+		{Placeholder: "[PHONE_1]", Category: generated.Phone, Sealed: []byte("sealed phone"), KeyID: "k1"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(redactMeetingQuery)).WithArgs("m1", "e1", "Sync with [EMAIL_1]").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteSegmentsQuery)).WithArgs("m1", "e1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO transcript_segments")).
+		WithArgs("m1", "e1", 0, "Alice", nil, "Call [PHONE_1]").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertRedactedValueQuery)).
+		WithArgs("m1", "e1", "[EMAIL_1]", generated.Email, []byte("sealed email"), "k1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertRedactedValueQuery)).
+		WithArgs("m1", "e1", "[PHONE_1]", generated.Phone, []byte("sealed phone"), "k1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.RedactTranscript(tenantContext, meeting, segments, values))
+}
+
+func TestRedactTranscript_RedactedMeanwhile(t *testing.T) {
+	r, mock := newMockRepository(t)
+	meeting := &dbmodels.Meeting{MeetingID: "m1", EstateID: "e1", Title: "Sync"}
+
+	// the transcript is not replaced
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(redactMeetingQuery)).WithArgs("m1", "e1", "Sync").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	assert.NoError(t, r.RedactTranscript(tenantContext, meeting, nil, nil))
+}
+
+func TestRedactTranscript_RollbackOnError(t *testing.T) {
+	r, mock := newMockRepository(t)
+	meeting := &dbmodels.Meeting{MeetingID: "m1", EstateID: "e1", Title: "Sync"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(redactMeetingQuery)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteSegmentsQuery)).WillReturnError(errors.New("boom"))
+	mock.ExpectRollback()
+
+	assert.Error(t, r.RedactTranscript(tenantContext, meeting, nil, nil))
+}
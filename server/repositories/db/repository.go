@@ -3,10 +3,46 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
 	"meeting-analyzer/server/repositories"
 
 	"eos2git.cec.lab.emc.com/ISG-Edge/hzp-go-commons/database/interfaces"
+	"github.com/lib/pq"
+)
+
+const (
+	// the title and transcript of the meetings are stored redacted
+	upsertMeetingQuery = `INSERT INTO meetings (meeting_id, estate_id, created_by, title, created_at, updated_at,
+		redacted) VALUES ($1, $2, $3, $4, $5, $6, true)
+		ON CONFLICT (estate_id, meeting_id) DO UPDATE SET title = EXCLUDED.title, updated_at = EXCLUDED.updated_at,
+		redacted = true`
+	deleteSegmentsQuery = `DELETE FROM transcript_segments WHERE meeting_id = $1 AND estate_id = $2`
+	insertSegmentQuery  = `INSERT INTO transcript_segments (meeting_id, estate_id, seq, member_name, "timestamp",
+		content) VALUES ($1, $2, $3, $4, $5, $6)`
+	selectMeetingQuery = `SELECT meeting_id, estate_id, created_by, title, created_at, updated_at FROM meetings
+		WHERE meeting_id = $1 AND estate_id = $2`
+	deleteMeetingQuery  = `DELETE FROM meetings WHERE meeting_id = $1 AND estate_id = $2`
+	selectSegmentsQuery = `SELECT meeting_id, seq, member_name, "timestamp", content FROM transcript_segments
+		WHERE meeting_id = $1 AND estate_id = $2 ORDER BY seq`
+	upsertSummaryQuery = `INSERT INTO summaries (meeting_id, estate_id, created_by, content, key_points, decisions,
+		action_items, analytics, redactions, model, prompt_template, prompt_version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (estate_id, meeting_id) DO UPDATE SET created_by = EXCLUDED.created_by, content = EXCLUDED.content,
+		key_points = EXCLUDED.key_points,
+		decisions = EXCLUDED.decisions, action_items = EXCLUDED.action_items, analytics = EXCLUDED.analytics,
+		redactions = EXCLUDED.redactions, model = EXCLUDED.model, prompt_template = EXCLUDED.prompt_template, prompt_version = EXCLUDED.prompt_version,
+		updated_at = EXCLUDED.updated_at`
+	selectSummaryQuery = `SELECT meeting_id, estate_id, created_by, content, key_points, decisions, action_items,
+		analytics, redactions, model, prompt_template, prompt_version, created_at, updated_at FROM summaries
+		WHERE meeting_id = $1 AND estate_id = $2`
 )
 
 type repository struct {
@@ -14,15 +50,302 @@ type repository struct {
 	dbCon *sql.DB
 }
 
-// NewRepository creates a new DriftRepository with dependency injection
+// NewRepository creates a new meetings Repository with dependency injection
 func NewRepository(con interfaces.Database) (repositories.Repository, error) {
-	//dbCon, err := con.GetConnection()
-	//if err != nil {
-	//	return nil, err
-	//}
+	dbCon, err := con.GetConnection()
+	if err != nil {
+		return nil, err
+	}
 
 	return &repository{
-		db:    nil,
-		dbCon: nil,
+		db:    con,
+		dbCon: dbCon,
 	}, nil
 }
+
+func (r *repository) CreateMeeting(ctx context.Context, meeting *dbmodels.Meeting, segments []dbmodels.TranscriptSegment,
+	values []dbmodels.RedactedValue, shares []dbmodels.MeetingShare, job *dbmodels.SummaryJob) error {
+	tx, err := r.dbCon.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// the upsert locks the meeting row until the commit, the concurrent requests for the meeting wait for it
+	if _, err = tx.ExecContext(ctx, upsertMeetingQuery, meeting.MeetingID, meeting.EstateID, meeting.CreatedBy,
+		meeting.Title, meeting.CreatedAt, meeting.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to upsert meeting %s: %w", meeting.MeetingID, err)
+	}
+	// the transcript of the running job is left untouched, the rollback restores the meeting
+	var inFlight bool
+	if err = tx.QueryRowContext(ctx, selectInFlightJobQuery, meeting.MeetingID, meeting.EstateID).Scan(&inFlight); err != nil {
+		return fmt.Errorf("failed to read the jobs of meeting %s: %w", meeting.MeetingID, err)
+	}
+	if inFlight {
+		return errorresponse.WithArgs(errorresponse.ErrSummaryInProgress, meeting.MeetingID)
+	}
+	if err = replaceSegments(ctx, tx, meeting.MeetingID, meeting.EstateID, segments); err != nil {
+		return err
+	}
+	// the placeholders of the new transcript are numbered again
+	if _, err = tx.ExecContext(ctx, deleteRedactedValuesQuery, meeting.MeetingID, meeting.EstateID); err != nil {
+		return fmt.Errorf("failed to delete redacted values of meeting %s: %w", meeting.MeetingID, err)
+	}
+	if err = insertRedactedValues(ctx, tx, meeting.MeetingID, meeting.EstateID, values); err != nil {
+		return err
+	}
+	for _, share := range shares {
+		if _, err = tx.ExecContext(ctx, upsertShareQuery, meeting.MeetingID, meeting.EstateID, share.PrincipalType,
+			share.PrincipalID, share.CreatedBy, share.CreatedAt); err != nil {
+			return fmt.Errorf("failed to share meeting %s with %s %s: %w", meeting.MeetingID, share.PrincipalType,
+				share.PrincipalID, err)
+		}
+	}
+	if err = insertJob(ctx, tx, job); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *repository) GetMeeting(ctx context.Context, meetingID string) (*dbmodels.Meeting, error) {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var meeting dbmodels.Meeting
+	err = r.dbCon.QueryRowContext(ctx, selectMeetingQuery, meetingID, estateID).Scan(&meeting.MeetingID,
+		&meeting.EstateID, &meeting.CreatedBy, &meeting.Title, &meeting.CreatedAt, &meeting.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorresponse.WithArgs(errorresponse.ErrMeetingIDNotFound, meetingID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &meeting, nil
+}
+
+func (r *repository) ListMeetings(ctx context.Context, query *dbmodels.MeetingsQuery) ([]dbmodels.MeetingListItem, int, error) {
+	tenant, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return nil, 0, errorresponse.ErrUnauthenticated
+	}
+	pageQuery, countQuery, args, err := buildMeetingsQuery(tenant, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err = r.dbCon.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.dbCon.QueryContext(ctx, pageQuery, append(args, query.Offset, query.Limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]dbmodels.MeetingListItem, 0)
+	for rows.Next() {
+		item, err := scanMeetingListItem(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, *item)
+	}
+	return items, total, rows.Err()
+}
+
+// scanMeetingListItem reads a row of selectMeetingListQuery, the summary and job columns are null when missing
+func scanMeetingListItem(rows *sql.Rows) (*dbmodels.MeetingListItem, error) {
+	var item dbmodels.MeetingListItem
+	var summaryContent, summaryModel, promptTemplate, jobID, jobStatus, jobError sql.NullString
+	var promptVersion sql.NullInt64
+	var summaryCreatedAt, summaryUpdatedAt, jobCreatedAt, jobUpdatedAt sql.NullTime
+	var keyPoints, decisions, actionItems, analytics, redactions []byte
+	var summaryCreatedBy, jobCreatedBy sql.NullString
+	err := rows.Scan(&item.MeetingID, &item.EstateID, &item.CreatedBy, &item.Title, &item.CreatedAt, &item.UpdatedAt,
+		pq.Array(&item.Participants), &summaryCreatedBy, &summaryContent, &keyPoints, &decisions, &actionItems, &analytics, &redactions, &summaryModel, &promptTemplate,
+		&promptVersion, &summaryCreatedAt, &summaryUpdatedAt,
+		&jobID, &jobCreatedBy, &jobStatus, &jobError, &jobCreatedAt, &jobUpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if summaryContent.Valid {
+		item.Summary = &dbmodels.Summary{
+			MeetingID:      item.MeetingID,
+			EstateID:       item.EstateID,
+			CreatedBy:      summaryCreatedBy.String,
+			Content:        summaryContent.String,
+			Model:          summaryModel.String,
+			PromptTemplate: promptTemplate.String,
+			PromptVersion:  int(promptVersion.Int64),
+			CreatedAt:      summaryCreatedAt.Time,
+			UpdatedAt:      summaryUpdatedAt.Time,
+		}
+		if err = unmarshalSummaryFields(item.Summary, keyPoints, decisions, actionItems, analytics, redactions); err != nil {
+			return nil, err
+		}
+	}
+	if jobID.Valid {
+		item.Job = &dbmodels.SummaryJob{
+			JobID:     jobID.String,
+			MeetingID: item.MeetingID,
+			EstateID:  item.EstateID,
+			CreatedBy: jobCreatedBy.String,
+			Status:    generated.JobStatusEnum(jobStatus.String),
+			CreatedAt: jobCreatedAt.Time,
+			UpdatedAt: jobUpdatedAt.Time,
+		}
+		if jobError.Valid {
+			item.Job.Error = &jobError.String
+		}
+	}
+	return &item, nil
+}
+
+func (r *repository) DeleteMeeting(ctx context.Context, meetingID string) error {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.dbCon.ExecContext(ctx, deleteMeetingQuery, meetingID, estateID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errorresponse.WithArgs(errorresponse.ErrMeetingIDNotFound, meetingID)
+	}
+	return nil
+}
+
+func (r *repository) GetTranscriptSegments(ctx context.Context, meetingID string) ([]dbmodels.TranscriptSegment, error) {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.dbCon.QueryContext(ctx, selectSegmentsQuery, meetingID, estateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	segments := make([]dbmodels.TranscriptSegment, 0)
+	for rows.Next() {
+		var segment dbmodels.TranscriptSegment
+		if err = rows.Scan(&segment.MeetingID, &segment.Seq, &segment.MemberName, &segment.Timestamp, &segment.Content); err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+	return segments, rows.Err()
+}
+
+func (r *repository) UpsertSummary(ctx context.Context, summary *dbmodels.Summary) error {
+	keyPoints, decisions, actionItems, redactions, err := marshalSummaryFields(summary)
+	if err != nil {
+		return err
+	}
+	// the analytics column stays null, rather than holding a JSON null, when there are no analytics
+	var analytics any
+	if summary.Analytics != nil {
+		if analytics, err = json.Marshal(summary.Analytics); err != nil {
+			return err
+		}
+	}
+	_, err = r.dbCon.ExecContext(ctx, upsertSummaryQuery, summary.MeetingID, summary.EstateID, summary.CreatedBy,
+		summary.Content, keyPoints, decisions, actionItems, analytics, redactions, summary.Model, summary.PromptTemplate,
+		summary.PromptVersion, summary.CreatedAt, summary.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert summary of meeting %s: %w", summary.MeetingID, err)
+	}
+	return nil
+}
+
+func (r *repository) GetSummary(ctx context.Context, meetingID string) (*dbmodels.Summary, error) {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var summary dbmodels.Summary
+	var keyPoints, decisions, actionItems, analytics, redactions []byte
+	err = r.dbCon.QueryRowContext(ctx, selectSummaryQuery, meetingID, estateID).
+		Scan(&summary.MeetingID, &summary.EstateID, &summary.CreatedBy, &summary.Content, &keyPoints, &decisions, &actionItems, &analytics, &redactions, &summary.Model,
+			&summary.PromptTemplate, &summary.PromptVersion, &summary.CreatedAt, &summary.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errorresponse.WithArgs(errorresponse.ErrSummaryNotFound, meetingID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = unmarshalSummaryFields(&summary, keyPoints, decisions, actionItems, analytics, redactions); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// estateOf returns the estate of the tenant of ctx, the reads and updates of the repository only see its rows
+func estateOf(ctx context.Context) (string, error) {
+	tenant, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return "", errorresponse.ErrUnauthenticated
+	}
+	return tenant.EstateID, nil
+}
+
+// marshalSummaryFields encodes the JSONB columns of the summary, nil slices are stored as empty arrays
+func marshalSummaryFields(summary *dbmodels.Summary) ([]byte, []byte, []byte, []byte, error) {
+	keyPoints, err := json.Marshal(emptyIfNil(summary.KeyPoints))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	decisions, err := json.Marshal(emptyIfNil(summary.Decisions))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	actionItems, err := json.Marshal(emptyIfNil(summary.ActionItems))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	redactions, err := json.Marshal(emptyIfNil(summary.Redactions))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return keyPoints, decisions, actionItems, redactions, nil
+}
+
+// unmarshalSummaryFields decodes the JSONB columns of the summary, analytics is null for the older summaries
+func unmarshalSummaryFields(summary *dbmodels.Summary, keyPoints []byte, decisions []byte, actionItems []byte,
+	analytics []byte, redactions []byte) error {
+	if err := json.Unmarshal(keyPoints, &summary.KeyPoints); err != nil {
+		return fmt.Errorf("invalid key points of meeting %s: %w", summary.MeetingID, err)
+	}
+	if err := json.Unmarshal(decisions, &summary.Decisions); err != nil {
+		return fmt.Errorf("invalid decisions of meeting %s: %w", summary.MeetingID, err)
+	}
+	if err := json.Unmarshal(actionItems, &summary.ActionItems); err != nil {
+		return fmt.Errorf("invalid action items of meeting %s: %w", summary.MeetingID, err)
+	}
+	if analytics != nil {
+		if err := json.Unmarshal(analytics, &summary.Analytics); err != nil {
+			return fmt.Errorf("invalid analytics of meeting %s: %w", summary.MeetingID, err)
+		}
+	}
+	if err := json.Unmarshal(redactions, &summary.Redactions); err != nil {
+		return fmt.Errorf("invalid redactions of meeting %s: %w", summary.MeetingID, err)
+	}
+	return nil
+}
+
+func emptyIfNil[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
@@ -1 +1,383 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
 package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tenantContext is the context of the requests of the estate e1
+var tenantContext = tenancy.NewContext(context.Background(), tenancy.Tenant{EstateID: "e1", InitiatorID: "u1"})
+
+// adminContext is the context of the requests of an admin of the estate e1
+var adminContext = tenancy.NewContext(context.Background(),
+	tenancy.Tenant{EstateID: "e1", InitiatorID: "a1", Roles: []string{tenancy.RoleAdmin}})
+
+func newMockRepository(t *testing.T) (*repository, sqlmock.Sqlmock) {
+	dbCon, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		_ = dbCon.Close()
+	})
+	return &repository{dbCon: dbCon}, mock
+}
+
+func TestCreateMeeting(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+	meeting := &dbmodels.Meeting{MeetingID: "m1", EstateID: "e1", CreatedBy: "u1", Title: "Sync", CreatedAt: now,
+		UpdatedAt: now}
+	segments := []dbmodels.TranscriptSegment{
+		{MeetingID: "m1", Seq: 0, MemberName: "Alice", Timestamp: &now, Content: "Hello"},
+		{MeetingID: "m1", Seq: 1, MemberName: "Bob", Content: "Hi"},
+	}
+	values := []dbmodels.RedactedValue{{Placeholder: "[EMAIL_1]", Category: generated.Email, Sealed: []byte("sealed"),
+		KeyID: "k1"}}
+	shares := []dbmodels.MeetingShare{{MeetingID: "m1", PrincipalType: generated.User, PrincipalID: "bob", CreatedBy: "u1",
+		CreatedAt: now}}
+	job := &dbmodels.SummaryJob{JobID: "j1", MeetingID: "m1", EstateID: "e1", CreatedBy: "u1", Status: generated.PENDING,
+		Owner: "instance-1", HeartbeatAt: now, CreatedAt: now, UpdatedAt: now}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO meetings")).
+		WithArgs("m1", "e1", "u1", "Sync", now, now).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectInFlightJobQuery)).WithArgs("m1", "e1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta(deleteSegmentsQuery)).
+		WithArgs("m1", "e1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO transcript_segments")).
+		WithArgs("m1", "e1", 0, "Alice", &now, "Hello").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO transcript_segments")).
+		WithArgs("m1", "e1", 1, "Bob", nil, "Hi").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteRedactedValuesQuery)).
+		WithArgs("m1", "e1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(insertRedactedValueQuery)).
+		WithArgs("m1", "e1", "[EMAIL_1]", generated.Email, []byte("sealed"), "k1").WillReturnResult(sqlmock.NewResult(1, 1))
+	// the meeting is shared with its participants
+	mock.ExpectExec(regexp.QuoteMeta(upsertShareQuery)).
+		WithArgs("m1", "e1", generated.User, "bob", "u1", now).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO summary_jobs")).
+		WithArgs("j1", "m1", "e1", "u1", generated.PENDING, nil, "instance-1", now, now, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.CreateMeeting(tenantContext, meeting, segments, values, shares, job))
+}
+
+func TestCreateMeeting_SummaryInProgress(t *testing.T) {
+	r, mock := newMockRepository(t)
+	meeting := &dbmodels.Meeting{MeetingID: "m1", EstateID: "e1", CreatedBy: "u1"}
+	job := &dbmodels.SummaryJob{JobID: "j2", MeetingID: "m1", EstateID: "e1", CreatedBy: "u1", Status: generated.PENDING}
+
+	// the transcript is not replaced
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO meetings")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectInFlightJobQuery)).WithArgs("m1", "e1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	assert.ErrorIs(t, r.CreateMeeting(tenantContext, meeting, nil, nil, nil, job), errorresponse.ErrSummaryInProgress)
+}
+
+func TestCreateMeeting_ConcurrentJob(t *testing.T) {
+	r, mock := newMockRepository(t)
+	meeting := &dbmodels.Meeting{MeetingID: "m1", EstateID: "e1", CreatedBy: "u1"}
+	job := &dbmodels.SummaryJob{JobID: "j2", MeetingID: "m1", EstateID: "e1", CreatedBy: "u1", Status: generated.PENDING}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO meetings")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectInFlightJobQuery)).WithArgs("m1", "e1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta(deleteSegmentsQuery)).WithArgs("m1", "e1").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(deleteRedactedValuesQuery)).WithArgs("m1", "e1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO summary_jobs")).
+		WillReturnError(&pq.Error{Code: uniqueViolationCode, Constraint: inFlightJobConstraint})
+	mock.ExpectRollback()
+
+	assert.ErrorIs(t, r.CreateMeeting(tenantContext, meeting, nil, nil, nil, job), errorresponse.ErrSummaryInProgress)
+}
+
+func TestCreateMeeting_RollbackOnError(t *testing.T) {
+	r, mock := newMockRepository(t)
+	meeting := &dbmodels.Meeting{MeetingID: "m1"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO meetings")).WillReturnError(errors.New("boom"))
+	mock.ExpectRollback()
+
+	assert.Error(t, r.CreateMeeting(tenantContext, meeting, nil, nil, nil, &dbmodels.SummaryJob{}))
+}
+
+func TestGetMeeting(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectMeetingQuery)).WithArgs("m1", "e1").
+		WillReturnRows(sqlmock.NewRows([]string{"meeting_id", "estate_id", "created_by", "title", "created_at",
+			"updated_at"}).AddRow("m1", "e1", "u1", "Sync", now, now))
+
+	meeting, err := r.GetMeeting(tenantContext, "m1")
+
+	require.NoError(t, err)
+	assert.Equal(t, &dbmodels.Meeting{MeetingID: "m1", EstateID: "e1", CreatedBy: "u1", Title: "Sync", CreatedAt: now,
+		UpdatedAt: now}, meeting)
+}
+
+func TestGetMeeting_NotFound(t *testing.T) {
+	r, mock := newMockRepository(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectMeetingQuery)).WithArgs("m1", "e1").WillReturnError(sql.ErrNoRows)
+
+	_, err := r.GetMeeting(tenantContext, "m1")
+
+	assert.ErrorIs(t, err, errorresponse.ErrMeetingIDNotFound)
+}
+
+func TestRepository_WithoutTenant(t *testing.T) {
+	r, _ := newMockRepository(t)
+	ctx := context.Background()
+
+	_, err := r.GetMeeting(ctx, "m1")
+	assert.ErrorIs(t, err, errorresponse.ErrUnauthenticated)
+	_, _, err = r.ListMeetings(ctx, &dbmodels.MeetingsQuery{Limit: 100})
+	assert.ErrorIs(t, err, errorresponse.ErrUnauthenticated)
+	_, err = r.GetLatestJob(ctx, "m1")
+	assert.ErrorIs(t, err, errorresponse.ErrUnauthenticated)
+	_, err = r.GetRedactedValues(ctx, "m1")
+	assert.ErrorIs(t, err, errorresponse.ErrUnauthenticated)
+	_, err = r.GetIdempotencyKey(ctx, "k1", time.Now())
+	assert.ErrorIs(t, err, errorresponse.ErrUnauthenticated)
+}
+
+var meetingListColumns = []string{"meeting_id", "estate_id", "created_by", "title", "created_at", "updated_at",
+	"participants", "summary_created_by", "content", "key_points", "decisions", "action_items", "analytics", "redactions", "model", "prompt_template",
+	"prompt_version", "summary_created_at", "summary_updated_at",
+	"job_id", "job_created_by", "status", "error", "job_created_at", "job_updated_at"}
+
+func TestListMeetings(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+	since := now.Add(-time.Hour)
+	errMsg := "llm unavailable"
+	query := &dbmodels.MeetingsQuery{
+		Offset: 1,
+		Limit:  2,
+		Filters: []dbmodels.Filter{
+			{Field: "title", Operator: dbmodels.OperatorIlike, Value: "%sync%"},
+			{Field: "created_at", Operator: dbmodels.OperatorGt, Value: since},
+			{Field: "participant", Operator: dbmodels.OperatorEq, Value: "Alice"},
+		},
+		Sort: []dbmodels.SortField{{Field: "title"}},
+	}
+	where := " WHERE m.estate_id = $1 AND m.title ILIKE $2 AND m.created_at > $3 AND " +
+		"EXISTS (SELECT 1 FROM transcript_segments t WHERE t.estate_id = m.estate_id AND t.meeting_id = m.meeting_id AND t.member_name = $4)"
+
+	mock.ExpectQuery(regexp.QuoteMeta(countMeetingListQuery+where)).WithArgs("e1", "%sync%", since, "Alice").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(selectMeetingListQuery+where+" ORDER BY m.title ASC, m.meeting_id ASC OFFSET $5 LIMIT $6")).
+		WithArgs("e1", "%sync%", since, "Alice", 1, 2).
+		WillReturnRows(sqlmock.NewRows(meetingListColumns).
+			AddRow("m2", "e1", "u1", "Second sync", now, now, "{Alice,Bob}", "u2", "summary", `["point"]`, `[]`, `[]`, nil,
+				`[]`, "gpt", "retrospective", 2, now, now, "j2", "u2", "DONE", nil, now, now).
+			AddRow("m3", "e1", "u1", "Third sync", now, now, "{Alice}", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+				nil, nil, "j3", "u1", "FAILED", errMsg, now, now))
+
+	items, total, err := r.ListMeetings(adminContext, query)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, items, 2)
+	assert.Equal(t, "m2", items[0].MeetingID)
+	assert.Equal(t, []string{"Alice", "Bob"}, items[0].Participants)
+	assert.Equal(t, "u1", items[0].CreatedBy)
+	assert.Equal(t, &dbmodels.Summary{MeetingID: "m2", EstateID: "e1", CreatedBy: "u2", Content: "summary", KeyPoints: []string{"point"},
+		Decisions: []string{}, ActionItems: []dbmodels.ActionItem{}, Redactions: []dbmodels.Redaction{}, Model: "gpt", PromptTemplate: "retrospective", PromptVersion: 2,
+		CreatedAt: now, UpdatedAt: now},
+		items[0].Summary)
+	assert.Equal(t, generated.DONE, items[0].Job.Status)
+	assert.Equal(t, "u2", items[0].Job.CreatedBy)
+	assert.Nil(t, items[0].Job.Error)
+	assert.Nil(t, items[1].Summary)
+	assert.Equal(t, &errMsg, items[1].Job.Error)
+}
+
+func TestListMeetings_DefaultOrder(t *testing.T) {
+	r, mock := newMockRepository(t)
+
+	where := " WHERE m.estate_id = $1"
+
+	mock.ExpectQuery(regexp.QuoteMeta(countMeetingListQuery + where)).WithArgs("e1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(selectMeetingListQuery+where+" ORDER BY m.created_at DESC, m.meeting_id ASC OFFSET $2 LIMIT $3")).
+		WithArgs("e1", 0, 100).WillReturnRows(sqlmock.NewRows(meetingListColumns))
+
+	items, total, err := r.ListMeetings(adminContext, &dbmodels.MeetingsQuery{Limit: 100})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, items)
+}
+
+func TestListMeetings_Reader(t *testing.T) {
+	r, mock := newMockRepository(t)
+	ctx := tenancy.NewContext(context.Background(),
+		tenancy.Tenant{EstateID: "e1", InitiatorID: "u1", Groups: []string{"g1", "g2"}})
+	query := &dbmodels.MeetingsQuery{
+		Limit:   100,
+		Filters: []dbmodels.Filter{{Field: "title", Operator: dbmodels.OperatorEq, Value: "Sync"}},
+	}
+
+	where := " WHERE m.estate_id = $1 AND " + fmt.Sprintf(readerCondition, 2, 3) + " AND m.title = $4"
+
+	mock.ExpectQuery(regexp.QuoteMeta(countMeetingListQuery+where)).WithArgs("e1", "u1", "{\"g1\",\"g2\"}", "Sync").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(selectMeetingListQuery+where+" ORDER BY m.created_at DESC, m.meeting_id ASC OFFSET $5 LIMIT $6")).
+		WithArgs("e1", "u1", "{\"g1\",\"g2\"}", "Sync", 0, 100).WillReturnRows(sqlmock.NewRows(meetingListColumns))
+
+	_, total, err := r.ListMeetings(ctx, query)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
+
+func TestListMeetings_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *dbmodels.MeetingsQuery
+		err   error
+	}{
+		{
+			name:  "UnknownFilterField",
+			query: &dbmodels.MeetingsQuery{Filters: []dbmodels.Filter{{Field: "content", Operator: dbmodels.OperatorEq}}},
+			err:   errorresponse.ErrInvalidFilterField,
+		},
+		{
+			name:  "UnknownOperator",
+			query: &dbmodels.MeetingsQuery{Filters: []dbmodels.Filter{{Field: "title", Operator: "in"}}},
+			err:   errorresponse.ErrInvalidFilterOperator,
+		},
+		{
+			name:  "UnknownSortField",
+			query: &dbmodels.MeetingsQuery{Sort: []dbmodels.SortField{{Field: "content"}}},
+			err:   errorresponse.ErrInvalidSortField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newMockRepository(t)
+
+			_, _, err := r.ListMeetings(tenantContext, tt.query)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestDeleteMeeting(t *testing.T) {
+	r, mock := newMockRepository(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(deleteMeetingQuery)).WithArgs("m1", "e1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteMeetingQuery)).WithArgs("m2", "e1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, r.DeleteMeeting(tenantContext, "m1"))
+	assert.ErrorIs(t, r.DeleteMeeting(tenantContext, "m2"), errorresponse.ErrMeetingIDNotFound)
+}
+
+func TestGetTranscriptSegments(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectSegmentsQuery)).WithArgs("m1", "e1").
+		WillReturnRows(sqlmock.NewRows([]string{"meeting_id", "seq", "member_name", "timestamp", "content"}).
+			AddRow("m1", 0, "Alice", now, "Hello").
+			AddRow("m1", 1, "Bob", nil, "Hi"))
+
+	segments, err := r.GetTranscriptSegments(tenantContext, "m1")
+
+	require.NoError(t, err)
+	require.Len(t, segments, 2)
+	assert.Equal(t, now, *segments[0].Timestamp)
+	assert.Nil(t, segments[1].Timestamp)
+}
+
+func TestSummary(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+	owner := "Alice"
+	summary := &dbmodels.Summary{
+		MeetingID:   "m1",
+		EstateID:    "e1",
+		CreatedBy:   "u1",
+		Content:     "text",
+		KeyPoints:   []string{"point"},
+		Decisions:   []string{},
+		ActionItems: []dbmodels.ActionItem{{Description: "ship", Owner: &owner}},
+		Analytics: &generated.MeetingAnalytics{
+			Timed:         true,
+			Speakers:      []generated.SpeakerAnalytics{{MemberName: "Alice", TalkShare: 1, WordCount: 3, TurnCount: 1}},
+			Interruptions: []generated.Interruption{},
+			SilenceGaps:   []generated.SilenceGap{},
+		},
+		Redactions:     []dbmodels.Redaction{{Placeholder: "[EMAIL_1]", Category: generated.Email}},
+		Model:          "model",
+		PromptTemplate: "executive_brief",
+		PromptVersion:  1,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	actionItems := `[{"description":"ship","owner":"Alice"}]`
+	redactions := `[{"placeholder":"[EMAIL_1]","category":"email"}]`
+	analytics, err := json.Marshal(summary.Analytics)
+	require.NoError(t, err)
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO summaries")).
+		WithArgs("m1", "e1", "u1", "text", []byte(`["point"]`), []byte(`[]`), []byte(actionItems), analytics,
+			[]byte(redactions), "model", "executive_brief", 1, now, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectSummaryQuery)).WithArgs("m1", "e1").
+		WillReturnRows(sqlmock.NewRows([]string{"meeting_id", "estate_id", "created_by", "content", "key_points",
+			"decisions", "action_items", "analytics", "redactions", "model", "prompt_template", "prompt_version",
+			"created_at", "updated_at"}).
+			AddRow("m1", "e1", "u1", "text", []byte(`["point"]`), []byte(`[]`), []byte(actionItems), analytics,
+				[]byte(redactions), "model", "executive_brief", 1, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta(selectSummaryQuery)).WithArgs("m2", "e1").WillReturnError(sql.ErrNoRows)
+
+	require.NoError(t, r.UpsertSummary(tenantContext, summary))
+	stored, err := r.GetSummary(tenantContext, "m1")
+	require.NoError(t, err)
+	assert.Equal(t, summary, stored)
+	_, err = r.GetSummary(tenantContext, "m2")
+	assert.ErrorIs(t, err, errorresponse.ErrSummaryNotFound)
+}
+
+func TestUpsertSummary_NilFields(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO summaries")).
+		WithArgs("m1", "e1", "u1", "text", []byte(`[]`), []byte(`[]`), []byte(`[]`), nil, []byte(`[]`), "model", "default",
+			1, now, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	assert.NoError(t, r.UpsertSummary(tenantContext,
+		&dbmodels.Summary{MeetingID: "m1", EstateID: "e1", CreatedBy: "u1", Content: "text", Model: "model", PromptTemplate: "default", PromptVersion: 1,
+			CreatedAt: now, UpdatedAt: now}))
+}
@@ -0,0 +1,90 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+)
+
+const (
+	selectSharesQuery = `SELECT meeting_id, principal_type, principal_id, created_by, created_at FROM meeting_shares
+		WHERE meeting_id = $1 AND estate_id = $2
+		ORDER BY created_at, principal_type, principal_id`
+	// the share is only inserted when the meeting belongs to the estate, an existing share keeps its creator
+	upsertShareQuery = `INSERT INTO meeting_shares (meeting_id, estate_id, principal_type, principal_id, created_by,
+		created_at)
+		SELECT meeting_id, estate_id, CAST($3 AS principal_type_enum), $4, $5, $6 FROM meetings
+		WHERE meeting_id = $1 AND estate_id = $2
+		ON CONFLICT (estate_id, meeting_id, principal_type, principal_id)
+		DO UPDATE SET created_by = meeting_shares.created_by
+		RETURNING created_by, created_at`
+	deleteShareQuery = `DELETE FROM meeting_shares
+		WHERE meeting_id = $1 AND estate_id = $2 AND principal_type = $3 AND principal_id = $4`
+)
+
+func (r *repository) ListShares(ctx context.Context, meetingID string) ([]dbmodels.MeetingShare, error) {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.dbCon.QueryContext(ctx, selectSharesQuery, meetingID, estateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select shares of meeting %s: %w", meetingID, err)
+	}
+	defer rows.Close()
+
+	shares := make([]dbmodels.MeetingShare, 0)
+	for rows.Next() {
+		var share dbmodels.MeetingShare
+		if err = rows.Scan(&share.MeetingID, &share.PrincipalType, &share.PrincipalID, &share.CreatedBy,
+			&share.CreatedAt); err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, rows.Err()
+}
+
+// SaveShare stores the share, or reads back the creator and creation time of the existing share of the principal
+func (r *repository) SaveShare(ctx context.Context, share *dbmodels.MeetingShare) error {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return err
+	}
+	err = r.dbCon.QueryRowContext(ctx, upsertShareQuery, share.MeetingID, estateID, share.PrincipalType,
+		share.PrincipalID, share.CreatedBy, share.CreatedAt).Scan(&share.CreatedBy, &share.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errorresponse.WithArgs(errorresponse.ErrMeetingIDNotFound, share.MeetingID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to share meeting %s with %s %s: %w", share.MeetingID, share.PrincipalType,
+			share.PrincipalID, err)
+	}
+	return nil
+}
+
+func (r *repository) DeleteShare(ctx context.Context, meetingID string, principalType generated.PrincipalTypeEnum,
+	principalID string) error {
+	estateID, err := estateOf(ctx)
+	if err != nil {
+		return err
+	}
+	res, err := r.dbCon.ExecContext(ctx, deleteShareQuery, meetingID, estateID, principalType, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to unshare meeting %s: %w", meetingID, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errorresponse.WithArgs(errorresponse.ErrShareNotFound, meetingID, principalID)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package db
+
+import (
+	"database/sql"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListShares(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectSharesQuery)).WithArgs("m1", "e1").
+		WillReturnRows(sqlmock.NewRows([]string{"meeting_id", "principal_type", "principal_id", "created_by", "created_at"}).
+			AddRow("m1", "user", "u2", "u1", now).
+			AddRow("m1", "group", "g1", "u1", now))
+
+	shares, err := r.ListShares(tenantContext, "m1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []dbmodels.MeetingShare{
+		{MeetingID: "m1", PrincipalType: generated.User, PrincipalID: "u2", CreatedBy: "u1", CreatedAt: now},
+		{MeetingID: "m1", PrincipalType: generated.Group, PrincipalID: "g1", CreatedBy: "u1", CreatedAt: now},
+	}, shares)
+}
+
+func TestSaveShare(t *testing.T) {
+	r, mock := newMockRepository(t)
+	now := time.Now()
+	sharedAt := now.Add(-time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta(upsertShareQuery)).WithArgs("m1", "e1", generated.User, "u2", "u1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"created_by", "created_at"}).AddRow("u3", sharedAt))
+	mock.ExpectQuery(regexp.QuoteMeta(upsertShareQuery)).WithArgs("m2", "e1", generated.Group, "g1", "u1", now).
+		WillReturnError(sql.ErrNoRows)
+
+	share := &dbmodels.MeetingShare{MeetingID: "m1", PrincipalType: generated.User, PrincipalID: "u2", CreatedBy: "u1",
+		CreatedAt: now}
+	require.NoError(t, r.SaveShare(tenantContext, share))
+	assert.Equal(t, "u3", share.CreatedBy)
+	assert.Equal(t, sharedAt, share.CreatedAt)
+
+	err := r.SaveShare(tenantContext, &dbmodels.MeetingShare{MeetingID: "m2", PrincipalType: generated.Group,
+		PrincipalID: "g1", CreatedBy: "u1", CreatedAt: now})
+	assert.ErrorIs(t, err, errorresponse.ErrMeetingIDNotFound)
+}
+
+func TestDeleteShare(t *testing.T) {
+	r, mock := newMockRepository(t)
+
+	mock.ExpectExec(regexp.QuoteMeta(deleteShareQuery)).WithArgs("m1", "e1", generated.User, "u2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteShareQuery)).WithArgs("m1", "e1", generated.Group, "g1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, r.DeleteShare(tenantContext, "m1", generated.User, "u2"))
+	assert.ErrorIs(t, r.DeleteShare(tenantContext, "m1", generated.Group, "g1"), errorresponse.ErrShareNotFound)
+}
@@ -2,5 +2,71 @@
 
 package repositories
 
+import (
+	"context"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models/dbmodels"
+	"time"
+)
+
+// Repository stores the meetings of the estates. The reads and updates only see the rows of the estate of the tenant
+// of their context, see tenancy.FromContext, and fail with ErrUnauthenticated without one. The rows are stored with
+// the estate they are stamped with.
 type Repository interface {
+	// CreateMeeting stores the meeting, replaces its transcript segments and the values redacted from them, shares it
+	// with its participants and stores the job summarizing it, in one transaction. The existing shares are kept. It
+	// fails with ErrSummaryInProgress, before the meeting is changed, when the meeting already has an unfinished job.
+	// Each estate has its own meeting ids.
+	CreateMeeting(ctx context.Context, meeting *dbmodels.Meeting, segments []dbmodels.TranscriptSegment,
+		values []dbmodels.RedactedValue, shares []dbmodels.MeetingShare, job *dbmodels.SummaryJob) error
+	GetMeeting(ctx context.Context, meetingID string) (*dbmodels.Meeting, error)
+	// GetRedactedValues returns the values redacted from the title and transcript of the meeting, in order of
+	// redaction
+	GetRedactedValues(ctx context.Context, meetingID string) ([]dbmodels.RedactedValue, error)
+	// ListUnredactedMeetings returns up to limit meetings, of all the estates, whose title and transcript were stored
+	// before they were redacted, in order of estate and meeting id, after the meeting afterMeetingID of afterEstateID.
+	// Empty ids list the meetings from the first one.
+	ListUnredactedMeetings(ctx context.Context, afterEstateID, afterMeetingID string, limit int) ([]dbmodels.Meeting, error)
+	// RedactTranscript replaces the title and transcript segments of the meeting, stored before they were redacted,
+	// by their redacted copies and adds the values redacted from them, in one transaction. The meeting is left
+	// untouched when it was redacted meanwhile.
+	RedactTranscript(ctx context.Context, meeting *dbmodels.Meeting, segments []dbmodels.TranscriptSegment,
+		values []dbmodels.RedactedValue) error
+	// ListMeetings returns a page of the meetings matching the query and the total count of matching meetings. Only
+	// the meetings the initiator may read are listed, all the meetings of the estate for its admins.
+	ListMeetings(ctx context.Context, query *dbmodels.MeetingsQuery) ([]dbmodels.MeetingListItem, int, error)
+	// DeleteMeeting removes the meeting together with its transcript, summary and shares
+	DeleteMeeting(ctx context.Context, meetingID string) error
+	GetTranscriptSegments(ctx context.Context, meetingID string) ([]dbmodels.TranscriptSegment, error)
+	UpsertSummary(ctx context.Context, summary *dbmodels.Summary) error
+	GetSummary(ctx context.Context, meetingID string) (*dbmodels.Summary, error)
+	// ReplaceActionItems replaces the action items extracted from the summary of the meeting
+	ReplaceActionItems(ctx context.Context, meetingID string, items []dbmodels.MeetingActionItem) error
+	// ListActionItems returns the action items of the meeting in extraction order, joined with their source segment
+	ListActionItems(ctx context.Context, meetingID string) ([]dbmodels.MeetingActionItem, error)
+	// SetActionItemDone marks the action item of the meeting as done, or open again, and returns the updated item
+	SetActionItemDone(ctx context.Context, meetingID string, itemID string, done bool) (*dbmodels.MeetingActionItem, error)
+	// ListShares returns the users and groups the meeting is shared with, in order of sharing
+	ListShares(ctx context.Context, meetingID string) ([]dbmodels.MeetingShare, error)
+	// SaveShare shares the meeting with the principal of the share. Sharing it again keeps the existing share, whose
+	// creator and creation time are set in share. It fails with ErrMeetingIDNotFound when the meeting is not found.
+	SaveShare(ctx context.Context, share *dbmodels.MeetingShare) error
+	// DeleteShare stops sharing the meeting with the principal, it fails with ErrShareNotFound when it was not shared
+	DeleteShare(ctx context.Context, meetingID string, principalType generated.PrincipalTypeEnum, principalID string) error
+	GetJob(ctx context.Context, jobID string) (*dbmodels.SummaryJob, error)
+	// GetLatestJob returns the most recently created job of the meeting
+	GetLatestJob(ctx context.Context, meetingID string) (*dbmodels.SummaryJob, error)
+	// UpdateJobStatus moves the job to the given status, errMsg is stored for failed jobs
+	UpdateJobStatus(ctx context.Context, jobID string, status generated.JobStatusEnum, errMsg *string) error
+	// RenewJobLeases renews the leases of the unfinished jobs of the owner until now
+	RenewJobLeases(ctx context.Context, owner string, now time.Time) error
+	// FailInterruptedJobs marks the unfinished jobs of the owner, left by its previous run, and the jobs whose lease
+	// was last renewed before expiredBefore, whose instance stopped, as failed, in all estates. An empty owner only
+	// fails the jobs whose lease expired.
+	FailInterruptedJobs(ctx context.Context, owner string, expiredBefore time.Time, errMsg string) (int, error)
+	// GetIdempotencyKey returns the response stored for the key since createdAfter, nil when there is none
+	GetIdempotencyKey(ctx context.Context, key string, createdAfter time.Time) (*dbmodels.IdempotencyKey, error)
+	// SaveIdempotencyKey stores the response of the key, replacing an expired one, and removes the keys created
+	// before expiredBefore
+	SaveIdempotencyKey(ctx context.Context, key *dbmodels.IdempotencyKey, expiredBefore time.Time) error
 }
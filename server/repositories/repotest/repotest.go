@@ -0,0 +1,487 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package repotest provides an in-memory repositories.Repository, so that the services and the whole API can be
+// tested without a database. Like the database repository, it only reads the rows of the estate of the tenant of the
+// contexts, lists the meetings its initiator may read, and returns the catalog errors with their arguments. The
+// meetings are listed by creation time, newest first, and filters are not supported.
+package repotest
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"meeting-analyzer/server/services/access"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Key identifies a meeting, each estate has its own meeting ids
+type Key struct {
+	EstateID  string
+	MeetingID string
+}
+
+// Repository stores the rows in memory, the rows of the meetings by their Key. The tests may seed and inspect the maps
+// directly while no request is running.
+type Repository struct {
+	mu          sync.Mutex
+	Meetings    map[Key]dbmodels.Meeting
+	Segments    map[Key][]dbmodels.TranscriptSegment
+	Summaries   map[Key]dbmodels.Summary
+	ActionItems map[Key][]dbmodels.MeetingActionItem
+	Jobs        map[string]dbmodels.SummaryJob
+	Shares      map[Key][]dbmodels.MeetingShare
+	Redactions  map[Key][]dbmodels.RedactedValue
+	// Unredacted marks the meetings whose title and transcript were stored before they were redacted, the meetings
+	// seeded by the tests are redacted unless they are marked
+	Unredacted map[Key]bool
+	// Keys are stored by estate and key
+	Keys map[[2]string]dbmodels.IdempotencyKey
+	// Err fails the creation of the meetings when set, like an unavailable database
+	Err error
+}
+
+// NewRepository returns an empty repository
+func NewRepository() *Repository {
+	return &Repository{
+		Meetings:    map[Key]dbmodels.Meeting{},
+		Segments:    map[Key][]dbmodels.TranscriptSegment{},
+		Summaries:   map[Key]dbmodels.Summary{},
+		ActionItems: map[Key][]dbmodels.MeetingActionItem{},
+		Jobs:        map[string]dbmodels.SummaryJob{},
+		Shares:      map[Key][]dbmodels.MeetingShare{},
+		Redactions:  map[Key][]dbmodels.RedactedValue{},
+		Unredacted:  map[Key]bool{},
+		Keys:        map[[2]string]dbmodels.IdempotencyKey{},
+	}
+}
+
+func (r *Repository) CreateMeeting(_ context.Context, meeting *dbmodels.Meeting, segments []dbmodels.TranscriptSegment,
+	values []dbmodels.RedactedValue, shares []dbmodels.MeetingShare, job *dbmodels.SummaryJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Err != nil {
+		return r.Err
+	}
+	key := Key{EstateID: meeting.EstateID, MeetingID: meeting.MeetingID}
+	if existing, ok := r.Meetings[key]; ok {
+		meeting.CreatedAt, meeting.CreatedBy = existing.CreatedAt, existing.CreatedBy
+	}
+	for _, existing := range r.Jobs {
+		if jobKey(existing) == key && inFlight(existing) {
+			return errorresponse.WithArgs(errorresponse.ErrSummaryInProgress, meeting.MeetingID)
+		}
+	}
+	r.Meetings[key] = *meeting
+	r.Segments[key] = slices.Clone(segments)
+	r.Redactions[key] = slices.Clone(values)
+	delete(r.Unredacted, key)
+	for _, share := range shares {
+		if !slices.ContainsFunc(r.Shares[key], func(existing dbmodels.MeetingShare) bool {
+			return existing.PrincipalType == share.PrincipalType && existing.PrincipalID == share.PrincipalID
+		}) {
+			r.Shares[key] = append(r.Shares[key], share)
+		}
+	}
+	r.Jobs[job.JobID] = *job
+	return nil
+}
+
+func (r *Repository) GetMeeting(ctx context.Context, meetingID string) (*dbmodels.Meeting, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	meeting, ok := r.Meetings[keyOf(ctx, meetingID)]
+	if !ok {
+		return nil, errorresponse.WithArgs(errorresponse.ErrMeetingIDNotFound, meetingID)
+	}
+	return &meeting, nil
+}
+
+func (r *Repository) ListMeetings(ctx context.Context, query *dbmodels.MeetingsQuery) ([]dbmodels.MeetingListItem, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tenant, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return nil, 0, errorresponse.ErrUnauthenticated
+	}
+	if len(query.Filters) > 0 || len(query.Sort) > 0 {
+		return nil, 0, errors.New("the in-memory repository does not filter or sort meetings")
+	}
+	items := make([]dbmodels.MeetingListItem, 0, len(r.Meetings))
+	for key, meeting := range r.Meetings {
+		acl := access.ACL{Owner: meeting.CreatedBy, Shares: r.Shares[key]}
+		if key.EstateID != tenant.EstateID || !access.Allowed(tenant, acl, access.ActionRead) {
+			continue
+		}
+		item := dbmodels.MeetingListItem{Meeting: meeting, Participants: participants(r.Segments[key]),
+			Job: r.latestJob(key)}
+		if summary, ok := r.Summaries[key]; ok {
+			item.Summary = &summary
+		}
+		items = append(items, item)
+	}
+	slices.SortFunc(items, func(a, b dbmodels.MeetingListItem) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		if a.MeetingID < b.MeetingID {
+			return -1
+		}
+		return 1
+	})
+	total := len(items)
+	offset := min(query.Offset, total)
+	return items[offset:min(offset+query.Limit, total)], total, nil
+}
+
+func (r *Repository) DeleteMeeting(ctx context.Context, meetingID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := keyOf(ctx, meetingID)
+	if _, ok := r.Meetings[key]; !ok {
+		return errorresponse.WithArgs(errorresponse.ErrMeetingIDNotFound, meetingID)
+	}
+	delete(r.Meetings, key)
+	delete(r.Segments, key)
+	delete(r.Summaries, key)
+	delete(r.ActionItems, key)
+	delete(r.Shares, key)
+	delete(r.Redactions, key)
+	delete(r.Unredacted, key)
+	for id, job := range r.Jobs {
+		if jobKey(job) == key {
+			delete(r.Jobs, id)
+		}
+	}
+	return nil
+}
+
+func (r *Repository) GetTranscriptSegments(ctx context.Context, meetingID string) ([]dbmodels.TranscriptSegment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	segments := slices.Clone(r.Segments[keyOf(ctx, meetingID)])
+	if segments == nil {
+		return []dbmodels.TranscriptSegment{}, nil
+	}
+	return segments, nil
+}
+
+func (r *Repository) GetRedactedValues(ctx context.Context, meetingID string) ([]dbmodels.RedactedValue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	values := slices.Clone(r.Redactions[keyOf(ctx, meetingID)])
+	if values == nil {
+		return []dbmodels.RedactedValue{}, nil
+	}
+	return values, nil
+}
+
+func (r *Repository) ListUnredactedMeetings(_ context.Context, afterEstateID, afterMeetingID string,
+	limit int) ([]dbmodels.Meeting, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := make([]Key, 0, len(r.Unredacted))
+	for key := range r.Unredacted {
+		if _, ok := r.Meetings[key]; ok && cmp.Or(cmp.Compare(key.EstateID, afterEstateID),
+			cmp.Compare(key.MeetingID, afterMeetingID)) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b Key) int {
+		return cmp.Or(cmp.Compare(a.EstateID, b.EstateID), cmp.Compare(a.MeetingID, b.MeetingID))
+	})
+	meetings := make([]dbmodels.Meeting, 0, min(limit, len(keys)))
+	for _, key := range keys[:min(limit, len(keys))] {
+		meetings = append(meetings, r.Meetings[key])
+	}
+	return meetings, nil
+}
+
+func (r *Repository) RedactTranscript(ctx context.Context, meeting *dbmodels.Meeting,
+	segments []dbmodels.TranscriptSegment, values []dbmodels.RedactedValue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := keyOf(ctx, meeting.MeetingID)
+	stored, ok := r.Meetings[key]
+	if !ok || !r.Unredacted[key] {
+		return nil
+	}
+	delete(r.Unredacted, key)
+	stored.Title = meeting.Title
+	r.Meetings[key] = stored
+	r.Segments[key] = slices.Clone(segments)
+	r.Redactions[key] = append(r.Redactions[key], values...)
+	return nil
+}
+
+func (r *Repository) UpsertSummary(_ context.Context, summary *dbmodels.Summary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Summaries[Key{EstateID: summary.EstateID, MeetingID: summary.MeetingID}] = *summary
+	return nil
+}
+
+func (r *Repository) GetSummary(ctx context.Context, meetingID string) (*dbmodels.Summary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	summary, ok := r.Summaries[keyOf(ctx, meetingID)]
+	if !ok {
+		return nil, errorresponse.WithArgs(errorresponse.ErrSummaryNotFound, meetingID)
+	}
+	return &summary, nil
+}
+
+func (r *Repository) ReplaceActionItems(ctx context.Context, meetingID string, items []dbmodels.MeetingActionItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := keyOf(ctx, meetingID)
+	// like the database repository, the items done before keep their status
+	doneAt := make(map[string]*time.Time)
+	for _, item := range r.ActionItems[key] {
+		if item.Done {
+			doneAt[item.ItemID] = item.DoneAt
+			doneAt[strings.ToLower(item.Description)] = item.DoneAt
+		}
+	}
+	items = slices.Clone(items)
+	for i, item := range items {
+		at, ok := doneAt[item.ItemID]
+		if !ok {
+			at, ok = doneAt[strings.ToLower(item.Description)]
+		}
+		if ok {
+			items[i].Done, items[i].DoneAt = true, at
+		}
+	}
+	r.ActionItems[key] = items
+	return nil
+}
+
+func (r *Repository) ListActionItems(ctx context.Context, meetingID string) ([]dbmodels.MeetingActionItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := keyOf(ctx, meetingID)
+	items := slices.Clone(r.ActionItems[key])
+	if items == nil {
+		return []dbmodels.MeetingActionItem{}, nil
+	}
+	for i, item := range items {
+		if item.SegmentSeq == nil {
+			continue
+		}
+		for _, segment := range r.Segments[key] {
+			if segment.Seq == *item.SegmentSeq {
+				items[i].Segment = &segment
+			}
+		}
+	}
+	return items, nil
+}
+
+func (r *Repository) SetActionItemDone(ctx context.Context, meetingID string, itemID string, done bool) (*dbmodels.MeetingActionItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := keyOf(ctx, meetingID)
+	for i, item := range r.ActionItems[key] {
+		if item.ItemID == itemID {
+			now := time.Now().UTC()
+			item.Done, item.DoneAt, item.UpdatedAt = done, nil, now
+			if done {
+				item.DoneAt = &now
+			}
+			r.ActionItems[key][i] = item
+			return &item, nil
+		}
+	}
+	return nil, errorresponse.WithArgs(errorresponse.ErrActionItemNotFound, meetingID, itemID)
+}
+
+func (r *Repository) ListShares(ctx context.Context, meetingID string) ([]dbmodels.MeetingShare, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shares := slices.Clone(r.Shares[keyOf(ctx, meetingID)])
+	if shares == nil {
+		return []dbmodels.MeetingShare{}, nil
+	}
+	return shares, nil
+}
+
+func (r *Repository) SaveShare(ctx context.Context, share *dbmodels.MeetingShare) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := keyOf(ctx, share.MeetingID)
+	if _, ok := r.Meetings[key]; !ok {
+		return errorresponse.WithArgs(errorresponse.ErrMeetingIDNotFound, share.MeetingID)
+	}
+	for _, existing := range r.Shares[key] {
+		if existing.PrincipalType == share.PrincipalType && existing.PrincipalID == share.PrincipalID {
+			*share = existing
+			return nil
+		}
+	}
+	r.Shares[key] = append(r.Shares[key], *share)
+	return nil
+}
+
+func (r *Repository) DeleteShare(ctx context.Context, meetingID string, principalType generated.PrincipalTypeEnum, principalID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := keyOf(ctx, meetingID)
+	for i, share := range r.Shares[key] {
+		if share.PrincipalType == principalType && share.PrincipalID == principalID {
+			r.Shares[key] = slices.Delete(r.Shares[key], i, i+1)
+			return nil
+		}
+	}
+	return errorresponse.WithArgs(errorresponse.ErrShareNotFound, meetingID, principalID)
+}
+
+func (r *Repository) GetJob(ctx context.Context, jobID string) (*dbmodels.SummaryJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.Jobs[jobID]
+	if !ok || job.EstateID != estateOf(ctx) {
+		return nil, errorresponse.WithArgs(errorresponse.ErrJobIDNotFound, jobID)
+	}
+	return &job, nil
+}
+
+func (r *Repository) GetLatestJob(ctx context.Context, meetingID string) (*dbmodels.SummaryJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	latest := r.latestJob(keyOf(ctx, meetingID))
+	if latest == nil {
+		return nil, errorresponse.ErrJobIDNotFound
+	}
+	return latest, nil
+}
+
+func (r *Repository) UpdateJobStatus(ctx context.Context, jobID string, status generated.JobStatusEnum, errMsg *string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.Jobs[jobID]
+	if !ok || job.EstateID != estateOf(ctx) {
+		return errorresponse.WithArgs(errorresponse.ErrJobIDNotFound, jobID)
+	}
+	job.Status = status
+	job.Error = errMsg
+	job.UpdatedAt = time.Now().UTC()
+	r.Jobs[jobID] = job
+	return nil
+}
+
+func (r *Repository) RenewJobLeases(_ context.Context, owner string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, job := range r.Jobs {
+		if job.Owner == owner && inFlight(job) {
+			job.HeartbeatAt = now
+			r.Jobs[id] = job
+		}
+	}
+	return nil
+}
+
+func (r *Repository) FailInterruptedJobs(_ context.Context, owner string, expiredBefore time.Time, errMsg string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	for id, job := range r.Jobs {
+		if inFlight(job) && ((owner != "" && job.Owner == owner) || job.HeartbeatAt.Before(expiredBefore)) {
+			job.Status = generated.FAILED
+			job.Error = &errMsg
+			r.Jobs[id] = job
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *Repository) GetIdempotencyKey(ctx context.Context, key string, createdAfter time.Time) (*dbmodels.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.Keys[[2]string{estateOf(ctx), key}]
+	if !ok || !stored.CreatedAt.After(createdAfter) {
+		return nil, nil
+	}
+	return &stored, nil
+}
+
+func (r *Repository) SaveIdempotencyKey(_ context.Context, key *dbmodels.IdempotencyKey, expiredBefore time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, stored := range r.Keys {
+		if stored.CreatedAt.Before(expiredBefore) {
+			delete(r.Keys, k)
+		}
+	}
+	r.Keys[[2]string{key.EstateID, key.Key}] = *key
+	return nil
+}
+
+// JobCount returns the number of jobs, while they may be running
+func (r *Repository) JobCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.Jobs)
+}
+
+// JobStatus returns the status of the job, while it may be running
+func (r *Repository) JobStatus(jobID string) generated.JobStatusEnum {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.Jobs[jobID].Status
+}
+
+// Job returns a copy of the job, while it may be running
+func (r *Repository) Job(jobID string) dbmodels.SummaryJob {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.Jobs[jobID]
+}
+
+func (r *Repository) latestJob(key Key) *dbmodels.SummaryJob {
+	var latest *dbmodels.SummaryJob
+	for _, job := range r.Jobs {
+		if jobKey(job) == key && (latest == nil || job.CreatedAt.After(latest.CreatedAt)) {
+			latest = &job
+		}
+	}
+	return latest
+}
+
+// keyOf returns the key of the meeting in the estate of the tenant of ctx
+func keyOf(ctx context.Context, meetingID string) Key {
+	return Key{EstateID: estateOf(ctx), MeetingID: meetingID}
+}
+
+func jobKey(job dbmodels.SummaryJob) Key {
+	return Key{EstateID: job.EstateID, MeetingID: job.MeetingID}
+}
+
+func inFlight(job dbmodels.SummaryJob) bool {
+	return job.Status == generated.PENDING || job.Status == generated.INPROGRESS
+}
+
+// estateOf returns the estate of the tenant of ctx, empty without tenant so that no meeting matches
+func estateOf(ctx context.Context) string {
+	tenant, _ := tenancy.FromContext(ctx)
+	return tenant.EstateID
+}
+
+// participants returns the distinct speakers of the segments in order of first appearance
+func participants(segments []dbmodels.TranscriptSegment) []string {
+	names := make([]string, 0)
+	for _, segment := range segments {
+		if segment.MemberName != "" && !slices.Contains(names, segment.MemberName) {
+			names = append(names, segment.MemberName)
+		}
+	}
+	return names
+}
@@ -0,0 +1,56 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package access decides what the initiators may do on the meetings of their estate
+package access
+
+import (
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models/dbmodels"
+	"slices"
+)
+
+// Action is what an initiator does on a meeting
+type Action string
+
+// actions on the meetings
+const (
+	// ActionRead reads the summary, analytics and action items of the meeting
+	ActionRead Action = "read"
+	// ActionUpdate marks the action items of the meeting as done or open
+	ActionUpdate Action = "update"
+	// ActionReveal reads the personal data and secrets redacted from the summary of the meeting
+	ActionReveal Action = "reveal"
+	// ActionManage regenerates the summary of the meeting, deletes the meeting and manages its shares
+	ActionManage Action = "manage"
+)
+
+// ACL is who may access a meeting besides the admins of its estate
+type ACL struct {
+	// Owner is the initiator which created the meeting
+	Owner  string
+	Shares []dbmodels.MeetingShare
+}
+
+// Allowed tells whether the initiator of the tenant may do the action on the meeting of the ACL. The admins of the
+// estate and the owner may do everything, and the users and groups the meeting is shared with only read it. The
+// participants read it through the shares stored with the meeting for the initiators of its speakers, their member
+// name, which the uploader chooses, grants nothing.
+func Allowed(tenant tenancy.Tenant, acl ACL, action Action) bool {
+	if tenant.IsAdmin() || (tenant.InitiatorID != "" && tenant.InitiatorID == acl.Owner) {
+		return true
+	}
+	return action == ActionRead && shared(tenant, acl)
+}
+
+func shared(tenant tenancy.Tenant, acl ACL) bool {
+	return slices.ContainsFunc(acl.Shares, func(share dbmodels.MeetingShare) bool {
+		switch share.PrincipalType {
+		case generated.User:
+			return tenant.InitiatorID != "" && share.PrincipalID == tenant.InitiatorID
+		case generated.Group:
+			return slices.Contains(tenant.Groups, share.PrincipalID)
+		}
+		return false
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package access
+
+import (
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models/dbmodels"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowed(t *testing.T) {
+	acl := ACL{
+		Owner: "owner",
+		Shares: []dbmodels.MeetingShare{
+			{PrincipalType: generated.User, PrincipalID: "reader"},
+			{PrincipalType: generated.Group, PrincipalID: "team"},
+		},
+	}
+	tests := []struct {
+		name     string
+		tenant   tenancy.Tenant
+		expected map[Action]bool
+	}{
+		{name: "Owner", tenant: tenancy.Tenant{InitiatorID: "owner"},
+			expected: map[Action]bool{ActionRead: true, ActionUpdate: true, ActionReveal: true, ActionManage: true}},
+		{name: "Admin", tenant: tenancy.Tenant{InitiatorID: "u1", Roles: []string{tenancy.RoleAdmin}},
+			expected: map[Action]bool{ActionRead: true, ActionUpdate: true, ActionReveal: true, ActionManage: true}},
+		{name: "SharedUser", tenant: tenancy.Tenant{InitiatorID: "reader"},
+			expected: map[Action]bool{ActionRead: true}},
+		{name: "SharedGroup", tenant: tenancy.Tenant{InitiatorID: "u1", Groups: []string{"other", "team"}},
+			expected: map[Action]bool{ActionRead: true}},
+		{name: "GroupNamedLikeUser", tenant: tenancy.Tenant{InitiatorID: "u1", Groups: []string{"reader"}},
+			expected: map[Action]bool{}},
+		{name: "Stranger", tenant: tenancy.Tenant{InitiatorID: "u1", Roles: []string{"auditor"}},
+			expected: map[Action]bool{}},
+		{name: "NoInitiator", tenant: tenancy.Tenant{},
+			expected: map[Action]bool{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, action := range []Action{ActionRead, ActionUpdate, ActionReveal, ActionManage} {
+				assert.Equal(t, tt.expected[action], Allowed(tt.tenant, acl, action), action)
+			}
+		})
+	}
+}
+
+func TestAllowed_NoOwner(t *testing.T) {
+	// the meetings created before the initiators were stamped are only managed by the admins
+	assert.False(t, Allowed(tenancy.Tenant{}, ACL{}, ActionManage))
+	assert.True(t, Allowed(tenancy.Tenant{Roles: []string{tenancy.RoleAdmin}}, ACL{}, ActionManage))
+}
@@ -0,0 +1,201 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package actionitems resolves the action items of a summary against the transcript of the meeting:
+// owners are matched to speakers, commitments to the segment where they were made and deadlines to dates
+package actionitems
+
+import (
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/services/summary"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// minSegmentScore is the share of the words of a quote which must appear in a segment to reference it
+const minSegmentScore = 0.6
+
+// itemIDNamespace is the namespace of the name-based UUIDs of the action items
+var itemIDNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("meeting-analyzer/action-items"))
+
+// Extract converts the action items of the summary of the meeting of the estate to tracked items. The due phrase is
+// resolved relative to the time of the source segment, or of the meeting start when the segment is unknown, and the
+// due date given by the model is only kept when the phrase cannot be resolved. The ids of the items are derived from
+// their content, see itemID, so that they are kept when the summary is regenerated.
+func Extract(estateID, meetingID string, items []summary.ActionItem, segments []dbmodels.TranscriptSegment,
+	now time.Time) []dbmodels.MeetingActionItem {
+	speakers := speakers(segments)
+	start := meetingStart(segments, now)
+	tracked := make([]dbmodels.MeetingActionItem, 0, len(items))
+	occurrences := make(map[itemSource]int, len(items))
+	for i, item := range items {
+		res := dbmodels.MeetingActionItem{
+			MeetingID:   meetingID,
+			Seq:         i,
+			Description: item.Description,
+			DuePhrase:   item.DuePhrase,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if item.Owner != nil {
+			res.Owner = MatchOwner(*item.Owner, speakers)
+		}
+
+		reference := start
+		quote := item.Description
+		if item.Quote != nil {
+			quote = *item.Quote
+		}
+		if segment := FindSegment(quote, segments); segment != nil {
+			res.SegmentSeq = &segment.Seq
+			if segment.Timestamp != nil {
+				reference = *segment.Timestamp
+			}
+		}
+		source := itemSource{segmentSeq: -1, description: strings.Join(words(item.Description), " ")}
+		if res.SegmentSeq != nil {
+			source.segmentSeq = *res.SegmentSeq
+		}
+		res.ItemID = itemID(estateID, meetingID, source, occurrences[source])
+		occurrences[source]++
+
+		if item.DuePhrase != nil {
+			if dueDate, ok := NormalizeDueDate(*item.DuePhrase, reference); ok {
+				res.DueDate = &dueDate
+			}
+		}
+		if res.DueDate == nil && item.DueDate != nil {
+			if dueDate, err := time.Parse(summary.DueDateLayout, *item.DueDate); err == nil {
+				res.DueDate = &dueDate
+			}
+		}
+		tracked = append(tracked, res)
+	}
+	return tracked
+}
+
+// itemSource identifies the action items of a meeting: the seq of their source segment, -1 when it is unknown, and
+// their description, in lower case words without punctuation
+type itemSource struct {
+	segmentSeq  int
+	description string
+}
+
+// itemID returns the id of the occurrence-th action item of the source in the meeting of the estate, a name-based
+// UUID which is the same for the same item in each summary of the meeting. The estate is part of the name since the
+// item ids are unique across the estates.
+func itemID(estateID, meetingID string, source itemSource, occurrence int) string {
+	name := strings.Join([]string{estateID, meetingID, strconv.Itoa(source.segmentSeq), source.description,
+		strconv.Itoa(occurrence)}, "\x00")
+	return uuid.NewSHA1(itemIDNamespace, []byte(name)).String()
+}
+
+// MatchOwner returns the speaker designated by the owner name given by the model: the speaker with the same name,
+// ignoring case, or else the only speaker whose name contains all the words of the owner, e.g. "alice" for
+// "Alice Smith", or whose words are all contained in the owner. It returns nil when no speaker or several match.
+func MatchOwner(owner string, speakers []string) *string {
+	ownerWords := words(owner)
+	if len(ownerWords) == 0 {
+		return nil
+	}
+	for _, speaker := range speakers {
+		if strings.EqualFold(strings.TrimSpace(speaker), strings.TrimSpace(owner)) {
+			return &speaker
+		}
+	}
+
+	var match *string
+	for _, speaker := range speakers {
+		speakerWords := words(speaker)
+		if len(speakerWords) == 0 || !(containsAll(speakerWords, ownerWords) || containsAll(ownerWords, speakerWords)) {
+			continue
+		}
+		if match != nil {
+			return nil
+		}
+		match = &speaker
+	}
+	return match
+}
+
+// FindSegment returns the first segment containing the quote, or else the segment containing the largest share
+// of its words, at least minSegmentScore. It returns nil when no segment matches.
+func FindSegment(quote string, segments []dbmodels.TranscriptSegment) *dbmodels.TranscriptSegment {
+	quoteWords := words(quote)
+	if len(quoteWords) == 0 {
+		return nil
+	}
+	normalized := strings.Join(quoteWords, " ")
+	var best *dbmodels.TranscriptSegment
+	bestScore := 0.0
+	for i := range segments {
+		segmentWords := words(segments[i].Content)
+		if strings.Contains(" "+strings.Join(segmentWords, " ")+" ", " "+normalized+" ") {
+			return &segments[i]
+		}
+		if score := overlap(quoteWords, segmentWords); score >= minSegmentScore && score > bestScore {
+			best, bestScore = &segments[i], score
+		}
+	}
+	return best
+}
+
+// meetingStart returns the time of the first timed segment, or now when the transcript has no timestamps
+func meetingStart(segments []dbmodels.TranscriptSegment, now time.Time) time.Time {
+	for _, segment := range segments {
+		if segment.Timestamp != nil {
+			return *segment.Timestamp
+		}
+	}
+	return now
+}
+
+func speakers(segments []dbmodels.TranscriptSegment) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, segment := range segments {
+		if segment.MemberName != "" && !seen[segment.MemberName] {
+			seen[segment.MemberName] = true
+			names = append(names, segment.MemberName)
+		}
+	}
+	return names
+}
+
+// words returns the lower case words of the text without punctuation
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+}
+
+func containsAll(values []string, expected []string) bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	for _, e := range expected {
+		if !set[e] {
+			return false
+		}
+	}
+	return true
+}
+
+// overlap returns the share of the quote words found in the segment
+func overlap(quoteWords []string, segmentWords []string) float64 {
+	set := make(map[string]bool, len(segmentWords))
+	for _, w := range segmentWords {
+		set[w] = true
+	}
+	found := 0
+	for _, w := range quoteWords {
+		if set[w] {
+			found++
+		}
+	}
+	return float64(found) / float64(len(quoteWords))
+}
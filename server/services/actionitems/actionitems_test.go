@@ -0,0 +1,128 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package actionitems
+
+import (
+	"meeting-analyzer/server/commons/utils"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/services/summary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSegments() []dbmodels.TranscriptSegment {
+	// the meeting runs across midnight, on Wednesday and Thursday
+	first := time.Date(2024, 3, 13, 23, 50, 0, 0, time.UTC)
+	second := time.Date(2024, 3, 14, 0, 5, 0, 0, time.UTC)
+	return []dbmodels.TranscriptSegment{
+		{MeetingID: "m1", Seq: 0, MemberName: "Alice Smith", Timestamp: &first, Content: "Let's review the release."},
+		{MeetingID: "m1", Seq: 1, MemberName: "Bob", Timestamp: &first, Content: "The notes are not sent yet."},
+		{MeetingID: "m1", Seq: 2, MemberName: "Alice Smith", Timestamp: &second, Content: "OK, I'll send the notes by tomorrow!"},
+	}
+}
+
+func TestMatchOwner(t *testing.T) {
+	speakers := []string{"Alice Smith", "Alice Jones", "Bob", "Carol White"}
+
+	tests := []struct {
+		owner    string
+		expected *string
+	}{
+		{owner: "bob", expected: utils.ToPointer("Bob")},
+		{owner: "Alice Smith", expected: utils.ToPointer("Alice Smith")},
+		{owner: "Smith", expected: utils.ToPointer("Alice Smith")},
+		{owner: "Carol", expected: utils.ToPointer("Carol White")},
+		{owner: "Bob Brown", expected: utils.ToPointer("Bob")},
+		{owner: "Alice", expected: nil},
+		{owner: "Dave", expected: nil},
+		{owner: " ", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.owner, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MatchOwner(tt.owner, speakers))
+		})
+	}
+}
+
+func TestFindSegment(t *testing.T) {
+	segments := testSegments()
+
+	assert.Equal(t, 2, FindSegment("I'll send the notes", segments).Seq)
+	assert.Equal(t, 1, FindSegment("notes not sent", segments).Seq)
+	assert.Nil(t, FindSegment("budget review", segments))
+	assert.Nil(t, FindSegment("", segments))
+}
+
+func TestExtract(t *testing.T) {
+	now := time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC)
+	items := []summary.ActionItem{
+		{
+			Description: "Send the notes",
+			Owner:       utils.ToPointer("Alice"),
+			DuePhrase:   utils.ToPointer("by tomorrow"),
+			DueDate:     utils.ToPointer("2024-03-14"),
+			Quote:       utils.ToPointer("I'll send the notes by tomorrow"),
+		},
+		{
+			Description: "Review the release",
+			Owner:       utils.ToPointer("Bob"),
+			DuePhrase:   utils.ToPointer("before the launch"),
+			DueDate:     utils.ToPointer("2024-04-01"),
+		},
+		{Description: "Book a room", Owner: utils.ToPointer("Zed")},
+	}
+
+	tracked := Extract("e1", "m1", items, testSegments(), now)
+
+	require.Len(t, tracked, 3)
+	for i, item := range tracked {
+		assert.NotEmpty(t, item.ItemID)
+		assert.Equal(t, "m1", item.MeetingID)
+		assert.Equal(t, i, item.Seq)
+		assert.False(t, item.Done)
+		assert.Equal(t, now, item.CreatedAt)
+	}
+
+	// the phrase is resolved relative to the segment, said after midnight
+	assert.Equal(t, utils.ToPointer("Alice Smith"), tracked[0].Owner)
+	assert.Equal(t, utils.ToPointer(2), tracked[0].SegmentSeq)
+	assert.Equal(t, utils.ToPointer(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)), tracked[0].DueDate)
+	assert.Equal(t, utils.ToPointer("by tomorrow"), tracked[0].DuePhrase)
+
+	// without quote the description is searched, the date of the model is kept when the phrase is not understood
+	assert.Equal(t, utils.ToPointer("Bob"), tracked[1].Owner)
+	assert.Equal(t, utils.ToPointer(0), tracked[1].SegmentSeq)
+	assert.Equal(t, utils.ToPointer(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)), tracked[1].DueDate)
+
+	assert.Nil(t, tracked[2].Owner)
+	assert.Nil(t, tracked[2].SegmentSeq)
+	assert.Nil(t, tracked[2].DueDate)
+}
+
+func TestExtract_StableIDs(t *testing.T) {
+	now := time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC)
+	items := []summary.ActionItem{
+		{Description: "Send the notes", Quote: utils.ToPointer("I'll send the notes by tomorrow")},
+		{Description: "Book a room"},
+		{Description: "Book a room"},
+	}
+
+	tracked := Extract("e1", "m1", items, testSegments(), now)
+	// the items of the next summary are described and ordered differently
+	regenerated := Extract("e1", "m1", []summary.ActionItem{
+		{Description: "Book a room."},
+		{Description: "send the notes!", Quote: utils.ToPointer("I'll send the notes by tomorrow")},
+	}, testSegments(), now.Add(time.Hour))
+
+	require.Len(t, tracked, 3)
+	assert.NotEqual(t, tracked[1].ItemID, tracked[2].ItemID, "the identical items have their own id")
+	assert.Equal(t, tracked[1].ItemID, regenerated[0].ItemID)
+	assert.Equal(t, tracked[0].ItemID, regenerated[1].ItemID)
+	assert.NotEqual(t, tracked[0].ItemID, Extract("e2", "m1", items, testSegments(), now)[0].ItemID,
+		"the items of the meetings of the other estates have other ids")
+	assert.NotEqual(t, tracked[0].ItemID, Extract("e1", "m2", items, testSegments(), now)[0].ItemID)
+}
@@ -0,0 +1,139 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package actionitems
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// deadlinePrefixPattern matches the words introducing a deadline, "by the end of the week" reads "end of the week"
+	deadlinePrefixPattern = regexp.MustCompile(`^(?:(?:no later than|due|by|on|before|until|till|for|within|in|at)\s+)*(?:the\s+)?`)
+	relativePattern       = regexp.MustCompile(`^(\d+|a|an|one|two|three|four|five|six|seven|eight|nine|ten)\s+(day|week|month)s?$`)
+	monthDayPattern       = regexp.MustCompile(`^([a-z]+)\.?\s+(\d{1,2})(?:st|nd|rd|th)?$`)
+	dayMonthPattern       = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?\s+(?:of\s+)?([a-z]+)\.?$`)
+)
+
+var numbers = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "tues": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "february": time.February, "march": time.March, "april": time.April,
+	"may": time.May, "june": time.June, "july": time.July, "august": time.August, "september": time.September,
+	"october": time.October, "november": time.November, "december": time.December,
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April, "jun": time.June,
+	"jul": time.July, "aug": time.August, "sep": time.September, "sept": time.September, "oct": time.October,
+	"nov": time.November, "dec": time.December,
+}
+
+// NormalizeDueDate resolves a deadline phrase such as "by Friday", "tomorrow", "end of next week", "in two weeks"
+// or "March 3rd" to a date, relative to the reference time of the meeting. Weeks start on Monday and end on Friday,
+// a bare weekday is its next occurrence after the reference day and "next <weekday>" is the one of the next week.
+// It returns false when the phrase is not understood.
+func NormalizeDueDate(phrase string, reference time.Time) (time.Time, bool) {
+	text := strings.ToLower(strings.Join(strings.Fields(phrase), " "))
+	text = strings.TrimRight(text, ".!?,;")
+	text = deadlinePrefixPattern.ReplaceAllString(text, "")
+	day := time.Date(reference.Year(), reference.Month(), reference.Day(), 0, 0, 0, 0, time.UTC)
+
+	if date, err := time.Parse(time.DateOnly, text); err == nil {
+		return date, true
+	}
+	switch text {
+	case "today", "tonight", "eod", "end of day", "end of the day", "end of today", "this afternoon", "this evening":
+		return day, true
+	case "tomorrow", "tomorrow morning", "end of tomorrow":
+		return day.AddDate(0, 0, 1), true
+	case "day after tomorrow":
+		return day.AddDate(0, 0, 2), true
+	case "eow", "end of week", "end of the week", "end of this week", "this week":
+		return nextWeekday(day, time.Friday, true), true
+	case "next week", "end of next week":
+		return startOfWeek(day).AddDate(0, 0, 7+4), true
+	case "early next week", "beginning of next week", "start of next week":
+		return startOfWeek(day).AddDate(0, 0, 7), true
+	case "eom", "end of month", "end of the month", "end of this month", "this month":
+		return endOfMonth(day, 0), true
+	case "next month", "end of next month":
+		return endOfMonth(day, 1), true
+	}
+
+	if weekday, ok := weekdays[strings.TrimPrefix(text, "this ")]; ok {
+		return nextWeekday(day, weekday, false), true
+	}
+	if weekday, ok := weekdays[strings.TrimPrefix(text, "next ")]; ok {
+		return startOfWeek(day).AddDate(0, 0, 7+(int(weekday)+6)%7), true
+	}
+	if match := relativePattern.FindStringSubmatch(text); match != nil {
+		count, ok := numbers[match[1]]
+		if !ok {
+			count, _ = strconv.Atoi(match[1])
+		}
+		switch match[2] {
+		case "day":
+			return day.AddDate(0, 0, count), true
+		case "week":
+			return day.AddDate(0, 0, 7*count), true
+		default:
+			return day.AddDate(0, count, 0), true
+		}
+	}
+	if match := monthDayPattern.FindStringSubmatch(text); match != nil {
+		return calendarDate(day, match[1], match[2])
+	}
+	if match := dayMonthPattern.FindStringSubmatch(text); match != nil {
+		return calendarDate(day, match[2], match[1])
+	}
+	return time.Time{}, false
+}
+
+// nextWeekday returns the next occurrence of weekday after day, or day itself when includeDay is set
+func nextWeekday(day time.Time, weekday time.Weekday, includeDay bool) time.Time {
+	days := (int(weekday) - int(day.Weekday()) + 7) % 7
+	if days == 0 && !includeDay {
+		days = 7
+	}
+	return day.AddDate(0, 0, days)
+}
+
+// startOfWeek returns the Monday of the week of day
+func startOfWeek(day time.Time) time.Time {
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// endOfMonth returns the last day of the month following the month of day by offset months
+func endOfMonth(day time.Time, offset int) time.Time {
+	return time.Date(day.Year(), day.Month()+time.Month(offset)+1, 0, 0, 0, 0, 0, time.UTC)
+}
+
+// calendarDate resolves a month and day without year to their next occurrence from day
+func calendarDate(day time.Time, monthName string, dayOfMonth string) (time.Time, bool) {
+	month, ok := months[monthName]
+	if !ok {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(dayOfMonth)
+	if err != nil || n < 1 || n > 31 {
+		return time.Time{}, false
+	}
+	date := time.Date(day.Year(), month, n, 0, 0, 0, 0, time.UTC)
+	if date.Month() != month {
+		return time.Time{}, false
+	}
+	if date.Before(day) {
+		date = date.AddDate(1, 0, 0)
+	}
+	return date, true
+}
@@ -0,0 +1,59 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package actionitems
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeDueDate(t *testing.T) {
+	// a Wednesday
+	reference := time.Date(2024, 3, 13, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		phrase   string
+		expected string
+	}{
+		{phrase: "by Friday", expected: "2024-03-15"},
+		{phrase: "on Wednesday", expected: "2024-03-20"},
+		{phrase: "this Thursday", expected: "2024-03-14"},
+		{phrase: "by next Monday", expected: "2024-03-18"},
+		{phrase: "next Friday", expected: "2024-03-22"},
+		{phrase: "today", expected: "2024-03-13"},
+		{phrase: "by EOD", expected: "2024-03-13"},
+		{phrase: "Tomorrow.", expected: "2024-03-14"},
+		{phrase: "by the end of the week", expected: "2024-03-15"},
+		{phrase: "next week", expected: "2024-03-22"},
+		{phrase: "early next week", expected: "2024-03-18"},
+		{phrase: "by the end of the month", expected: "2024-03-31"},
+		{phrase: "end of next month", expected: "2024-04-30"},
+		{phrase: "in two weeks", expected: "2024-03-27"},
+		{phrase: "within 3 days", expected: "2024-03-16"},
+		{phrase: "in a month", expected: "2024-04-13"},
+		{phrase: "by March 20th", expected: "2024-03-20"},
+		{phrase: "the 5th of January", expected: "2025-01-05"},
+		{phrase: "2024-04-02", expected: "2024-04-02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			date, ok := NormalizeDueDate(tt.phrase, reference)
+
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, date.Format(time.DateOnly))
+		})
+	}
+}
+
+func TestNormalizeDueDate_Unknown(t *testing.T) {
+	reference := time.Date(2024, 3, 13, 15, 30, 0, 0, time.UTC)
+
+	for _, phrase := range []string{"", "soon", "asap", "before the release", "February 30", "Smarch 3"} {
+		_, ok := NormalizeDueDate(phrase, reference)
+
+		assert.False(t, ok, phrase)
+	}
+}
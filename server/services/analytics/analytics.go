@@ -0,0 +1,176 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package analytics computes the participation metrics of a meeting from its transcript, without any model
+package analytics
+
+import (
+	"meeting-analyzer/server/models/dbmodels"
+	"strings"
+	"time"
+)
+
+const (
+	// wordsPerSecond is the average speaking rate, 150 words per minute, used to estimate when a segment ends
+	// since the transcripts only time the start of the segments
+	wordsPerSecond = 2.5
+	// InterruptionGap is the pause under which a change of speaker counts as an interruption
+	InterruptionGap = time.Second
+	// MinSilenceGap is the pause from which nobody speaking counts as a silence
+	MinSilenceGap = 3 * time.Second
+)
+
+// Analytics are the participation metrics of a meeting. When some segments are not timed, Timed is false,
+// the talk times are estimated from the words only and the interruptions and silences are not computed.
+type Analytics struct {
+	Timed            bool
+	Duration         time.Duration
+	TalkTime         time.Duration
+	Silence          time.Duration
+	Speakers         []Speaker
+	LongestMonologue *Monologue
+	Interruptions    []Interruption
+	SilenceGaps      []SilenceGap
+}
+
+// Speaker are the metrics of a speaker, TalkShare is the share of the talk time of the meeting between 0 and 1
+type Speaker struct {
+	MemberName    string
+	TalkTime      time.Duration
+	TalkShare     float64
+	Words         int
+	Turns         int
+	Interruptions int
+	Interrupted   int
+}
+
+// Monologue is a turn, the consecutive segments of a speaker
+type Monologue struct {
+	MemberName string
+	Seq        int
+	Start      *time.Time
+	Duration   time.Duration
+	Words      int
+}
+
+// Interruption is a change of speaker before the previous one finished, or less than InterruptionGap after.
+// Overlap is the time both were speaking.
+type Interruption struct {
+	Seq         int
+	MemberName  string
+	Interrupted string
+	Timestamp   time.Time
+	Overlap     time.Duration
+}
+
+// SilenceGap is a pause of at least MinSilenceGap after the segment AfterSeq
+type SilenceGap struct {
+	AfterSeq int
+	Start    time.Time
+	Duration time.Duration
+}
+
+// Compute returns the analytics of the segments, ordered by seq. The end of a segment is estimated from its
+// words, and cut at the start of the next segment.
+func Compute(segments []dbmodels.TranscriptSegment) *Analytics {
+	res := &Analytics{
+		Timed:         len(segments) > 0,
+		Speakers:      make([]Speaker, 0),
+		Interruptions: make([]Interruption, 0),
+		SilenceGaps:   make([]SilenceGap, 0),
+	}
+	for _, segment := range segments {
+		if segment.Timestamp == nil {
+			res.Timed = false
+		}
+	}
+
+	speakers := make(map[string]int)
+	speaker := func(name string) *Speaker {
+		i, ok := speakers[name]
+		if !ok {
+			i = len(res.Speakers)
+			speakers[name] = i
+			res.Speakers = append(res.Speakers, Speaker{MemberName: name})
+		}
+		return &res.Speakers[i]
+	}
+
+	var turn *Monologue
+	for i, segment := range segments {
+		// registers the speaker before the next one, the speakers are listed in order of first appearance
+		speaker(segment.MemberName)
+		words := len(strings.Fields(segment.Content))
+		talk := estimate(words)
+		var next *dbmodels.TranscriptSegment
+		if i+1 < len(segments) {
+			next = &segments[i+1]
+		}
+		if res.Timed && next != nil {
+			end := segment.Timestamp.Add(talk)
+			gap := next.Timestamp.Sub(end)
+			if gap < 0 {
+				talk = max(next.Timestamp.Sub(*segment.Timestamp), 0)
+			}
+			if next.MemberName != segment.MemberName && gap < InterruptionGap {
+				res.Interruptions = append(res.Interruptions, Interruption{
+					Seq:         next.Seq,
+					MemberName:  next.MemberName,
+					Interrupted: segment.MemberName,
+					Timestamp:   *next.Timestamp,
+					Overlap:     max(-gap, 0),
+				})
+				speaker(next.MemberName).Interruptions++
+				speaker(segment.MemberName).Interrupted++
+			}
+			if gap >= MinSilenceGap {
+				res.SilenceGaps = append(res.SilenceGaps, SilenceGap{AfterSeq: segment.Seq, Start: end, Duration: gap})
+				res.Silence += gap
+			}
+		}
+
+		stats := speaker(segment.MemberName)
+		stats.TalkTime += talk
+		stats.Words += words
+		res.TalkTime += talk
+
+		if turn == nil || turn.MemberName != segment.MemberName {
+			stats.Turns++
+			turn = &Monologue{MemberName: segment.MemberName, Seq: segment.Seq, Start: segment.Timestamp}
+		}
+		turn.Words += words
+		if res.Timed {
+			turn.Duration = segment.Timestamp.Add(talk).Sub(*turn.Start)
+		} else {
+			turn.Duration += talk
+		}
+		if res.LongestMonologue == nil || longer(turn, res.LongestMonologue) {
+			longest := *turn
+			res.LongestMonologue = &longest
+		}
+	}
+
+	for i := range res.Speakers {
+		if res.TalkTime > 0 {
+			res.Speakers[i].TalkShare = float64(res.Speakers[i].TalkTime) / float64(res.TalkTime)
+		}
+	}
+	if res.Timed {
+		last := segments[len(segments)-1]
+		res.Duration = last.Timestamp.Add(estimate(len(strings.Fields(last.Content)))).Sub(*segments[0].Timestamp)
+	} else {
+		res.Duration = res.TalkTime
+	}
+	return res
+}
+
+// estimate returns the time needed to say the words
+func estimate(words int) time.Duration {
+	return time.Duration(float64(words) / wordsPerSecond * float64(time.Second))
+}
+
+func longer(turn *Monologue, longest *Monologue) bool {
+	if turn.Duration != longest.Duration {
+		return turn.Duration > longest.Duration
+	}
+	return turn.Words > longest.Words
+}
@@ -0,0 +1,99 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package analytics
+
+import (
+	"meeting-analyzer/server/models/dbmodels"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+// segment returns a segment of the given number of words, said offset after the start of the meeting
+func segment(seq int, member string, offset time.Duration, words int) dbmodels.TranscriptSegment {
+	timestamp := start.Add(offset)
+	return dbmodels.TranscriptSegment{
+		MeetingID:  "m1",
+		Seq:        seq,
+		MemberName: member,
+		Timestamp:  &timestamp,
+		Content:    strings.TrimSpace(strings.Repeat("word ", words)),
+	}
+}
+
+func TestCompute(t *testing.T) {
+	segments := []dbmodels.TranscriptSegment{
+		// 10 words last 4s, Bob starts talking 1s before Alice finishes
+		segment(0, "Alice", 0, 10),
+		segment(1, "Bob", 3*time.Second, 5),
+		segment(2, "Bob", 5500*time.Millisecond, 5),
+		// 5s of silence after Bob finishes at 7.5s
+		segment(3, "Alice", 12500*time.Millisecond, 20),
+	}
+
+	res := Compute(segments)
+
+	assert.True(t, res.Timed)
+	assert.Equal(t, 20500*time.Millisecond, res.Duration)
+	assert.Equal(t, 15*time.Second, res.TalkTime)
+	assert.Equal(t, []Speaker{
+		{MemberName: "Alice", TalkTime: 11 * time.Second, TalkShare: 11.0 / 15, Words: 30, Turns: 2, Interrupted: 1},
+		{MemberName: "Bob", TalkTime: 4 * time.Second, TalkShare: 4.0 / 15, Words: 10, Turns: 1, Interruptions: 1},
+	}, res.Speakers)
+	assert.Equal(t, []Interruption{
+		{Seq: 1, MemberName: "Bob", Interrupted: "Alice", Timestamp: start.Add(3 * time.Second), Overlap: time.Second},
+	}, res.Interruptions)
+	assert.Equal(t, []SilenceGap{{AfterSeq: 2, Start: start.Add(7500 * time.Millisecond), Duration: 5 * time.Second}},
+		res.SilenceGaps)
+	assert.Equal(t, 5*time.Second, res.Silence)
+	require.NotNil(t, res.LongestMonologue)
+	assert.Equal(t, "Alice", res.LongestMonologue.MemberName)
+	assert.Equal(t, 3, res.LongestMonologue.Seq)
+	assert.Equal(t, 8*time.Second, res.LongestMonologue.Duration)
+	assert.Equal(t, 20, res.LongestMonologue.Words)
+}
+
+func TestCompute_QuickTurnChange(t *testing.T) {
+	// Alice finishes at 2s and Bob answers 500ms later
+	res := Compute([]dbmodels.TranscriptSegment{
+		segment(0, "Alice", 0, 5),
+		segment(1, "Bob", 2500*time.Millisecond, 5),
+		segment(2, "Alice", 10*time.Second, 5),
+	})
+
+	require.Len(t, res.Interruptions, 1)
+	assert.Equal(t, time.Duration(0), res.Interruptions[0].Overlap)
+	assert.Equal(t, "Bob", res.Interruptions[0].MemberName)
+}
+
+func TestCompute_Untimed(t *testing.T) {
+	segments := []dbmodels.TranscriptSegment{
+		segment(0, "Alice", 0, 10),
+		{Seq: 1, MemberName: "Bob", Content: "one two three four five"},
+		segment(2, "Alice", time.Second, 5),
+	}
+
+	res := Compute(segments)
+
+	assert.False(t, res.Timed)
+	assert.Equal(t, 8*time.Second, res.TalkTime)
+	assert.Equal(t, res.TalkTime, res.Duration)
+	assert.Empty(t, res.Interruptions)
+	assert.Empty(t, res.SilenceGaps)
+	assert.Equal(t, 2, res.Speakers[0].Turns)
+	assert.Equal(t, 6*time.Second, res.Speakers[0].TalkTime)
+	assert.Equal(t, 0, res.LongestMonologue.Seq)
+}
+
+func TestCompute_Empty(t *testing.T) {
+	res := Compute(nil)
+
+	assert.False(t, res.Timed)
+	assert.Empty(t, res.Speakers)
+	assert.Nil(t, res.LongestMonologue)
+}
@@ -0,0 +1,149 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package authz
+
+import (
+	"context"
+	"errors"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/models/errorresponse"
+	"meeting-analyzer/server/repositories"
+	"meeting-analyzer/server/services/access"
+	"meeting-analyzer/server/services/service"
+)
+
+type authorizedSvc struct {
+	next service.Service
+	repo repositories.Repository
+}
+
+// NewService checks the permissions of the initiator of the tenant of the contexts on the meetings before calling
+// next, see access.Allowed. The calls on a meeting the initiator may not access fail with ErrForbidden, the meetings
+// of the other estates are still not found. Generating the summary of a new meeting is always allowed, its initiator
+// owns it, while regenerating it requires access.ActionManage. The listed summaries are restricted by the repository.
+func NewService(next service.Service, repo repositories.Repository) service.Service {
+	return &authorizedSvc{next: next, repo: repo}
+}
+
+func (s *authorizedSvc) GenerateMeetingSummary(ctx context.Context, meetingDetails *models.MeetingDetails, options models.GenerateOptions) (*generated.GenerateMeetingSummaryResponse, error) {
+	if err := s.authorizeGeneration(ctx, meetingDetails.MeetingID); err != nil {
+		return nil, err
+	}
+	return s.next.GenerateMeetingSummary(ctx, meetingDetails, options)
+}
+
+func (s *authorizedSvc) ImportMeetingTranscript(ctx context.Context, upload *models.TranscriptUpload, options models.GenerateOptions) (*generated.GenerateMeetingSummaryResponse, error) {
+	if err := s.authorizeGeneration(ctx, upload.MeetingID); err != nil {
+		return nil, err
+	}
+	return s.next.ImportMeetingTranscript(ctx, upload, options)
+}
+
+func (s *authorizedSvc) GetMeetingSummary(ctx context.Context, meetingID string) (*generated.MeetingSummary, error) {
+	if err := s.authorize(ctx, meetingID, access.ActionRead); err != nil {
+		return nil, err
+	}
+	return s.next.GetMeetingSummary(ctx, meetingID)
+}
+
+func (s *authorizedSvc) StreamMeetingSummary(ctx context.Context, meetingID string) (<-chan models.SummaryEvent, error) {
+	if err := s.authorize(ctx, meetingID, access.ActionRead); err != nil {
+		return nil, err
+	}
+	return s.next.StreamMeetingSummary(ctx, meetingID)
+}
+
+func (s *authorizedSvc) ListMeetingSummaries(ctx context.Context, params generated.GetMeetingSummariesParams) ([]generated.MeetingSummary, int, error) {
+	return s.next.ListMeetingSummaries(ctx, params)
+}
+
+func (s *authorizedSvc) DeleteMeeting(ctx context.Context, meetingID string) error {
+	if err := s.authorize(ctx, meetingID, access.ActionManage); err != nil {
+		return err
+	}
+	return s.next.DeleteMeeting(ctx, meetingID)
+}
+
+func (s *authorizedSvc) ListActionItems(ctx context.Context, meetingID string) ([]generated.MeetingActionItem, error) {
+	if err := s.authorize(ctx, meetingID, access.ActionRead); err != nil {
+		return nil, err
+	}
+	return s.next.ListActionItems(ctx, meetingID)
+}
+
+func (s *authorizedSvc) UpdateActionItem(ctx context.Context, meetingID string, itemID string, request *generated.UpdateActionItemRequest) (*generated.MeetingActionItem, error) {
+	if err := s.authorize(ctx, meetingID, access.ActionUpdate); err != nil {
+		return nil, err
+	}
+	return s.next.UpdateActionItem(ctx, meetingID, itemID, request)
+}
+
+func (s *authorizedSvc) GetMeetingAnalytics(ctx context.Context, meetingID string) (*generated.MeetingAnalytics, error) {
+	if err := s.authorize(ctx, meetingID, access.ActionRead); err != nil {
+		return nil, err
+	}
+	return s.next.GetMeetingAnalytics(ctx, meetingID)
+}
+
+func (s *authorizedSvc) ListMeetingShares(ctx context.Context, meetingID string) ([]generated.MeetingShare, error) {
+	if err := s.authorize(ctx, meetingID, access.ActionManage); err != nil {
+		return nil, err
+	}
+	return s.next.ListMeetingShares(ctx, meetingID)
+}
+
+func (s *authorizedSvc) ShareMeeting(ctx context.Context, meetingID string, request *generated.ShareMeetingRequest) (*generated.MeetingShare, error) {
+	if err := s.authorize(ctx, meetingID, access.ActionManage); err != nil {
+		return nil, err
+	}
+	return s.next.ShareMeeting(ctx, meetingID, request)
+}
+
+func (s *authorizedSvc) UnshareMeeting(ctx context.Context, meetingID string, principalType generated.PrincipalTypeEnum, principalID string) error {
+	if err := s.authorize(ctx, meetingID, access.ActionManage); err != nil {
+		return err
+	}
+	return s.next.UnshareMeeting(ctx, meetingID, principalType, principalID)
+}
+
+func (s *authorizedSvc) Shutdown(ctx context.Context) error {
+	return s.next.Shutdown(ctx)
+}
+
+// authorizeGeneration allows the generation of the summary of a new meeting of the estate, and requires
+// access.ActionManage on an existing one
+func (s *authorizedSvc) authorizeGeneration(ctx context.Context, meetingID string) error {
+	err := s.authorize(ctx, meetingID, access.ActionManage)
+	if errors.Is(err, errorresponse.ErrMeetingIDNotFound) {
+		return nil
+	}
+	return err
+}
+
+// authorize fails with ErrForbidden when the initiator may not do the action on the meeting. The shares of the
+// meeting are only read when the initiator is neither an admin nor the owner.
+func (s *authorizedSvc) authorize(ctx context.Context, meetingID string, action access.Action) error {
+	tenant, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return errorresponse.ErrUnauthenticated
+	}
+	meeting, err := s.repo.GetMeeting(ctx, meetingID)
+	if err != nil {
+		return err
+	}
+	acl := access.ACL{Owner: meeting.CreatedBy}
+	if access.Allowed(tenant, acl, action) {
+		return nil
+	}
+	if action == access.ActionRead {
+		if acl.Shares, err = s.repo.ListShares(ctx, meetingID); err != nil {
+			return err
+		}
+		if access.Allowed(tenant, acl, action) {
+			return nil
+		}
+	}
+	return errorresponse.WithArgs(errorresponse.ErrForbidden, meetingID)
+}
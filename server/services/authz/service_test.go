@@ -0,0 +1,146 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package authz
+
+import (
+	"context"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"meeting-analyzer/server/repositories/repotest"
+	"meeting-analyzer/server/services/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeService records the calls which passed the authorization, the other methods are not called
+type fakeService struct {
+	service.Service
+	calls []string
+}
+
+func (f *fakeService) GenerateMeetingSummary(_ context.Context, meetingDetails *models.MeetingDetails, _ models.GenerateOptions) (*generated.GenerateMeetingSummaryResponse, error) {
+	f.calls = append(f.calls, "generate "+meetingDetails.MeetingID)
+	return &generated.GenerateMeetingSummaryResponse{MeetingId: meetingDetails.MeetingID}, nil
+}
+
+func (f *fakeService) ImportMeetingTranscript(_ context.Context, upload *models.TranscriptUpload, _ models.GenerateOptions) (*generated.GenerateMeetingSummaryResponse, error) {
+	f.calls = append(f.calls, "import "+upload.MeetingID)
+	return &generated.GenerateMeetingSummaryResponse{MeetingId: upload.MeetingID}, nil
+}
+
+func (f *fakeService) GetMeetingSummary(_ context.Context, meetingID string) (*generated.MeetingSummary, error) {
+	f.calls = append(f.calls, "get "+meetingID)
+	return &generated.MeetingSummary{MeetingId: meetingID}, nil
+}
+
+func (f *fakeService) UpdateActionItem(_ context.Context, meetingID string, itemID string, _ *generated.UpdateActionItemRequest) (*generated.MeetingActionItem, error) {
+	f.calls = append(f.calls, "update "+meetingID)
+	return &generated.MeetingActionItem{Id: itemID, MeetingId: meetingID}, nil
+}
+
+func (f *fakeService) DeleteMeeting(_ context.Context, meetingID string) error {
+	f.calls = append(f.calls, "delete "+meetingID)
+	return nil
+}
+
+func (f *fakeService) ShareMeeting(_ context.Context, meetingID string, request *generated.ShareMeetingRequest) (*generated.MeetingShare, error) {
+	f.calls = append(f.calls, "share "+meetingID)
+	return &generated.MeetingShare{MeetingId: meetingID, PrincipalId: request.PrincipalId}, nil
+}
+
+// key returns the key of the meeting in the estate e1 of the tests
+func key(meetingID string) repotest.Key {
+	return repotest.Key{EstateID: "e1", MeetingID: meetingID}
+}
+
+func newTestService() (service.Service, *fakeService) {
+	repo := repotest.NewRepository()
+	repo.Meetings[key("m1")] = dbmodels.Meeting{MeetingID: "m1", EstateID: "e1", CreatedBy: "owner"}
+	repo.Segments[key("m1")] = []dbmodels.TranscriptSegment{{MeetingID: "m1", MemberName: "Alice"}}
+	repo.Shares[key("m1")] = []dbmodels.MeetingShare{{MeetingID: "m1", PrincipalType: generated.Group, PrincipalID: "team"}}
+	next := &fakeService{}
+	return NewService(next, repo), next
+}
+
+func tenantContext(initiatorID string, groups ...string) context.Context {
+	return tenancy.NewContext(context.Background(), tenancy.Tenant{EstateID: "e1", InitiatorID: initiatorID, Groups: groups})
+}
+
+func TestService(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		call func(ctx context.Context, s service.Service) error
+		err  error
+	}{
+		{name: "OwnerDeletes", ctx: tenantContext("owner"), call: deleteMeeting},
+		{name: "AdminDeletes", ctx: tenancy.NewContext(context.Background(),
+			tenancy.Tenant{EstateID: "e1", InitiatorID: "u1", Roles: []string{tenancy.RoleAdmin}}), call: deleteMeeting},
+		{name: "SpeakerDeletes", ctx: tenantContext("alice"), call: deleteMeeting, err: errorresponse.ErrForbidden},
+		{name: "SpeakerReads", ctx: tenantContext("alice"), call: getSummary, err: errorresponse.ErrForbidden},
+		{name: "SpeakerUpdates", ctx: tenantContext("alice"), call: updateActionItem, err: errorresponse.ErrForbidden},
+		{name: "OwnerUpdates", ctx: tenantContext("owner"), call: updateActionItem},
+		{name: "GroupReads", ctx: tenantContext("u1", "team"), call: getSummary},
+		{name: "GroupUpdates", ctx: tenantContext("u1", "team"), call: updateActionItem, err: errorresponse.ErrForbidden},
+		{name: "GroupShares", ctx: tenantContext("u1", "team"), call: shareMeeting, err: errorresponse.ErrForbidden},
+		{name: "StrangerReads", ctx: tenantContext("u1"), call: getSummary, err: errorresponse.ErrForbidden},
+		{name: "StrangerRegenerates", ctx: tenantContext("u1"), call: generateSummary("m1"), err: errorresponse.ErrForbidden},
+		{name: "StrangerGenerates", ctx: tenantContext("u1"), call: generateSummary("m2")},
+		{name: "OwnerRegenerates", ctx: tenantContext("owner"), call: generateSummary("m1")},
+		{name: "StrangerImports", ctx: tenantContext("u1"), call: func(ctx context.Context, s service.Service) error {
+			_, err := s.ImportMeetingTranscript(ctx, &models.TranscriptUpload{MeetingID: "m1"}, models.GenerateOptions{})
+			return err
+		}, err: errorresponse.ErrForbidden},
+		{name: "OtherEstate", ctx: tenancy.NewContext(context.Background(), tenancy.Tenant{EstateID: "e2", InitiatorID: "owner"}),
+			call: getSummary, err: errorresponse.ErrMeetingIDNotFound},
+		{name: "OtherEstateGenerates", ctx: tenancy.NewContext(context.Background(),
+			tenancy.Tenant{EstateID: "e2", InitiatorID: "u1"}), call: generateSummary("m1")},
+		{name: "WithoutTenant", ctx: context.Background(), call: getSummary, err: errorresponse.ErrUnauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, next := newTestService()
+
+			err := tt.call(tt.ctx, s)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Empty(t, next.calls)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, next.calls, 1)
+		})
+	}
+}
+
+func deleteMeeting(ctx context.Context, s service.Service) error {
+	return s.DeleteMeeting(ctx, "m1")
+}
+
+func getSummary(ctx context.Context, s service.Service) error {
+	_, err := s.GetMeetingSummary(ctx, "m1")
+	return err
+}
+
+func updateActionItem(ctx context.Context, s service.Service) error {
+	_, err := s.UpdateActionItem(ctx, "m1", "a1", &generated.UpdateActionItemRequest{Done: true})
+	return err
+}
+
+func shareMeeting(ctx context.Context, s service.Service) error {
+	_, err := s.ShareMeeting(ctx, "m1", &generated.ShareMeetingRequest{PrincipalType: generated.User, PrincipalId: "u2"})
+	return err
+}
+
+func generateSummary(meetingID string) func(ctx context.Context, s service.Service) error {
+	return func(ctx context.Context, s service.Service) error {
+		_, err := s.GenerateMeetingSummary(ctx, &models.MeetingDetails{MeetingID: meetingID}, models.GenerateOptions{})
+		return err
+	}
+}
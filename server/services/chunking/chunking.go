@@ -0,0 +1,137 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package chunking splits long transcriptions in chunks fitting the context window of the model
+package chunking
+
+import (
+	"meeting-analyzer/server/models"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// charsPerToken is the average length of a token of English text for the GPT tokenizers
+	charsPerToken = 4
+	// segmentOverheadTokens accounts for the quotes, separators and line breaks of a formatted segment
+	segmentOverheadTokens = 4
+)
+
+// Config sizes the chunks, in estimated tokens
+type Config struct {
+	MaxTokens     int
+	OverlapTokens int
+}
+
+// EstimateTokens approximates the number of tokens of the text without loading a tokenizer
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken
+}
+
+// SegmentTokens estimates the tokens of a segment once formatted in the prompt
+func SegmentTokens(t models.Transcription) int {
+	return EstimateTokens(t.MemberName) + EstimateTokens(t.FormattedTimestamp()) + EstimateTokens(t.Content) + segmentOverheadTokens
+}
+
+// turn is a run of consecutive segments of the same speaker
+type turn struct {
+	segments []models.Transcription
+	tokens   int
+}
+
+// Split splits the transcription in chunks of at most MaxTokens, cutting only between speaker turns.
+// Every chunk but the first starts with the last turns of the previous one, up to OverlapTokens, so that
+// the model keeps the context at the boundaries. Turns larger than a chunk are cut between segments,
+// and segments larger than a chunk between words.
+func Split(transcription []models.Transcription, config Config) [][]models.Transcription {
+	if len(transcription) == 0 {
+		return nil
+	}
+	if config.MaxTokens <= 0 {
+		return [][]models.Transcription{transcription}
+	}
+
+	chunks := make([][]models.Transcription, 0)
+	var current []turn
+	currentTokens, fresh := 0, 0
+	for _, t := range toTurns(transcription, config.MaxTokens) {
+		if currentTokens+t.tokens > config.MaxTokens && fresh > 0 {
+			chunks = append(chunks, flatten(current))
+			current = overlap(current, config.OverlapTokens)
+			currentTokens, fresh = sumTokens(current), 0
+		}
+		for currentTokens+t.tokens > config.MaxTokens && len(current) > 0 {
+			currentTokens -= current[0].tokens
+			current = current[1:]
+		}
+		current = append(current, t)
+		currentTokens += t.tokens
+		fresh++
+	}
+	return append(chunks, flatten(current))
+}
+
+// toTurns groups the consecutive segments of a speaker, the turns larger than maxTokens are cut
+func toTurns(transcription []models.Transcription, maxTokens int) []turn {
+	turns := make([]turn, 0)
+	for _, segment := range transcription {
+		for _, piece := range splitSegment(segment, maxTokens) {
+			tokens := SegmentTokens(piece)
+			last := len(turns) - 1
+			if last >= 0 && turns[last].segments[0].MemberName == piece.MemberName && turns[last].tokens+tokens <= maxTokens {
+				turns[last].segments = append(turns[last].segments, piece)
+				turns[last].tokens += tokens
+				continue
+			}
+			turns = append(turns, turn{segments: []models.Transcription{piece}, tokens: tokens})
+		}
+	}
+	return turns
+}
+
+// splitSegment cuts the content of a segment larger than maxTokens between words
+func splitSegment(segment models.Transcription, maxTokens int) []models.Transcription {
+	if SegmentTokens(segment) <= maxTokens {
+		return []models.Transcription{segment}
+	}
+	budget := max(maxTokens-SegmentTokens(models.Transcription{MemberName: segment.MemberName, Timestamp: segment.Timestamp}), 1)
+	pieces := make([]models.Transcription, 0)
+	var content strings.Builder
+	for _, word := range strings.Fields(segment.Content) {
+		if content.Len() > 0 && EstimateTokens(content.String()+" "+word) > budget {
+			pieces = append(pieces, models.Transcription{MemberName: segment.MemberName, Timestamp: segment.Timestamp, Content: content.String()})
+			content.Reset()
+		}
+		if content.Len() > 0 {
+			content.WriteString(" ")
+		}
+		content.WriteString(word)
+	}
+	return append(pieces, models.Transcription{MemberName: segment.MemberName, Timestamp: segment.Timestamp, Content: content.String()})
+}
+
+// overlap returns the longest tail of whole turns within the overlap budget
+func overlap(turns []turn, overlapTokens int) []turn {
+	tokens := 0
+	start := len(turns)
+	for start > 0 && tokens+turns[start-1].tokens <= overlapTokens {
+		start--
+		tokens += turns[start].tokens
+	}
+	return append([]turn(nil), turns[start:]...)
+}
+
+func sumTokens(turns []turn) int {
+	tokens := 0
+	for _, t := range turns {
+		tokens += t.tokens
+	}
+	return tokens
+}
+
+func flatten(turns []turn) []models.Transcription {
+	segments := make([]models.Transcription, 0)
+	for _, t := range turns {
+		segments = append(segments, t.segments...)
+	}
+	return segments
+}
@@ -0,0 +1,141 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package chunking
+
+import (
+	"meeting-analyzer/server/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// segment returns a segment estimated at tokens tokens
+func segment(member string, tokens int) models.Transcription {
+	t := models.Transcription{MemberName: member}
+	t.Content = strings.Repeat("x", (tokens-SegmentTokens(t))*charsPerToken)
+	return t
+}
+
+func members(chunk []models.Transcription) string {
+	names := make([]string, 0, len(chunk))
+	for _, t := range chunk {
+		names = append(names, t.MemberName)
+	}
+	return strings.Join(names, ",")
+}
+
+// timestamp parses an RFC 3339 timestamp of the fixtures
+func timestamp(value string) *time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		panic(err)
+	}
+	return &parsed
+}
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, 0, EstimateTokens(""))
+	assert.Equal(t, 1, EstimateTokens("abc"))
+	assert.Equal(t, 2, EstimateTokens("hello"))
+	assert.Equal(t, 1, EstimateTokens("日本語"))
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments []models.Transcription
+		config   Config
+		expected []string
+	}{
+		{
+			name:     "FitsInOneChunk",
+			segments: []models.Transcription{segment("A", 10), segment("B", 10)},
+			config:   Config{MaxTokens: 100},
+			expected: []string{"A,B"},
+		},
+		{
+			name:     "NoLimit",
+			segments: []models.Transcription{segment("A", 100), segment("B", 100)},
+			config:   Config{},
+			expected: []string{"A,B"},
+		},
+		{
+			name:     "CutsBetweenTurns",
+			segments: []models.Transcription{segment("A", 30), segment("B", 30), segment("B", 30), segment("C", 30)},
+			config:   Config{MaxTokens: 70},
+			expected: []string{"A", "B,B", "C"},
+		},
+		{
+			name:     "OverlapsLastTurns",
+			segments: []models.Transcription{segment("A", 30), segment("B", 20), segment("C", 30), segment("D", 30)},
+			config:   Config{MaxTokens: 60, OverlapTokens: 20},
+			expected: []string{"A,B", "B,C", "D"},
+		},
+		{
+			name:     "DropsOverlapNotFitting",
+			segments: []models.Transcription{segment("A", 20), segment("B", 50)},
+			config:   Config{MaxTokens: 60, OverlapTokens: 20},
+			expected: []string{"A", "B"},
+		},
+		{
+			name:     "CutsLongTurnBetweenSegments",
+			segments: []models.Transcription{segment("A", 30), segment("A", 30), segment("A", 30)},
+			config:   Config{MaxTokens: 70},
+			expected: []string{"A,A", "A"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := Split(tt.segments, tt.config)
+
+			actual := make([]string, 0, len(chunks))
+			for _, chunk := range chunks {
+				actual = append(actual, members(chunk))
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestSplit_LongSegment(t *testing.T) {
+	long := models.Transcription{MemberName: "A", Timestamp: timestamp("2024-01-01T10:00:00Z"),
+		Content: strings.TrimSpace(strings.Repeat("word ", 200))}
+
+	chunks := Split([]models.Transcription{long}, Config{MaxTokens: 50, OverlapTokens: 10})
+
+	require.Greater(t, len(chunks), 1)
+	words := 0
+	for _, chunk := range chunks {
+		require.Len(t, chunk, 1)
+		assert.LessOrEqual(t, SegmentTokens(chunk[0]), 50)
+		assert.Equal(t, long.Timestamp, chunk[0].Timestamp)
+		words += len(strings.Fields(chunk[0].Content))
+	}
+	assert.Equal(t, 200, words)
+}
+
+func TestSplit_ChunksFitLimit(t *testing.T) {
+	segments := make([]models.Transcription, 0)
+	for i := 0; i < 50; i++ {
+		segments = append(segments, segment(string(rune('A'+i%3)), 10+i%7*5))
+	}
+
+	chunks := Split(segments, Config{MaxTokens: 120, OverlapTokens: 30})
+
+	for _, chunk := range chunks {
+		tokens := 0
+		for _, s := range chunk {
+			tokens += SegmentTokens(s)
+		}
+		assert.LessOrEqual(t, tokens, 120)
+	}
+	assert.Equal(t, segments[len(segments)-1], chunks[len(chunks)-1][len(chunks[len(chunks)-1])-1])
+}
+
+func TestSplit_Empty(t *testing.T) {
+	assert.Nil(t, Split(nil, Config{MaxTokens: 10}))
+}
@@ -0,0 +1,76 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package credentials loads the API keys of the llm providers from the environment, a mounted secret file or
+// HashiCorp Vault, and picks up their rotation without a restart. The keys are never logged nor returned in errors.
+package credentials
+
+import (
+	"context"
+	"errors"
+)
+
+const redacted = "[REDACTED]"
+
+// errors
+var (
+	ErrEmptySecret         = errors.New("secret is empty")
+	ErrInvalidVaultConfig  = errors.New("vault config is incomplete")
+	ErrSecretFieldNotFound = errors.New("secret field not found")
+)
+
+// Provider returns the current value of a secret
+type Provider interface {
+	// Get returns the current secret, it is cached and loaded again when it rotates
+	Get(ctx context.Context) (string, error)
+	// Invalidate forces the next Get to load the secret again, e.g. after the secret was rejected
+	Invalidate()
+}
+
+// Config selects where the secret is loaded from: Vault when set, else File when set, else APIKey
+type Config struct {
+	APIKey Static
+	// File is the path of a mounted secret file, like a kubernetes secret volume
+	File  string
+	Vault *VaultConfig
+}
+
+// New returns the provider of the secret described by the config. The secret is loaded once so that a
+// misconfiguration fails at startup.
+func New(ctx context.Context, config Config) (Provider, error) {
+	var provider Provider
+	switch {
+	case config.Vault != nil:
+		vaultProvider, err := NewVaultProvider(*config.Vault)
+		if err != nil {
+			return nil, err
+		}
+		provider = vaultProvider
+	case config.File != "":
+		provider = NewFileProvider(config.File)
+	default:
+		return config.APIKey, nil
+	}
+	if _, err := provider.Get(ctx); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// Static is a secret which does not rotate, like an env var. An empty Static means no secret.
+type Static string
+
+func (s Static) Get(context.Context) (string, error) {
+	return string(s), nil
+}
+
+func (s Static) Invalidate() {}
+
+// String keeps the secret out of the logs
+func (s Static) String() string {
+	return redacted
+}
+
+// GoString keeps the secret out of the logs
+func (s Static) GoString() string {
+	return redacted
+}
@@ -0,0 +1,61 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	vault := newFakeVault(t, "vault-key")
+	path := filepath.Join(t.TempDir(), "api-key")
+	require.NoError(t, os.WriteFile(path, []byte("file-key"), 0o600))
+	vaultConfig := &VaultConfig{Address: vault.URL, RoleID: testRoleID, SecretID: testSecretID,
+		SecretPath: testSecretPath}
+
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{name: "Vault", config: Config{APIKey: "env-key", File: path, Vault: vaultConfig}, expected: "vault-key"},
+		{name: "File", config: Config{APIKey: "env-key", File: path}, expected: "file-key"},
+		{name: "Env", config: Config{APIKey: "env-key"}, expected: "env-key"},
+		{name: "None", config: Config{}, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := New(context.Background(), tt.config)
+			require.NoError(t, err)
+
+			secret, err := provider.Get(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, secret)
+		})
+	}
+}
+
+func TestNew_FailsOnMissingSecret(t *testing.T) {
+	_, err := New(context.Background(), Config{File: filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = New(context.Background(), Config{Vault: &VaultConfig{Address: "http://localhost"}})
+	assert.ErrorIs(t, err, ErrInvalidVaultConfig)
+}
+
+func TestStatic_Redacted(t *testing.T) {
+	config := Config{APIKey: "env-key", Vault: &VaultConfig{SecretID: "vault-secret-id"}}
+
+	printed := fmt.Sprintf("%v %+v %#v %s %+v", config, config, config, config.APIKey, *config.Vault)
+
+	assert.NotContains(t, printed, "env-key")
+	assert.NotContains(t, printed, "vault-secret-id")
+}
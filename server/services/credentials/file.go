@@ -0,0 +1,69 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "eos2git.cec.lab.emc.com/ISG-Edge/hzp-go-commons/logger"
+)
+
+// fileProvider reads the secret from a file and reads it again when the file changes. Kubernetes rotates a
+// mounted secret by swapping the symlink of the volume, which changes the modification time seen through it.
+type fileProvider struct {
+	path string
+
+	mu      sync.Mutex
+	secret  string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileProvider returns the provider of the secret stored in the file at path
+func NewFileProvider(path string) Provider {
+	return &fileProvider{path: path}
+}
+
+func (p *fileProvider) Get(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return p.fallback(ctx, fmt.Errorf("failed to stat the secret file %s: %w", p.path, err))
+	}
+	if p.secret != "" && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return p.secret, nil
+	}
+
+	content, err := os.ReadFile(p.path)
+	if err != nil {
+		return p.fallback(ctx, fmt.Errorf("failed to read the secret file %s: %w", p.path, err))
+	}
+	secret := strings.TrimSpace(string(content))
+	if secret == "" {
+		return p.fallback(ctx, fmt.Errorf("%w: %s", ErrEmptySecret, p.path))
+	}
+	p.secret, p.modTime, p.size = secret, info.ModTime(), info.Size()
+	return p.secret, nil
+}
+
+func (p *fileProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.modTime = time.Time{}
+}
+
+// fallback keeps the last secret read when the file is being rotated
+func (p *fileProvider) fallback(ctx context.Context, err error) (string, error) {
+	if p.secret == "" {
+		return "", err
+	}
+	log.Error(ctx, nil, "", err, "failed to reload the secret file, using the previous secret")
+	return p.secret, nil
+}
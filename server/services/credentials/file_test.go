@@ -0,0 +1,86 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSecret writes the secret file with a modification time after the previous one
+func writeSecret(t *testing.T, path string, content string, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestFileProvider_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-key")
+	modTime := time.Now().Add(-time.Hour)
+	writeSecret(t, path, "first-key\n", modTime)
+	provider := NewFileProvider(path)
+
+	secret, err := provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first-key", secret)
+
+	writeSecret(t, path, "second-key", modTime.Add(time.Minute))
+	secret, err = provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "second-key", secret)
+}
+
+func TestFileProvider_Invalidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-key")
+	modTime := time.Now().Add(-time.Hour)
+	writeSecret(t, path, "first-key", modTime)
+	provider := NewFileProvider(path)
+	_, err := provider.Get(context.Background())
+	require.NoError(t, err)
+
+	// same size and modification time, only an invalidation reads the file again
+	writeSecret(t, path, "other-key", modTime)
+	secret, err := provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first-key", secret)
+
+	provider.Invalidate()
+	secret, err = provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "other-key", secret)
+}
+
+func TestFileProvider_KeepsPreviousSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-key")
+	writeSecret(t, path, "first-key", time.Now().Add(-time.Hour))
+	provider := NewFileProvider(path)
+	_, err := provider.Get(context.Background())
+	require.NoError(t, err)
+
+	writeSecret(t, path, " \n", time.Now())
+	secret, err := provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first-key", secret)
+
+	require.NoError(t, os.Remove(path))
+	secret, err = provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first-key", secret)
+}
+
+func TestFileProvider_Errors(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty")
+	writeSecret(t, empty, "\n", time.Now())
+
+	_, err := NewFileProvider(empty).Get(context.Background())
+	assert.ErrorIs(t, err, ErrEmptySecret)
+
+	_, err = NewFileProvider(filepath.Join(dir, "missing")).Get(context.Background())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
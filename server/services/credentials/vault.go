@@ -0,0 +1,221 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "eos2git.cec.lab.emc.com/ISG-Edge/hzp-go-commons/logger"
+	vault "github.com/hashicorp/vault/api"
+)
+
+// defaults of the vault config
+const (
+	DefaultVaultAuthMount      = "approle"
+	DefaultVaultKVMount        = "secret"
+	DefaultVaultSecretKey      = "api_key"
+	DefaultVaultRefresh        = 5 * time.Minute
+	DefaultVaultRequestTimeout = 10 * time.Second
+)
+
+// VaultConfig holds the settings to read a secret from a KV v2 engine of Vault, logging in with AppRole
+type VaultConfig struct {
+	Address string
+	RoleID  string
+	// SecretID is used when SecretIDFile is not set, the file is read at each login so it can be rotated
+	SecretID     Static
+	SecretIDFile string
+	// AuthMount defaults to DefaultVaultAuthMount
+	AuthMount string
+	// KVMount defaults to DefaultVaultKVMount
+	KVMount    string
+	SecretPath string
+	// SecretKey is the field of the secret holding the value, defaults to DefaultVaultSecretKey
+	SecretKey string
+	// RefreshInterval is how long the secret is cached before being read again, defaults to DefaultVaultRefresh
+	RefreshInterval time.Duration
+	// Timeout of the calls to Vault, defaults to DefaultVaultRequestTimeout
+	Timeout time.Duration
+}
+
+type vaultProvider struct {
+	config VaultConfig
+	client *vault.Client
+	now    func() time.Time
+
+	mu        sync.Mutex
+	secret    string
+	fetchedAt time.Time
+	// refresh is closed once the read in flight is done, nil when there is none, refreshErr is its error
+	refresh    chan struct{}
+	refreshErr error
+	// tokenExpiry is only used by the read in flight
+	tokenExpiry time.Time
+}
+
+// NewVaultProvider returns the provider of the secret stored in Vault. The token of the AppRole login is
+// renewed by logging in again before it expires, or when Vault rejects it.
+func NewVaultProvider(config VaultConfig) (Provider, error) {
+	if config.Address == "" || config.RoleID == "" || config.SecretPath == "" ||
+		(config.SecretID == "" && config.SecretIDFile == "") {
+		return nil, ErrInvalidVaultConfig
+	}
+	if config.AuthMount == "" {
+		config.AuthMount = DefaultVaultAuthMount
+	}
+	if config.KVMount == "" {
+		config.KVMount = DefaultVaultKVMount
+	}
+	if config.SecretKey == "" {
+		config.SecretKey = DefaultVaultSecretKey
+	}
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = DefaultVaultRefresh
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultVaultRequestTimeout
+	}
+
+	clientConfig := vault.DefaultConfig()
+	if clientConfig.Error != nil {
+		return nil, fmt.Errorf("failed to configure the vault client: %w", clientConfig.Error)
+	}
+	clientConfig.Address = config.Address
+	clientConfig.Timeout = config.Timeout
+	client, err := vault.NewClient(clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the vault client: %w", err)
+	}
+	// only the token of the AppRole login is used, not the one of the environment
+	client.ClearToken()
+
+	return &vaultProvider{config: config, client: client, now: time.Now}, nil
+}
+
+// Get returns the cached secret while it is fresh. Otherwise a single caller reads it from Vault, without holding the
+// lock, while the other callers keep using the previous secret, or wait for the read when there is none yet.
+func (p *vaultProvider) Get(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	if p.secret != "" && p.now().Sub(p.fetchedAt) < p.config.RefreshInterval {
+		defer p.mu.Unlock()
+		return p.secret, nil
+	}
+	if done := p.refresh; done != nil {
+		previous := p.secret
+		p.mu.Unlock()
+		if previous != "" {
+			return previous, nil
+		}
+		return p.wait(ctx, done)
+	}
+	done := make(chan struct{})
+	p.refresh = done
+	p.mu.Unlock()
+
+	secret, err := p.read(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.refresh, p.refreshErr = nil, err
+	close(done)
+	if err != nil {
+		if p.secret == "" {
+			return "", err
+		}
+		log.Error(ctx, nil, "", err, "failed to refresh the secret %s from vault, using the previous secret",
+			p.config.SecretPath)
+		return p.secret, nil
+	}
+	p.secret, p.fetchedAt = secret, p.now()
+	return p.secret, nil
+}
+
+// wait waits for the read in flight, which is done once done is closed, and returns its secret
+func (p *vaultProvider) wait(ctx context.Context, done <-chan struct{}) (string, error) {
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.secret == "" {
+		return "", p.refreshErr
+	}
+	return p.secret, nil
+}
+
+func (p *vaultProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fetchedAt = time.Time{}
+}
+
+// read reads the secret, logging in first when the token expired. Only one read is in flight at a time. A token revoked before its expiry is
+// rejected with a 403, in which case it logs in again once.
+func (p *vaultProvider) read(ctx context.Context) (string, error) {
+	if p.client.Token() == "" || (!p.tokenExpiry.IsZero() && !p.now().Before(p.tokenExpiry)) {
+		if err := p.login(ctx); err != nil {
+			return "", err
+		}
+	}
+	secret, err := p.client.KVv2(p.config.KVMount).Get(ctx, p.config.SecretPath)
+	var responseErr *vault.ResponseError
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusForbidden {
+		if err = p.login(ctx); err != nil {
+			return "", err
+		}
+		secret, err = p.client.KVv2(p.config.KVMount).Get(ctx, p.config.SecretPath)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the secret %s from vault: %w", p.config.SecretPath, err)
+	}
+
+	value, ok := secret.Data[p.config.SecretKey].(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %s of %s", ErrSecretFieldNotFound, p.config.SecretKey, p.config.SecretPath)
+	}
+	if value = strings.TrimSpace(value); value == "" {
+		return "", fmt.Errorf("%w: %s of %s", ErrEmptySecret, p.config.SecretKey, p.config.SecretPath)
+	}
+	return value, nil
+}
+
+// login logs in with AppRole and keeps the token until 3/4 of its lease, a lease of 0 never expires
+func (p *vaultProvider) login(ctx context.Context) error {
+	secretID := string(p.config.SecretID)
+	if p.config.SecretIDFile != "" {
+		content, err := os.ReadFile(p.config.SecretIDFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the vault secret id file %s: %w", p.config.SecretIDFile, err)
+		}
+		secretID = strings.TrimSpace(string(content))
+	}
+
+	p.client.ClearToken()
+	auth, err := p.client.Logical().WriteWithContext(ctx, "auth/"+p.config.AuthMount+"/login", map[string]any{
+		"role_id":   p.config.RoleID,
+		"secret_id": secretID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to login to vault: %w", err)
+	}
+	if auth == nil || auth.Auth == nil || auth.Auth.ClientToken == "" {
+		return errors.New("failed to login to vault: no token returned")
+	}
+
+	p.client.SetToken(auth.Auth.ClientToken)
+	p.tokenExpiry = time.Time{}
+	if auth.Auth.LeaseDuration > 0 {
+		lease := time.Duration(auth.Auth.LeaseDuration) * time.Second
+		p.tokenExpiry = p.now().Add(lease * 3 / 4)
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRoleID     = "meeting-analyzer"
+	testSecretID   = "approle-secret-id"
+	testSecretPath = "meeting-analyzer/llm"
+)
+
+// fakeVault stands in for a Vault dev server with the AppRole auth method and a KV v2 engine mounted at secret
+type fakeVault struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	apiKey string
+	// lease of the tokens in seconds, 0 never expires
+	lease  int
+	tokens map[string]bool
+	logins int
+	reads  int
+	// blocked holds the reads until it is closed, when set, and started receives each held read
+	blocked chan struct{}
+	started chan struct{}
+}
+
+func newFakeVault(t *testing.T, apiKey string) *fakeVault {
+	vault := &fakeVault{apiKey: apiKey, lease: 3600, tokens: make(map[string]bool)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /v1/auth/approle/login", vault.login)
+	mux.HandleFunc("GET /v1/secret/data/"+testSecretPath, vault.read)
+	vault.Server = httptest.NewServer(mux)
+	t.Cleanup(vault.Close)
+	return vault
+}
+
+func (v *fakeVault) login(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var body struct {
+		RoleID   string `json:"role_id"`
+		SecretID string `json:"secret_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.RoleID != testRoleID ||
+		body.SecretID != testSecretID {
+		writeVaultError(w, http.StatusBadRequest, "invalid role or secret ID")
+		return
+	}
+	v.logins++
+	token := fmt.Sprintf("token-%d", v.logins)
+	v.tokens[token] = true
+	_, _ = fmt.Fprintf(w, `{"auth":{"client_token":%q,"lease_duration":%d,"renewable":true}}`, token, v.lease)
+}
+
+func (v *fakeVault) read(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	blocked, started := v.blocked, v.started
+	v.mu.Unlock()
+	if blocked != nil {
+		started <- struct{}{}
+		<-blocked
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.tokens[r.Header.Get("X-Vault-Token")] {
+		writeVaultError(w, http.StatusForbidden, "permission denied")
+		return
+	}
+	if v.apiKey == "" {
+		writeVaultError(w, http.StatusNotFound)
+		return
+	}
+	v.reads++
+	_, _ = fmt.Fprintf(w, `{"data":{"data":{"api_key":%q},"metadata":{"version":%d,"created_time":"2024-01-01T10:00:00Z","deletion_time":"","destroyed":false}}}`,
+		v.apiKey, v.reads)
+}
+
+func (v *fakeVault) rotate(apiKey string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.apiKey = apiKey
+}
+
+// block holds the next reads until the returned function is called
+func (v *fakeVault) block() func() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.blocked, v.started = make(chan struct{}), make(chan struct{}, 10)
+	blocked := v.blocked
+	return func() {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		v.blocked = nil
+		close(blocked)
+	}
+}
+
+func (v *fakeVault) revokeTokens() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.tokens = make(map[string]bool)
+}
+
+func writeVaultError(w http.ResponseWriter, status int, errs ...string) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string][]string{"errors": errs})
+}
+
+// newTestVaultProvider returns a provider of the fake vault whose clock is advanced by the returned function
+func newTestVaultProvider(t *testing.T, vault *fakeVault, config VaultConfig) (*vaultProvider, func(time.Duration)) {
+	config.Address = vault.URL
+	config.RoleID = testRoleID
+	config.SecretPath = testSecretPath
+	if config.SecretIDFile == "" {
+		config.SecretID = testSecretID
+	}
+	provider, err := NewVaultProvider(config)
+	require.NoError(t, err)
+	now := time.Now()
+	p := provider.(*vaultProvider)
+	p.now = func() time.Time { return now }
+	return p, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestVaultProvider_Rotation(t *testing.T) {
+	vault := newFakeVault(t, "first-key")
+	provider, advance := newTestVaultProvider(t, vault, VaultConfig{RefreshInterval: time.Minute})
+
+	secret, err := provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first-key", secret)
+
+	vault.rotate("second-key")
+	advance(30 * time.Second)
+	secret, err = provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first-key", secret, "the secret is cached until the refresh interval")
+
+	advance(30 * time.Second)
+	secret, err = provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "second-key", secret)
+	assert.Equal(t, 1, vault.logins)
+	assert.Equal(t, 2, vault.reads)
+}
+
+func TestVaultProvider_Invalidate(t *testing.T) {
+	vault := newFakeVault(t, "first-key")
+	provider, _ := newTestVaultProvider(t, vault, VaultConfig{})
+	_, err := provider.Get(context.Background())
+	require.NoError(t, err)
+
+	vault.rotate("second-key")
+	provider.Invalidate()
+	secret, err := provider.Get(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "second-key", secret)
+}
+
+func TestVaultProvider_ConcurrentRefresh(t *testing.T) {
+	vault := newFakeVault(t, "first-key")
+	provider, advance := newTestVaultProvider(t, vault, VaultConfig{RefreshInterval: time.Minute})
+	unblock := vault.block()
+	secrets := make(chan string, 3)
+	for range 3 {
+		go func() {
+			secret, _ := provider.Get(context.Background())
+			secrets <- secret
+		}()
+	}
+	<-vault.started
+	unblock()
+	for range 3 {
+		assert.Equal(t, "first-key", <-secrets)
+	}
+	assert.Equal(t, 1, vault.reads, "the callers without secret wait for the same read")
+
+	vault.rotate("second-key")
+	advance(time.Minute)
+	unblock = vault.block()
+	go func() {
+		secret, _ := provider.Get(context.Background())
+		secrets <- secret
+	}()
+	<-vault.started
+	secret, err := provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first-key", secret, "the previous secret is used while the refresh is in flight")
+	unblock()
+	assert.Equal(t, "second-key", <-secrets)
+}
+
+func TestVaultProvider_TokenExpiry(t *testing.T) {
+	vault := newFakeVault(t, "api-key")
+	vault.lease = 60
+	provider, advance := newTestVaultProvider(t, vault, VaultConfig{RefreshInterval: 10 * time.Second})
+	_, err := provider.Get(context.Background())
+	require.NoError(t, err)
+
+	advance(40 * time.Second)
+	_, err = provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, vault.logins)
+
+	// the token is renewed at 3/4 of its lease
+	advance(10 * time.Second)
+	_, err = provider.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, vault.logins)
+}
+
+func TestVaultProvider_TokenRevoked(t *testing.T) {
+	vault := newFakeVault(t, "first-key")
+	provider, advance := newTestVaultProvider(t, vault, VaultConfig{RefreshInterval: time.Minute})
+	_, err := provider.Get(context.Background())
+	require.NoError(t, err)
+
+	vault.revokeTokens()
+	vault.rotate("second-key")
+	advance(time.Minute)
+	secret, err := provider.Get(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "second-key", secret)
+	assert.Equal(t, 2, vault.logins)
+}
+
+func TestVaultProvider_SecretIDFile(t *testing.T) {
+	vault := newFakeVault(t, "api-key")
+	path := filepath.Join(t.TempDir(), "secret-id")
+	require.NoError(t, os.WriteFile(path, []byte(testSecretID+"\n"), 0o600))
+	provider, _ := newTestVaultProvider(t, vault, VaultConfig{SecretIDFile: path})
+
+	secret, err := provider.Get(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "api-key", secret)
+}
+
+func TestVaultProvider_KeepsPreviousSecret(t *testing.T) {
+	vault := newFakeVault(t, "first-key")
+	provider, advance := newTestVaultProvider(t, vault, VaultConfig{RefreshInterval: time.Minute})
+	_, err := provider.Get(context.Background())
+	require.NoError(t, err)
+
+	vault.rotate("")
+	advance(time.Minute)
+	secret, err := provider.Get(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "first-key", secret)
+}
+
+func TestVaultProvider_Errors(t *testing.T) {
+	vault := newFakeVault(t, "api-key")
+
+	_, err := NewVaultProvider(VaultConfig{Address: vault.URL, RoleID: testRoleID, SecretPath: testSecretPath})
+	assert.ErrorIs(t, err, ErrInvalidVaultConfig)
+
+	provider, err := NewVaultProvider(VaultConfig{Address: vault.URL, RoleID: testRoleID, SecretID: "wrong-secret-id",
+		SecretPath: testSecretPath})
+	require.NoError(t, err)
+	_, err = provider.Get(context.Background())
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "wrong-secret-id")
+
+	provider, _ = newTestVaultProvider(t, vault, VaultConfig{SecretKey: "token"})
+	_, err = provider.Get(context.Background())
+	assert.ErrorIs(t, err, ErrSecretFieldNotFound)
+}
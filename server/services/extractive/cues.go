@@ -0,0 +1,57 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package extractive
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The decisions and action items are found by the English phrases announcing them
+var (
+	decisionPattern = regexp.MustCompile(`(?i)\b(?:(?:we|i|they|the team)(?: have|'ve)? (?:decided|agreed|settled on|chose|opted)|` +
+		`decision is|let'?s go with|we(?:'ll| will) go with|it'?s decided|approved)\b`)
+	// commitmentPattern is a speaker committing to a task
+	commitmentPattern = regexp.MustCompile(`(?i)\b(?:i'll|i will|i'm going to|i am going to|i can take|i'll take|` +
+		`let me|i'll make sure|i will make sure)\b`)
+	// assignmentPattern is a task given to somebody, possibly named before the request
+	assignmentPattern = regexp.MustCompile(`(?:\b([A-Z][\p{L}'-]+),? )?\b(?i:can you|could you|would you|please|` +
+		`will you|you'll need to|needs to|action item|to-?do|follow up on)\b`)
+	duePattern = regexp.MustCompile(`(?i)\b(?:(?:by|before|until|due|no later than)\s+(?:the\s+)?)?(?:` +
+		`end of (?:the |next )?(?:day|week|month)|eod|tomorrow|today|tonight|next (?:week|month)|` +
+		`(?:next )?(?:monday|tuesday|wednesday|thursday|friday|saturday|sunday)|\d{4}-\d{2}-\d{2}|` +
+		`(?:january|february|march|april|may|june|july|august|september|october|november|december) \d{1,2}(?:st|nd|rd|th)?)\b`)
+)
+
+// isDecision tells whether the sentence announces a decision
+func isDecision(text string) bool {
+	return decisionPattern.MatchString(text)
+}
+
+// actionOwner tells whether the sentence gives a task and returns its owner: the speaker committing to it, the name
+// addressed by the request, or nil when unknown
+func actionOwner(speaker string, text string) (*string, bool) {
+	if commitmentPattern.MatchString(text) && !strings.HasSuffix(text, "?") {
+		if speaker == "" {
+			return nil, true
+		}
+		return &speaker, true
+	}
+	match := assignmentPattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil, false
+	}
+	if match[1] != "" && !stopWords[strings.ToLower(match[1])] {
+		return &match[1], true
+	}
+	return nil, true
+}
+
+// duePhrase returns the deadline said in the sentence, e.g. "by Friday", nil when there is none
+func duePhrase(text string) *string {
+	phrase := duePattern.FindString(text)
+	if phrase == "" {
+		return nil
+	}
+	return &phrase
+}
@@ -0,0 +1,58 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package extractive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDecision(t *testing.T) {
+	assert.True(t, isDecision("So we decided to postpone the launch."))
+	assert.True(t, isDecision("Let's go with the second vendor."))
+	assert.True(t, isDecision("The team agreed on weekly demos."))
+	assert.False(t, isDecision("We should decide next week."))
+}
+
+func TestActionOwner(t *testing.T) {
+	tests := []struct {
+		name          string
+		speaker       string
+		text          string
+		expectedOwner *string
+		expectedFound bool
+	}{
+		{name: "Commitment", speaker: "Alice", text: "I'll send the notes by Friday.", expectedOwner: ptr("Alice"),
+			expectedFound: true},
+		{name: "CommitmentWithoutSpeaker", text: "I will update the roadmap.", expectedFound: true},
+		{name: "QuestionIsNoCommitment", speaker: "Alice", text: "Will I need to present?"},
+		{name: "NamedRequest", speaker: "Alice", text: "Bob, can you review the pull request?", expectedOwner: ptr("Bob"),
+			expectedFound: true},
+		{name: "NamedNeed", speaker: "Alice", text: "Carol needs to renew the certificate.", expectedOwner: ptr("Carol"),
+			expectedFound: true},
+		{name: "AnonymousRequest", speaker: "Alice", text: "Please file a ticket for it.", expectedFound: true},
+		{name: "SentenceStartIsNoName", speaker: "Alice", text: "So can you check the logs?", expectedFound: true},
+		{name: "NoTask", speaker: "Alice", text: "The demo went well."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, found := actionOwner(tt.speaker, tt.text)
+
+			assert.Equal(t, tt.expectedFound, found)
+			assert.Equal(t, tt.expectedOwner, owner)
+		})
+	}
+}
+
+func TestDuePhrase(t *testing.T) {
+	assert.Equal(t, ptr("by Friday"), duePhrase("I'll send the notes by Friday."))
+	assert.Equal(t, ptr("before the end of the week"), duePhrase("Ship it before the end of the week please."))
+	assert.Equal(t, ptr("tomorrow"), duePhrase("I'll call them tomorrow."))
+	assert.Equal(t, ptr("by March 3rd"), duePhrase("Renew it by March 3rd."))
+	assert.Nil(t, duePhrase("I'll send the notes."))
+}
+
+func ptr(value string) *string {
+	return &value
+}
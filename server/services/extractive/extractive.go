@@ -0,0 +1,196 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package extractive summarizes meetings without a model, by extracting their most central sentences with TextRank.
+// Its provider answers the summary requests of the service like an llm provider, so that a summary is still produced
+// when no model is reachable.
+package extractive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"meeting-analyzer/server/services/llm"
+	"meeting-analyzer/server/services/summary"
+	"regexp"
+	"strings"
+)
+
+// Model is the model name reported by the extractive summaries
+const Model = "extractive-textrank"
+
+const (
+	// chunkTokens is large since ranking sentences is cheap, the provider also merges partial summaries when the
+	// transcript was split for another provider
+	chunkTokens = 50000
+	// summarySentences is the count of sentences of the prose summary
+	summarySentences = 3
+	maxKeyPoints     = 7
+	maxDecisions     = 10
+	maxActionItems   = 20
+)
+
+// segmentPattern matches a segment of a transcript rendered by the transcript prompt template:
+// "speaker","timestamp" followed by "content" on the next lines
+var segmentPattern = regexp.MustCompile(`(?ms)^"([^"\n]*)","[^"\n]*"\n"(.*?)"$`)
+
+type provider struct{}
+
+// NewProvider creates the extractive summarizer. It reads the transcript from the user message of the request,
+// or the partial summaries to merge when it is a JSON array, ignores the instructions and answers with a summary.Summary.
+// The decisions and action items are found by English phrases like "we decided" or "I'll".
+func NewProvider() llm.LLMProvider {
+	return provider{}
+}
+
+func (provider) Model() string {
+	return Model
+}
+
+func (provider) Limits() llm.Limits {
+	return llm.Limits{ChunkTokens: chunkTokens}
+}
+
+func (provider) Complete(ctx context.Context, request llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", llm.ErrCompletionCanceled, err)
+	}
+	content := ""
+	for _, message := range request.Messages {
+		if message.Role == llm.RoleUser {
+			content = message.Content
+			break
+		}
+	}
+
+	var result *summary.Summary
+	var partials []summary.Summary
+	if strings.HasPrefix(strings.TrimSpace(content), "[") && json.Unmarshal([]byte(content), &partials) == nil {
+		result = merge(partials)
+	} else {
+		result = summarize(parseTranscript(content))
+	}
+	if strings.TrimSpace(result.Summary) == "" {
+		return nil, llm.ErrEmptyCompletion
+	}
+	answer, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &llm.CompletionResponse{Content: string(answer), Model: Model}, nil
+}
+
+// parseTranscript returns the sentences of the transcript, the content is read as a single unknown speaker when it
+// is not a rendered transcript
+func parseTranscript(content string) []sentence {
+	sentences := make([]sentence, 0)
+	matches := segmentPattern.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		matches = [][]string{{content, "", content}}
+	}
+	for _, match := range matches {
+		for _, text := range splitSentences(match[2]) {
+			sentences = append(sentences, newSentence(match[1], text))
+		}
+	}
+	return sentences
+}
+
+// summarize extracts the summary of the sentences of a transcript: the best ranked sentences make the prose summary
+// and the key points, in the order they were said
+func summarize(sentences []sentence) *summary.Summary {
+	scores := rank(sentences)
+	result := &summary.Summary{
+		KeyPoints:   make([]string, 0),
+		Decisions:   make([]string, 0),
+		ActionItems: make([]summary.ActionItem, 0),
+	}
+	result.Summary = prose(sentences, scores)
+	for _, i := range top(scores, maxKeyPoints) {
+		result.KeyPoints = append(result.KeyPoints, attribute(sentences[i]))
+	}
+
+	for _, s := range sentences {
+		if isDecision(s.text) && len(result.Decisions) < maxDecisions {
+			result.Decisions = append(result.Decisions, attribute(s))
+		}
+		if owner, ok := actionOwner(s.speaker, s.text); ok && len(result.ActionItems) < maxActionItems {
+			quote := s.text
+			result.ActionItems = append(result.ActionItems, summary.ActionItem{
+				Description: s.text,
+				Owner:       owner,
+				DuePhrase:   duePhrase(s.text),
+				Quote:       &quote,
+			})
+		}
+	}
+	return result
+}
+
+// merge combines the partial summaries of the parts of a transcript: the prose summary is extracted from the partial
+// ones, the key points are ranked again and the repeated decisions and action items are removed
+func merge(partials []summary.Summary) *summary.Summary {
+	sentences := make([]sentence, 0)
+	keyPoints := make([]sentence, 0)
+	result := &summary.Summary{
+		KeyPoints:   make([]string, 0),
+		Decisions:   make([]string, 0),
+		ActionItems: make([]summary.ActionItem, 0),
+	}
+	seen := make(map[string]bool)
+	for _, partial := range partials {
+		for _, text := range splitSentences(partial.Summary) {
+			sentences = append(sentences, newSentence("", text))
+		}
+		for _, point := range partial.KeyPoints {
+			if !seen["point:"+normalize(point)] {
+				seen["point:"+normalize(point)] = true
+				keyPoints = append(keyPoints, newSentence("", point))
+			}
+		}
+		for _, decision := range partial.Decisions {
+			if !seen["decision:"+normalize(decision)] && len(result.Decisions) < maxDecisions {
+				seen["decision:"+normalize(decision)] = true
+				result.Decisions = append(result.Decisions, decision)
+			}
+		}
+		for _, item := range partial.ActionItems {
+			if !seen["action:"+normalize(item.Description)] && len(result.ActionItems) < maxActionItems {
+				seen["action:"+normalize(item.Description)] = true
+				result.ActionItems = append(result.ActionItems, item)
+			}
+		}
+	}
+
+	result.Summary = prose(sentences, rank(sentences))
+	for _, i := range top(rank(keyPoints), maxKeyPoints) {
+		result.KeyPoints = append(result.KeyPoints, keyPoints[i].text)
+	}
+	return result
+}
+
+// prose joins the best ranked sentences, or the first ones when none could be ranked
+func prose(sentences []sentence, scores []float64) string {
+	indexes := top(scores, summarySentences)
+	if len(indexes) == 0 {
+		for i := 0; i < min(summarySentences, len(sentences)); i++ {
+			indexes = append(indexes, i)
+		}
+	}
+	texts := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		texts = append(texts, sentences[i].text)
+	}
+	return strings.Join(texts, " ")
+}
+
+// attribute prefixes the sentence with its speaker, e.g. "Alice: we ship on Monday."
+func attribute(s sentence) string {
+	if s.speaker == "" {
+		return s.text
+	}
+	return s.speaker + ": " + s.text
+}
+
+func normalize(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
@@ -0,0 +1,118 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package extractive
+
+import (
+	"context"
+	"encoding/json"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/services/llm"
+	"meeting-analyzer/server/services/prompts"
+	"meeting-analyzer/server/services/summary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func renderTranscript(t *testing.T, segments ...models.Transcription) string {
+	tmpl, err := prompts.Lookup(prompts.DefaultStyle)
+	require.NoError(t, err)
+	transcript, err := tmpl.Transcript(&models.MeetingDetails{MeetingTitle: "Release sync", Transcription: segments})
+	require.NoError(t, err)
+	return transcript
+}
+
+func complete(t *testing.T, content string) *summary.Summary {
+	res, err := NewProvider().Complete(context.Background(), llm.CompletionRequest{Messages: []llm.Message{
+		{Role: llm.RoleSystem, Content: "You summarize meeting transcriptions."},
+		{Role: llm.RoleUser, Content: content},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, Model, res.Model)
+	structured, err := summary.Parse(res.Content)
+	require.NoError(t, err)
+	return structured
+}
+
+func TestComplete_Transcript(t *testing.T) {
+	transcript := renderTranscript(t,
+		models.Transcription{MemberName: "Alice", Content: "The release candidate failed the database migration tests. " +
+			"The migration locks the orders table for ten minutes."},
+		models.Transcription{MemberName: "Bob", Content: "Yes."},
+		models.Transcription{MemberName: "Bob", Content: "We decided to run the migration in batches. " +
+			"I'll rewrite the migration script by Friday."},
+		models.Transcription{MemberName: "Carol", Content: "Alice, can you warn the support team about the delay?"},
+	)
+
+	structured := complete(t, transcript)
+
+	assert.Contains(t, structured.Summary, "migration")
+	assert.NotContains(t, structured.Summary, "Yes.")
+	assert.Contains(t, structured.KeyPoints, "Alice: The migration locks the orders table for ten minutes.")
+	assert.Equal(t, []string{"Bob: We decided to run the migration in batches."}, structured.Decisions)
+	require.Len(t, structured.ActionItems, 2)
+	assert.Equal(t, summary.ActionItem{
+		Description: "I'll rewrite the migration script by Friday.",
+		Owner:       ptr("Bob"),
+		DuePhrase:   ptr("by Friday"),
+		Quote:       ptr("I'll rewrite the migration script by Friday."),
+	}, structured.ActionItems[0])
+	assert.Equal(t, ptr("Alice"), structured.ActionItems[1].Owner)
+}
+
+func TestComplete_PlainText(t *testing.T) {
+	structured := complete(t, "The vendor contract expires in June. Renewal costs rose by ten percent.")
+
+	assert.Equal(t, "The vendor contract expires in June. Renewal costs rose by ten percent.", structured.Summary)
+	assert.Equal(t, []string{"The vendor contract expires in June.", "Renewal costs rose by ten percent."},
+		structured.KeyPoints)
+}
+
+func TestComplete_TrivialTranscript(t *testing.T) {
+	structured := complete(t, renderTranscript(t,
+		models.Transcription{MemberName: "Alice", Content: "Hi."},
+		models.Transcription{MemberName: "Bob", Content: "Bye."},
+	))
+
+	assert.Equal(t, "Hi. Bye.", structured.Summary)
+	assert.Empty(t, structured.KeyPoints)
+}
+
+func TestComplete_MergesPartialSummaries(t *testing.T) {
+	partials, err := json.Marshal([]summary.Summary{
+		{
+			Summary:     "The migration failed in staging.",
+			KeyPoints:   []string{"Alice: The migration failed in staging."},
+			Decisions:   []string{"Bob: We decided to run the migration in batches."},
+			ActionItems: []summary.ActionItem{{Description: "I'll rewrite the migration script.", Owner: ptr("Bob")}},
+		},
+		{
+			Summary:     "The migration will run in batches next week.",
+			KeyPoints:   []string{"alice:  the migration failed in staging.", "Bob: Batches run next week."},
+			Decisions:   []string{"Bob: We decided to run the migration in batches."},
+			ActionItems: []summary.ActionItem{{Description: "I'll rewrite the migration script.", Owner: ptr("Bob")}},
+		},
+	})
+	require.NoError(t, err)
+
+	structured := complete(t, string(partials))
+
+	assert.Equal(t, "The migration failed in staging. The migration will run in batches next week.", structured.Summary)
+	assert.Equal(t, []string{"Alice: The migration failed in staging.", "Bob: Batches run next week."},
+		structured.KeyPoints)
+	assert.Len(t, structured.Decisions, 1)
+	assert.Len(t, structured.ActionItems, 1)
+}
+
+func TestComplete_Errors(t *testing.T) {
+	_, err := NewProvider().Complete(context.Background(), llm.CompletionRequest{Messages: []llm.Message{
+		{Role: llm.RoleUser, Content: "  "},
+	}})
+	assert.ErrorIs(t, err, llm.ErrEmptyCompletion)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewProvider().Complete(ctx, llm.CompletionRequest{})
+	assert.ErrorIs(t, err, llm.ErrCompletionCanceled)
+}
@@ -0,0 +1,192 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package extractive
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	damping              = 0.85
+	maxIterations        = 100
+	convergenceThreshold = 1e-6
+	// minRankedWords is the count of content words a sentence needs to be ranked, "Yes, sounds good." has none
+	minRankedWords = 2
+)
+
+// stopWords are the English words carrying no topic, ignored when comparing sentences
+var stopWords = toSet(strings.Fields(`a about above after again against all also am an and any are as at be because
+	been before being below between both but by can could did do does doing down during each few for from further get
+	got had has have having he her here hers herself him himself his how i if in into is it its itself just let lets
+	like me more most my myself no nor not now of off on once only or other our ours ourselves out over own really right
+	same she should so some such than that the their theirs them themselves then there these they this those through to
+	too under until up us very was we were what when where which while who whom why will with would yeah yes you your
+	yours yourself yourselves okay ok um uh oh well think know going gonna want thing things`))
+
+// sentence is a sentence of the transcript and the speaker who said it
+type sentence struct {
+	speaker string
+	text    string
+	words   map[string]bool
+}
+
+func newSentence(speaker string, text string) sentence {
+	return sentence{speaker: speaker, text: text, words: toSet(contentWords(text))}
+}
+
+// contentWords returns the lower case words of the text which are not stop words
+func contentWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(field, "'")
+		if len([]rune(field)) < 2 || stopWords[field] {
+			continue
+		}
+		words = append(words, field)
+	}
+	return words
+}
+
+// splitSentences cuts the text after the sentence terminators followed by a space
+func splitSentences(text string) []string {
+	sentences := make([]string, 0)
+	runes := []rune(strings.TrimSpace(text))
+	start := 0
+	for i, r := range runes {
+		if !strings.ContainsRune(".!?", r) || (i+1 < len(runes) && !unicode.IsSpace(runes[i+1])) {
+			continue
+		}
+		if s := strings.TrimSpace(string(runes[start : i+1])); s != "" {
+			sentences = append(sentences, s)
+		}
+		start = i + 1
+	}
+	if s := strings.TrimSpace(string(runes[start:])); s != "" {
+		sentences = append(sentences, s)
+	}
+	return sentences
+}
+
+// similarity is the TextRank similarity of two sentences: their shared words normalized by their lengths, so that
+// long sentences are not favoured
+func similarity(a, b sentence) float64 {
+	if len(a.words) > len(b.words) {
+		a, b = b, a
+	}
+	shared := 0
+	for word := range a.words {
+		if b.words[word] {
+			shared++
+		}
+	}
+	if shared == 0 {
+		return 0
+	}
+	return float64(shared) / (math.Log(float64(1+len(a.words))) + math.Log(float64(1+len(b.words))))
+}
+
+// rank scores the sentences with TextRank, a PageRank over the graph of the sentences weighted by their similarity.
+// The random jumps are personalized by speaker: every speaker gets the same share, spread over their sentences, so
+// that the sentences of a quiet participant are not drowned out by the ones of the most talkative. The sentences
+// with too few content words are not ranked and score 0.
+func rank(sentences []sentence) []float64 {
+	n := len(sentences)
+	scores := make([]float64, n)
+	ranked := make([]int, 0, n)
+	perSpeaker := make(map[string]int)
+	for i, s := range sentences {
+		if len(s.words) >= minRankedWords {
+			ranked = append(ranked, i)
+			perSpeaker[s.speaker]++
+		}
+	}
+	if len(ranked) == 0 {
+		return scores
+	}
+
+	jump := make([]float64, n)
+	for _, i := range ranked {
+		jump[i] = 1 / float64(len(perSpeaker)*perSpeaker[sentences[i].speaker])
+		scores[i] = 1 / float64(len(ranked))
+	}
+	type edge struct {
+		to     int
+		weight float64
+	}
+	edges := make([][]edge, n)
+	outWeights := make([]float64, n)
+	for x, i := range ranked {
+		for _, j := range ranked[x+1:] {
+			if w := similarity(sentences[i], sentences[j]); w > 0 {
+				edges[i] = append(edges[i], edge{to: j, weight: w})
+				edges[j] = append(edges[j], edge{to: i, weight: w})
+				outWeights[i] += w
+				outWeights[j] += w
+			}
+		}
+	}
+
+	next := make([]float64, n)
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		// the score of the sentences without edges jumps like a random jump
+		dangling := 0.0
+		for _, i := range ranked {
+			if outWeights[i] == 0 {
+				dangling += scores[i]
+			}
+		}
+		for _, i := range ranked {
+			next[i] = (1 - damping + damping*dangling) * jump[i]
+		}
+		for _, i := range ranked {
+			for _, e := range edges[i] {
+				next[e.to] += damping * scores[i] * e.weight / outWeights[i]
+			}
+		}
+		delta := 0.0
+		for _, i := range ranked {
+			delta += math.Abs(next[i] - scores[i])
+		}
+		scores, next = next, scores
+		if delta < convergenceThreshold {
+			break
+		}
+	}
+	return scores
+}
+
+// top returns the indexes of the count best scores in increasing order, skipping the null scores
+func top(scores []float64, count int) []int {
+	indexes := make([]int, 0, len(scores))
+	for i, score := range scores {
+		if score > 0 {
+			indexes = append(indexes, i)
+		}
+	}
+	slices.SortStableFunc(indexes, func(a, b int) int {
+		switch {
+		case scores[a] > scores[b]:
+			return -1
+		case scores[a] < scores[b]:
+			return 1
+		}
+		return 0
+	})
+	indexes = indexes[:min(count, len(indexes))]
+	slices.Sort(indexes)
+	return indexes
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
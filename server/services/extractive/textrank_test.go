@@ -0,0 +1,96 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package extractive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{name: "Terminators", text: "We ship. Is it ready? Yes!", expected: []string{"We ship.", "Is it ready?", "Yes!"}},
+		{name: "NoTerminator", text: " the release is late ", expected: []string{"the release is late"}},
+		{name: "DecimalsAndVersions", text: "Upgrade to 1.2.3 now. Done", expected: []string{"Upgrade to 1.2.3 now.", "Done"}},
+		{name: "Empty", text: "  ", expected: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, splitSentences(tt.text))
+		})
+	}
+}
+
+func TestContentWords(t *testing.T) {
+	assert.Equal(t, []string{"database", "migration", "failed", "twice", "friday's", "run"},
+		contentWords("The database migration failed twice on Friday's run, um, I think."))
+}
+
+func TestSimilarity(t *testing.T) {
+	a := newSentence("", "The database migration failed")
+	b := newSentence("", "Rollback the database migration")
+	c := newSentence("", "Lunch is at noon")
+
+	assert.Greater(t, similarity(a, b), 0.0)
+	assert.Equal(t, similarity(a, b), similarity(b, a))
+	assert.Equal(t, 0.0, similarity(a, c))
+}
+
+func TestRank(t *testing.T) {
+	sentences := []sentence{
+		newSentence("Alice", "The database migration failed in staging."),
+		newSentence("Alice", "The migration script locks the database tables."),
+		newSentence("Alice", "We should split the migration script in batches."),
+		newSentence("Bob", "Lunch is at noon."),
+		newSentence("Bob", "Okay."),
+	}
+
+	scores := rank(sentences)
+
+	require.Len(t, scores, 5)
+	// the sentences sharing the topic of the meeting rank first
+	assert.Greater(t, scores[1], scores[3])
+	// a sentence without content words is not ranked
+	assert.Equal(t, 0.0, scores[4])
+	total := 0.0
+	for _, score := range scores {
+		total += score
+	}
+	assert.InDelta(t, 1.0, total, 1e-3)
+}
+
+func TestRank_SpeakerWeighting(t *testing.T) {
+	// Alice talks about four unrelated topics, Bob about a fifth one
+	sentences := []sentence{
+		newSentence("Alice", "Budget review pending."),
+		newSentence("Alice", "Office relocation planned."),
+		newSentence("Alice", "Printer toner empty."),
+		newSentence("Alice", "Parking permits renewed."),
+		newSentence("Bob", "Hiring freeze announced."),
+	}
+
+	scores := rank(sentences)
+
+	// the speakers share the random jumps evenly, instead of a fifth for Bob
+	assert.InDelta(t, 0.5, scores[4], 1e-3)
+	assert.InDelta(t, 0.125, scores[0], 1e-3)
+	assert.Equal(t, []int{4}, top(scores, 1))
+}
+
+func TestRank_NoSentence(t *testing.T) {
+	assert.Empty(t, rank(nil))
+	assert.Equal(t, []float64{0}, rank([]sentence{newSentence("", "Yes.")}))
+}
+
+func TestTop(t *testing.T) {
+	scores := []float64{0.1, 0.4, 0, 0.3, 0.2}
+
+	assert.Equal(t, []int{1, 3}, top(scores, 2))
+	assert.Equal(t, []int{0, 1, 3, 4}, top(scores, 10))
+}
@@ -0,0 +1,137 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package jobs provides a bounded pool of workers processing background jobs
+package jobs
+
+import (
+	"context"
+	"errors"
+	"meeting-analyzer/server/commons/tenancy"
+	"sync"
+	"time"
+)
+
+// errors
+var (
+	ErrQueueFull   = errors.New("job queue is full")
+	ErrPoolStopped = errors.New("job pool is stopped")
+)
+
+// Job identifies a unit of background work, SummaryStyle names the prompt templates of the summary and
+// SummaryEngine the engine generating it. Tenant is the tenant which requested the job, its estate owns the meeting.
+type Job struct {
+	ID            string
+	MeetingID     string
+	Tenant        tenancy.Tenant
+	SummaryStyle  string
+	SummaryEngine string
+}
+
+// Handler processes a single job and records its outcome
+type Handler func(ctx context.Context, job Job)
+
+// DefaultLease is how long the jobs of an instance stay leased to it without renewal
+const DefaultLease = 2 * time.Minute
+
+// Config holds the sizing of a Pool. InstanceID identifies the instance of the service running the pool, its jobs are
+// leased to it for Lease and the leases renewed while it runs, see repositories.Repository.RenewJobLeases. A stable
+// InstanceID, such as the host name, lets a restarted instance fail its interrupted jobs at once.
+type Config struct {
+	Workers    int
+	QueueSize  int
+	InstanceID string
+	Lease      time.Duration
+}
+
+// Pool runs a fixed number of workers consuming a bounded queue of jobs
+type Pool struct {
+	config  Config
+	handler Handler
+	queue   chan Job
+	wg      sync.WaitGroup
+	mu      sync.RWMutex
+	stopped bool
+	cancel  context.CancelFunc
+}
+
+// NewPool creates a pool, workers are started by Start
+func NewPool(config Config, handler Handler) *Pool {
+	if config.Workers < 1 {
+		config.Workers = 1
+	}
+	if config.QueueSize < 0 {
+		config.QueueSize = 0
+	}
+	return &Pool{
+		config:  config,
+		handler: handler,
+		queue:   make(chan Job, config.QueueSize),
+	}
+}
+
+// Start launches the workers, they stop when ctx is canceled or Stop is called
+func (p *Pool) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+	for i := 0; i < p.config.Workers; i++ {
+		p.wg.Add(1)
+		go p.work(ctx)
+	}
+}
+
+func (p *Pool) work(ctx context.Context) {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job, ok := <-p.queue:
+			if !ok {
+				return
+			}
+			p.handler(ctx, job)
+		}
+	}
+}
+
+// Submit enqueues the job without blocking, ErrQueueFull is returned when no slot is free
+func (p *Pool) Submit(job Job) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.stopped {
+		return ErrPoolStopped
+	}
+	select {
+	case p.queue <- job:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Stop rejects new jobs and waits for the queued ones to drain.
+// When ctx expires first the running jobs are canceled and ctx error is returned.
+func (p *Pool) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.stopped {
+		p.stopped = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		if p.cancel != nil {
+			p.cancel()
+		}
+		<-done
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package jobs
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_ProcessesJobs(t *testing.T) {
+	var mu sync.Mutex
+	processed := make([]string, 0)
+	pool := NewPool(Config{Workers: 2, QueueSize: 10}, func(_ context.Context, job Job) {
+		mu.Lock()
+		defer mu.Unlock()
+		processed = append(processed, job.ID)
+	})
+	pool.Start(context.Background())
+
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, pool.Submit(Job{ID: id}))
+	}
+	require.NoError(t, pool.Stop(context.Background()))
+
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, processed)
+	assert.ErrorIs(t, pool.Submit(Job{ID: "d"}), ErrPoolStopped)
+}
+
+func TestPool_QueueFull(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	pool := NewPool(Config{Workers: 1, QueueSize: 1}, func(_ context.Context, _ Job) {
+		started <- struct{}{}
+		<-release
+	})
+	pool.Start(context.Background())
+
+	require.NoError(t, pool.Submit(Job{ID: "running"}))
+	<-started
+	require.NoError(t, pool.Submit(Job{ID: "queued"}))
+	assert.ErrorIs(t, pool.Submit(Job{ID: "rejected"}), ErrQueueFull)
+
+	close(release)
+	<-started
+	require.NoError(t, pool.Stop(context.Background()))
+}
+
+func TestPool_BoundedConcurrency(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	pool := NewPool(Config{Workers: 3, QueueSize: 20}, func(_ context.Context, _ Job) {
+		current := running.Add(1)
+		for {
+			observed := maxRunning.Load()
+			if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+	})
+	pool.Start(context.Background())
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, pool.Submit(Job{}))
+	}
+	require.NoError(t, pool.Stop(context.Background()))
+
+	assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+}
+
+func TestPool_StopTimeoutCancelsRunningJobs(t *testing.T) {
+	canceled := make(chan struct{})
+	started := make(chan struct{})
+	pool := NewPool(Config{Workers: 1, QueueSize: 1}, func(ctx context.Context, _ Job) {
+		close(started)
+		<-ctx.Done()
+		close(canceled)
+	})
+	pool.Start(context.Background())
+	require.NoError(t, pool.Submit(Job{}))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, pool.Stop(ctx), context.DeadlineExceeded)
+	<-canceled
+}
@@ -0,0 +1,95 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package llm
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+type outcome int
+
+const (
+	// outcomeSuccess is an answer of the provider, even an error like a 400
+	outcomeSuccess outcome = iota
+	// outcomeFailure is a transient failure telling the provider may be down
+	outcomeFailure
+	// outcomeIgnored is a call abandoned by the caller, which tells nothing about the provider
+	outcomeIgnored
+)
+
+// circuitBreaker opens after threshold consecutive failures and rejects the calls during cooldown. It then lets a
+// single trial call through, which closes it again on success or reopens it on failure.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// ready tells whether a call would be allowed, without taking the trial call of a half open breaker
+func (b *circuitBreaker) ready() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case b.state == breakerOpen && b.now().Sub(b.openedAt) < b.cooldown,
+		b.state == breakerHalfOpen && b.trial:
+		return ErrProviderUnavailable
+	}
+	return nil
+}
+
+// allow returns ErrProviderUnavailable when the call must not reach the provider, else the caller must record its
+// outcome
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrProviderUnavailable
+		}
+		b.state = breakerHalfOpen
+		b.trial = true
+	case breakerHalfOpen:
+		if b.trial {
+			return ErrProviderUnavailable
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+func (b *circuitBreaker) record(result outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	switch result {
+	case outcomeSuccess:
+		b.state = breakerClosed
+		b.failures = 0
+	case outcomeFailure:
+		b.failures++
+		if b.state == breakerHalfOpen || b.failures >= b.threshold {
+			b.state = breakerOpen
+			b.openedAt = b.now()
+		}
+	}
+}
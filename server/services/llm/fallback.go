@@ -0,0 +1,65 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package llm
+
+import (
+	"context"
+
+	log "eos2git.cec.lab.emc.com/ISG-Edge/hzp-go-commons/logger"
+)
+
+type fallbackProvider struct {
+	primary  LLMProvider
+	fallback LLMProvider
+}
+
+// NewFallbackProvider answers with the fallback provider when the primary one is unreachable: its calls fail with a
+// transient error, like a network error or a 503, or with ErrProviderUnavailable while its circuit breaker is open.
+// The other errors, like a 400, are returned as is. The chunks are sized for the primary provider.
+func NewFallbackProvider(primary LLMProvider, fallback LLMProvider) StreamingProvider {
+	return &fallbackProvider{primary: primary, fallback: fallback}
+}
+
+func (p *fallbackProvider) Model() string {
+	return p.primary.Model()
+}
+
+func (p *fallbackProvider) Limits() Limits {
+	return p.primary.Limits()
+}
+
+func (p *fallbackProvider) Complete(ctx context.Context, request CompletionRequest) (*CompletionResponse, error) {
+	response, err := p.primary.Complete(ctx, request)
+	if !p.unreachable(ctx, err) {
+		return response, err
+	}
+	return p.fallback.Complete(ctx, request)
+}
+
+// Stream falls back when the primary provider fails before its answer starts, the fallback answer is sent as a
+// single delta
+func (p *fallbackProvider) Stream(ctx context.Context, request CompletionRequest) (*Stream, error) {
+	if streaming, ok := p.primary.(StreamingProvider); ok {
+		stream, err := streaming.Stream(ctx, request)
+		if !p.unreachable(ctx, err) {
+			return stream, err
+		}
+	} else {
+		response, err := p.primary.Complete(ctx, request)
+		if !p.unreachable(ctx, err) {
+			return completedStream(ctx, response, err)
+		}
+	}
+	response, err := p.fallback.Complete(ctx, request)
+	return completedStream(ctx, response, err)
+}
+
+// unreachable tells whether the error of the primary provider calls for the fallback, and logs it
+func (p *fallbackProvider) unreachable(ctx context.Context, err error) bool {
+	if err == nil || classify(ctx, err) != outcomeFailure {
+		return false
+	}
+	log.Error(ctx, nil, "", err, "llm provider %s is unreachable, falling back to %s", p.primary.Model(),
+		p.fallback.Model())
+	return true
+}
@@ -0,0 +1,116 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package llm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// offlineProvider answers every completion with the same content
+type offlineProvider struct {
+	calls int
+}
+
+func (p *offlineProvider) Model() string {
+	return "offline"
+}
+
+func (p *offlineProvider) Limits() Limits {
+	return Limits{ChunkTokens: 1}
+}
+
+func (p *offlineProvider) Complete(context.Context, CompletionRequest) (*CompletionResponse, error) {
+	p.calls++
+	return &CompletionResponse{Content: "offline summary", Model: "offline"}, nil
+}
+
+func TestFallbackProvider_Complete(t *testing.T) {
+	tests := []struct {
+		name            string
+		gateway         *fakeGateway
+		expectedContent string
+		expectedCode    int
+		expectedCalls   int
+	}{
+		{name: "PrimaryAnswers", gateway: &fakeGateway{}, expectedContent: "summary"},
+		{name: "PrimaryUnreachable", gateway: &fakeGateway{statuses: []int{503, 503}}, expectedContent: "offline summary",
+			expectedCalls: 1},
+		{name: "PrimaryRejectsRequest", gateway: &fakeGateway{statuses: []int{400}}, expectedCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary, _ := newTestResilientProvider(t, tt.gateway, ResilienceConfig{MaxAttempts: 2})
+			fallback := &offlineProvider{}
+			provider := NewFallbackProvider(primary, fallback)
+
+			res, err := provider.Complete(context.Background(), CompletionRequest{})
+
+			if tt.expectedCode != 0 {
+				var statusErr *StatusError
+				require.ErrorAs(t, err, &statusErr)
+				assert.Equal(t, tt.expectedCode, statusErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedContent, res.Content)
+			}
+			assert.Equal(t, tt.expectedCalls, fallback.calls)
+		})
+	}
+}
+
+func TestFallbackProvider_BreakerOpen(t *testing.T) {
+	gateway := &fakeGateway{statuses: []int{502}}
+	primary, _ := newTestResilientProvider(t, gateway, ResilienceConfig{MaxAttempts: 1, BreakerThreshold: 1})
+	fallback := &offlineProvider{}
+	provider := NewFallbackProvider(primary, fallback)
+
+	for i := 0; i < 2; i++ {
+		res, err := provider.Complete(context.Background(), CompletionRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, "offline", res.Model)
+	}
+
+	// the open breaker fails fast, the gateway is only called once
+	assert.Equal(t, int32(1), gateway.calls.Load())
+	assert.Equal(t, 2, fallback.calls)
+	assert.Equal(t, primary.Model(), provider.Model())
+	assert.Equal(t, primary.Limits(), provider.Limits())
+}
+
+func TestFallbackProvider_Stream(t *testing.T) {
+	tests := []struct {
+		name           string
+		primary        func(t *testing.T, gateway *fakeGateway) LLMProvider
+		statuses       []int
+		expectedDeltas []string
+	}{
+		{name: "PrimaryStreams", primary: func(t *testing.T, gateway *fakeGateway) LLMProvider {
+			return newTestStreamingProvider(t, gateway.handle)
+		}, expectedDeltas: []string{"sum", "mary"}},
+		{name: "StreamUnreachable", primary: func(t *testing.T, gateway *fakeGateway) LLMProvider {
+			return newTestStreamingProvider(t, gateway.handle)
+		}, statuses: []int{503}, expectedDeltas: []string{"offline summary"}},
+		{name: "CompleteOnly", primary: func(t *testing.T, gateway *fakeGateway) LLMProvider {
+			return completeOnly{newTestProvider(t, gateway.handle)}
+		}, expectedDeltas: []string{"summary"}},
+		{name: "CompleteOnlyUnreachable", primary: func(t *testing.T, gateway *fakeGateway) LLMProvider {
+			return completeOnly{newTestProvider(t, gateway.handle)}
+		}, statuses: []int{503}, expectedDeltas: []string{"offline summary"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := &fakeGateway{statuses: tt.statuses}
+			provider := NewFallbackProvider(tt.primary(t, gateway), &offlineProvider{})
+
+			stream, err := provider.Stream(context.Background(), CompletionRequest{})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedDeltas, collectDeltas(stream))
+		})
+	}
+}
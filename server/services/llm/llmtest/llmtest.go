@@ -0,0 +1,260 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package llmtest provides a fake OpenAI compatible chat completions server, so that the llm providers and the whole
+// summary pipeline can be tested offline. Each completion is answered by the next queued Reply, then by the handler
+// once the queue is empty. A Reply can delay its answer, fail with a status, drop the connection, or be streamed as
+// server-sent events when the request asks for a stream.
+package llmtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ChatCompletionsPath is the path of the completions, relative to the URL of the server
+const ChatCompletionsPath = "/chat/completions"
+
+// Model is the model reported by the replies without a model
+const Model = "llmtest-model"
+
+// Message is a message of a completion request
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Usage is the token usage reported by a reply
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Request is a completion request received by the server
+type Request struct {
+	Model          string          `json:"model"`
+	Messages       []Message       `json:"messages"`
+	Stream         bool            `json:"stream"`
+	Temperature    *float64        `json:"temperature,omitempty"`
+	MaxTokens      *int            `json:"max_tokens,omitempty"`
+	ResponseFormat json.RawMessage `json:"response_format,omitempty"`
+	// Authorization is the header sent with the request, e.g. "Bearer <key>"
+	Authorization string `json:"-"`
+}
+
+// Message returns the content of the first message of the role, empty when there is none
+func (r Request) Message(role string) string {
+	for _, message := range r.Messages {
+		if message.Role == role {
+			return message.Content
+		}
+	}
+	return ""
+}
+
+// Reply scripts the answer to a completion request
+type Reply struct {
+	// Content is the answer of the model. It is streamed as the Deltas when set, else word by word.
+	Content string
+	Deltas  []string
+	// Model defaults to the model of the request, or to Model
+	Model string
+	Usage *Usage
+	// Delay is waited before answering, unless the request is canceled first
+	Delay time.Duration
+	// DeltaDelay is waited before each delta of a streamed answer
+	DeltaDelay time.Duration
+	// Status fails the request with the status and Body, together with a Retry-After header when RetryAfter is set
+	Status     int
+	Body       string
+	RetryAfter string
+	// Disconnect drops the connection instead of answering, a streamed answer is dropped after its deltas
+	Disconnect bool
+	// StreamError ends a streamed answer with an error event after its deltas
+	StreamError string
+}
+
+// Handler answers the requests once the queued replies are used
+type Handler func(request Request) Reply
+
+// Server is a fake chat completions server, its URL is the base URL of an OpenAI provider
+type Server struct {
+	*httptest.Server
+	mu       sync.Mutex
+	replies  []Reply
+	handler  Handler
+	requests []Request
+}
+
+// NewServer starts a server closed at the end of the test. Without a queued reply or a handler the requests fail
+// with a 500, so that an unexpected completion fails the test.
+func NewServer(t testing.TB) *Server {
+	s := &Server{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+ChatCompletionsPath, s.complete)
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Enqueue adds replies answering the next requests in order
+func (s *Server) Enqueue(replies ...Reply) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replies = append(s.replies, replies...)
+}
+
+// Handle sets the handler answering the requests once the queued replies are used
+func (s *Server) Handle(handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = handler
+}
+
+// Requests returns the requests received so far, in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+func (s *Server) complete(w http.ResponseWriter, r *http.Request) {
+	var request Request
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("invalid completion request: %s", err), http.StatusBadRequest)
+		return
+	}
+	request.Authorization = r.Header.Get("Authorization")
+	reply, ok := s.next(request)
+	if !ok {
+		http.Error(w, "llmtest: no reply scripted for the completion", http.StatusInternalServerError)
+		return
+	}
+
+	if !wait(r, reply.Delay) {
+		return
+	}
+	if reply.Model == "" {
+		reply.Model = request.Model
+	}
+	if reply.Model == "" {
+		reply.Model = Model
+	}
+	switch {
+	case reply.Disconnect && !request.Stream:
+		panic(http.ErrAbortHandler)
+	case reply.Status != 0 && reply.Status != http.StatusOK:
+		if reply.RetryAfter != "" {
+			w.Header().Set("Retry-After", reply.RetryAfter)
+		}
+		w.WriteHeader(reply.Status)
+		_, _ = w.Write([]byte(reply.Body))
+	case request.Stream:
+		stream(w, r, reply)
+	case reply.Body != "":
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(reply.Body))
+	default:
+		writeJSON(w, map[string]any{
+			"model":   reply.Model,
+			"choices": []any{map[string]any{"message": Message{Role: "assistant", Content: reply.Content}}},
+			"usage":   usage(reply),
+		})
+	}
+}
+
+// next records the request and returns its reply
+func (s *Server) next(request Request) (Reply, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, request)
+	if len(s.replies) > 0 {
+		reply := s.replies[0]
+		s.replies = s.replies[1:]
+		return reply, true
+	}
+	if s.handler == nil {
+		return Reply{}, false
+	}
+	return s.handler(request), true
+}
+
+// stream writes the deltas of the reply as server-sent events, followed by the usage and the done events
+func stream(w http.ResponseWriter, r *http.Request, reply Reply) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	deltas := reply.Deltas
+	if deltas == nil {
+		deltas = strings.SplitAfter(reply.Content, " ")
+	}
+	for _, delta := range deltas {
+		if !wait(r, reply.DeltaDelay) {
+			return
+		}
+		writeEvent(w, map[string]any{
+			"model":   reply.Model,
+			"choices": []any{map[string]any{"delta": map[string]string{"content": delta}}},
+		})
+	}
+	if reply.Disconnect {
+		panic(http.ErrAbortHandler)
+	}
+	if reply.StreamError != "" {
+		writeEvent(w, map[string]any{"error": map[string]string{"message": reply.StreamError}})
+		return
+	}
+	writeEvent(w, map[string]any{"model": reply.Model, "choices": []any{}, "usage": usage(reply)})
+	writeEvent(w, nil)
+}
+
+// writeEvent writes a data event holding the JSON of the value, or the done event when value is nil
+func writeEvent(w http.ResponseWriter, value any) {
+	data := []byte("[DONE]")
+	if value != nil {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			panic(err)
+		}
+	}
+	_, _ = fmt.Fprintf(w, "data: %s\n\n", data)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		panic(err)
+	}
+}
+
+// usage returns the usage of the reply, or one counting a token per word
+func usage(reply Reply) Usage {
+	if reply.Usage != nil {
+		return *reply.Usage
+	}
+	tokens := len(strings.Fields(reply.Content))
+	return Usage{CompletionTokens: tokens, TotalTokens: tokens}
+}
+
+// wait waits the delay, it returns false when the request is canceled first
+func wait(r *http.Request, delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
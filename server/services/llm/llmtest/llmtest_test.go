@@ -0,0 +1,147 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package llmtest_test
+
+import (
+	"context"
+	"meeting-analyzer/server/services/credentials"
+	"meeting-analyzer/server/services/llm"
+	"meeting-analyzer/server/services/llm/llmtest"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var request = llm.CompletionRequest{Messages: []llm.Message{
+	{Role: llm.RoleSystem, Content: "Summarize."},
+	{Role: llm.RoleUser, Content: "hello"},
+}}
+
+func newProvider(t *testing.T, server *llmtest.Server, timeout time.Duration) llm.StreamingProvider {
+	provider, err := llm.NewOpenAIProvider(llm.Config{
+		BaseURL: server.URL,
+		APIKey:  credentials.Static("test-key"),
+		Model:   "test-model",
+		Timeout: timeout,
+	})
+	require.NoError(t, err)
+	return provider.(llm.StreamingProvider)
+}
+
+func TestServer_Complete(t *testing.T) {
+	server := llmtest.NewServer(t)
+	server.Enqueue(llmtest.Reply{Content: "first answer", Usage: &llmtest.Usage{TotalTokens: 12}})
+	server.Handle(func(request llmtest.Request) llmtest.Reply {
+		return llmtest.Reply{Content: "echo " + request.Message(llm.RoleUser), Model: "served-model"}
+	})
+	provider := newProvider(t, server, 5*time.Second)
+
+	first, err := provider.Complete(context.Background(), request)
+	require.NoError(t, err)
+	second, err := provider.Complete(context.Background(), request)
+	require.NoError(t, err)
+
+	assert.Equal(t, &llm.CompletionResponse{Content: "first answer", Model: "test-model", Usage: llm.Usage{TotalTokens: 12}}, first)
+	assert.Equal(t, "echo hello", second.Content)
+	assert.Equal(t, "served-model", second.Model)
+	requests := server.Requests()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "test-model", requests[0].Model)
+	assert.Equal(t, "Bearer test-key", requests[0].Authorization)
+	assert.Equal(t, "Summarize.", requests[0].Message(llm.RoleSystem))
+	assert.False(t, requests[0].Stream)
+}
+
+func TestServer_NoReply(t *testing.T) {
+	server := llmtest.NewServer(t)
+
+	_, err := newProvider(t, server, 5*time.Second).Complete(context.Background(), request)
+
+	var statusErr *llm.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
+}
+
+func TestServer_Failures(t *testing.T) {
+	tests := []struct {
+		name   string
+		reply  llmtest.Reply
+		assert func(t *testing.T, err error)
+	}{
+		{name: "Status", reply: llmtest.Reply{Status: http.StatusTooManyRequests, Body: "slow down", RetryAfter: "3"},
+			assert: func(t *testing.T, err error) {
+				var statusErr *llm.StatusError
+				require.ErrorAs(t, err, &statusErr)
+				assert.Equal(t, &llm.StatusError{StatusCode: http.StatusTooManyRequests, Body: "slow down",
+					RetryAfter: 3 * time.Second}, statusErr)
+			}},
+		{name: "InvalidBody", reply: llmtest.Reply{Body: "not json"},
+			assert: func(t *testing.T, err error) { assert.ErrorIs(t, err, llm.ErrInvalidCompletion) }},
+		{name: "Empty", reply: llmtest.Reply{Content: " "},
+			assert: func(t *testing.T, err error) { assert.ErrorIs(t, err, llm.ErrEmptyCompletion) }},
+		{name: "Disconnect", reply: llmtest.Reply{Disconnect: true},
+			assert: func(t *testing.T, err error) { assert.ErrorContains(t, err, "failed to call the llm provider") }},
+		{name: "Latency", reply: llmtest.Reply{Content: "late", Delay: time.Second},
+			assert: func(t *testing.T, err error) { assert.ErrorContains(t, err, "failed to call the llm provider") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := llmtest.NewServer(t)
+			server.Enqueue(tt.reply)
+
+			_, err := newProvider(t, server, 100*time.Millisecond).Complete(context.Background(), request)
+
+			tt.assert(t, err)
+		})
+	}
+}
+
+func TestServer_Stream(t *testing.T) {
+	server := llmtest.NewServer(t)
+	server.Enqueue(
+		llmtest.Reply{Content: "the summary", Usage: &llmtest.Usage{TotalTokens: 5}},
+		llmtest.Reply{Deltas: []string{"a", "b"}, DeltaDelay: time.Millisecond},
+	)
+	provider := newProvider(t, server, 5*time.Second)
+
+	for _, expected := range [][]string{{"the ", "summary"}, {"a", "b"}} {
+		stream, err := provider.Stream(context.Background(), request)
+		require.NoError(t, err)
+		deltas := make([]string, 0)
+		for delta := range stream.Deltas() {
+			deltas = append(deltas, delta)
+		}
+		_, err = stream.Response()
+		require.NoError(t, err)
+		assert.Equal(t, expected, deltas)
+	}
+	assert.True(t, server.Requests()[0].Stream)
+}
+
+func TestServer_StreamFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    llmtest.Reply
+		expected string
+	}{
+		{name: "StreamError", reply: llmtest.Reply{Content: "partial", StreamError: "overloaded"}, expected: "overloaded"},
+		{name: "Disconnect", reply: llmtest.Reply{Content: "partial", Disconnect: true}, expected: "completion stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := llmtest.NewServer(t)
+			server.Enqueue(tt.reply)
+
+			stream, err := newProvider(t, server, 5*time.Second).Stream(context.Background(), request)
+			require.NoError(t, err)
+			for range stream.Deltas() {
+			}
+			_, err = stream.Response()
+
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"meeting-analyzer/server/services/credentials"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	chatCompletionsPath = "/chat/completions"
+	maxErrorBodyLength  = 512
+	// the events of a stream end with a data line holding doneEvent
+	dataPrefix = "data:"
+	doneEvent  = "[DONE]"
+)
+
+// Config holds the settings of an OpenAI compatible chat completions gateway
+type Config struct {
+	BaseURL string
+	// APIKey is sent as bearer token, it is read for each completion so that a rotated key is used right away
+	APIKey  credentials.Provider
+	Model   string
+	Timeout time.Duration
+	// Limits defaults to DefaultChunkTokens and DefaultChunkOverlapTokens
+	Limits Limits
+}
+
+type openAIProvider struct {
+	config Config
+	client *http.Client
+}
+
+type chatCompletionRequest struct {
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	Stream      bool      `json:"stream"`
+	Temperature *float64  `json:"temperature,omitempty"`
+	MaxTokens   *int      `json:"max_tokens,omitempty"`
+
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	StreamOptions  *streamOptions  `json:"stream_options,omitempty"`
+}
+
+type streamOptions struct {
+	// IncludeUsage asks for a last chunk reporting the usage of the whole completion
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatCompletionResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message Message `json:"message"`
+	} `json:"choices"`
+	Usage Usage `json:"usage"`
+}
+
+// chatCompletionChunk is an event of a streamed completion, the usage is only set by the last chunk
+type chatCompletionChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *Usage `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// NewOpenAIProvider creates a provider for OpenAI compatible chat completions endpoints
+func NewOpenAIProvider(config Config) (LLMProvider, error) {
+	if config.BaseURL == "" || config.Model == "" {
+		return nil, ErrProviderNotReady
+	}
+	if config.Limits.ChunkTokens <= 0 {
+		config.Limits.ChunkTokens = DefaultChunkTokens
+	}
+	if config.Limits.ChunkOverlapTokens <= 0 {
+		config.Limits.ChunkOverlapTokens = DefaultChunkOverlapTokens
+	}
+	return &openAIProvider{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+func (p *openAIProvider) Model() string {
+	return p.config.Model
+}
+
+func (p *openAIProvider) Limits() Limits {
+	return p.config.Limits
+}
+
+func (p *openAIProvider) Complete(ctx context.Context, request CompletionRequest) (*CompletionResponse, error) {
+	resp, err := p.send(ctx, request, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the completion response: %w", err)
+	}
+
+	var result chatCompletionResponse
+	if err = json.Unmarshal(body, &result); err != nil {
+		return nil, errors.Join(ErrInvalidCompletion, err)
+	}
+	if len(result.Choices) == 0 || strings.TrimSpace(result.Choices[0].Message.Content) == "" {
+		return nil, ErrEmptyCompletion
+	}
+
+	return &CompletionResponse{
+		Content: result.Choices[0].Message.Content,
+		Model:   p.model(result.Model),
+		Usage:   result.Usage,
+	}, nil
+}
+
+// Stream sends the request with "stream": true, the provider answers with server-sent events holding the deltas
+// of the answer
+func (p *openAIProvider) Stream(ctx context.Context, request CompletionRequest) (*Stream, error) {
+	resp, err := p.send(ctx, request, true)
+	if err != nil {
+		return nil, err
+	}
+	return NewStream(ctx, func(send func(delta string) bool) (*CompletionResponse, error) {
+		defer resp.Body.Close()
+		response := &CompletionResponse{Model: p.config.Model}
+		reader := bufio.NewReader(resp.Body)
+		for {
+			line, err := reader.ReadString('\n')
+			if errors.Is(err, io.EOF) && line == "" {
+				return nil, fmt.Errorf("%w: the stream ended before %s", ErrInvalidCompletion, doneEvent)
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("failed to read the completion stream: %w", err)
+			}
+			// the other fields of the events, and the comments keeping the connection alive, are ignored
+			data, ok := strings.CutPrefix(strings.TrimRight(line, "\r\n"), dataPrefix)
+			if !ok {
+				continue
+			}
+			data = strings.TrimSpace(data)
+			if data == doneEvent {
+				return response, nil
+			}
+			var chunk chatCompletionChunk
+			if err = json.Unmarshal([]byte(data), &chunk); err != nil {
+				return nil, errors.Join(ErrInvalidCompletion, err)
+			}
+			if chunk.Error != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidCompletion, chunk.Error.Message)
+			}
+			response.Model = p.model(chunk.Model)
+			if chunk.Usage != nil {
+				response.Usage = *chunk.Usage
+			}
+			if len(chunk.Choices) > 0 && !send(chunk.Choices[0].Delta.Content) {
+				return nil, ErrCompletionCanceled
+			}
+		}
+	}), nil
+}
+
+// send posts the completion request and returns the response of a successful status
+func (p *openAIProvider) send(ctx context.Context, request CompletionRequest, stream bool) (*http.Response, error) {
+	completionRequest := chatCompletionRequest{
+		Model:       p.config.Model,
+		Messages:    request.Messages,
+		Stream:      stream,
+		Temperature: request.Temperature,
+		MaxTokens:   request.MaxTokens,
+
+		ResponseFormat: request.ResponseFormat,
+	}
+	if stream {
+		completionRequest.StreamOptions = &streamOptions{IncludeUsage: true}
+	}
+	requestBody, err := json.Marshal(completionRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the completion request: %w", err)
+	}
+
+	url := strings.TrimSuffix(p.config.BaseURL, "/") + chatCompletionsPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the completion request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if stream {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+	if p.config.APIKey != nil {
+		apiKey, err := p.config.APIKey.Get(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the llm api key: %w", err)
+		}
+		if apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCompletionCanceled, ctx.Err())
+		}
+		return nil, fmt.Errorf("failed to call the llm provider: %w", err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the completion response: %w", err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized && p.config.APIKey != nil {
+		// the key may have been rotated since it was cached
+		p.config.APIKey.Invalidate()
+	}
+	return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+}
+
+// parseRetryAfter reads a Retry-After header holding either a number of seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
+
+// model returns the model reported by the provider, or the configured one
+func (p *openAIProvider) model(reported string) string {
+	if reported == "" {
+		return p.config.Model
+	}
+	return reported
+}
@@ -0,0 +1,194 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"meeting-analyzer/server/services/credentials"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) LLMProvider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	provider, err := NewOpenAIProvider(Config{
+		BaseURL: server.URL + "/api/",
+		APIKey:  credentials.Static("test-key"),
+		Model:   "test-model",
+		Timeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+func TestNewOpenAIProvider_MissingConfig(t *testing.T) {
+	_, err := NewOpenAIProvider(Config{BaseURL: "http://localhost"})
+	assert.ErrorIs(t, err, ErrProviderNotReady)
+}
+
+func TestNewOpenAIProvider_Limits(t *testing.T) {
+	defaults, err := NewOpenAIProvider(Config{BaseURL: "http://localhost", Model: "model"})
+	require.NoError(t, err)
+	configured, err := NewOpenAIProvider(Config{BaseURL: "http://localhost", Model: "model",
+		Limits: Limits{ChunkTokens: 100000, ChunkOverlapTokens: 500}})
+	require.NoError(t, err)
+
+	assert.Equal(t, Limits{ChunkTokens: DefaultChunkTokens, ChunkOverlapTokens: DefaultChunkOverlapTokens}, defaults.Limits())
+	assert.Equal(t, Limits{ChunkTokens: 100000, ChunkOverlapTokens: 500}, configured.Limits())
+}
+
+func TestComplete_Success(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		var body chatCompletionRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "test-model", body.Model)
+		assert.False(t, body.Stream)
+		assert.Equal(t, []Message{{Role: RoleUser, Content: "hello"}}, body.Messages)
+		assert.Nil(t, body.ResponseFormat)
+
+		_, _ = w.Write([]byte(`{"model":"served-model","choices":[{"message":{"role":"assistant","content":"summary"}}],"usage":{"total_tokens":7}}`))
+	})
+
+	response, err := provider.Complete(context.Background(), CompletionRequest{
+		Messages: []Message{{Role: RoleUser, Content: "hello"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "summary", response.Content)
+	assert.Equal(t, "served-model", response.Model)
+	assert.Equal(t, 7, response.Usage.TotalTokens)
+}
+
+func TestComplete_ResponseFormat(t *testing.T) {
+	format := &ResponseFormat{
+		Type:       ResponseFormatJSONSchema,
+		JSONSchema: &JSONSchema{Name: "summary", Schema: json.RawMessage(`{"type":"object"}`), Strict: true},
+	}
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]any{
+			"type": "json_schema",
+			"json_schema": map[string]any{
+				"name":   "summary",
+				"schema": map[string]any{"type": "object"},
+				"strict": true,
+			},
+		}, body["response_format"])
+
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"{}"}}]}`))
+	})
+
+	_, err := provider.Complete(context.Background(), CompletionRequest{
+		Messages:       []Message{{Role: RoleUser, Content: "hello"}},
+		ResponseFormat: format,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestComplete_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "EmptyChoices", status: http.StatusOK, body: `{"choices":[]}`, wantErr: ErrEmptyCompletion},
+		{name: "BlankContent", status: http.StatusOK, body: `{"choices":[{"message":{"content":"  "}}]}`, wantErr: ErrEmptyCompletion},
+		{name: "MalformedJSON", status: http.StatusOK, body: `{"choices":`, wantErr: ErrInvalidCompletion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			_, err := provider.Complete(context.Background(), CompletionRequest{})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestComplete_StatusError(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("upstream down"))
+	})
+
+	_, err := provider.Complete(context.Background(), CompletionRequest{})
+
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusBadGateway, statusErr.StatusCode)
+	assert.Equal(t, "upstream down", statusErr.Body)
+	assert.Equal(t, "llm provider returned status 502", err.Error(), "the body is only logged")
+}
+
+func TestComplete_ContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	provider := newTestProvider(t, func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	})
+	t.Cleanup(func() { close(release) })
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := provider.Complete(ctx, CompletionRequest{})
+
+	assert.ErrorIs(t, err, ErrCompletionCanceled)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// rotatingKey is a credentials provider counting the invalidations of its key
+type rotatingKey struct {
+	keys          []string
+	invalidations int
+}
+
+func (k *rotatingKey) Get(context.Context) (string, error) {
+	return k.keys[k.invalidations], nil
+}
+
+func (k *rotatingKey) Invalidate() {
+	k.invalidations++
+}
+
+func TestComplete_RotatedAPIKey(t *testing.T) {
+	apiKey := &rotatingKey{keys: []string{"old-key", "new-key"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer new-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"summary"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+	provider, err := NewOpenAIProvider(Config{BaseURL: server.URL, APIKey: apiKey, Model: "test-model"})
+	require.NoError(t, err)
+
+	_, err = provider.Complete(context.Background(), CompletionRequest{})
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+	assert.Equal(t, 1, apiKey.invalidations)
+
+	res, err := provider.Complete(context.Background(), CompletionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "summary", res.Content)
+}
@@ -0,0 +1,111 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package llm provides the abstraction used by the service to talk to large language models
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Roles of the messages exchanged with the model
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// errors
+var (
+	ErrEmptyCompletion    = errors.New("llm returned an empty completion")
+	ErrProviderNotReady   = errors.New("llm provider is not configured")
+	ErrInvalidCompletion  = errors.New("llm returned a malformed completion")
+	ErrCompletionCanceled = errors.New("llm completion was canceled")
+	// ErrProviderUnavailable is returned without calling the provider while it is considered down
+	ErrProviderUnavailable = errors.New("llm provider is unavailable")
+)
+
+// StatusError is returned when the provider answers with a non successful HTTP status
+type StatusError struct {
+	StatusCode int
+	// Body is the start of the body of the response, it may echo the prompt and is only logged, never part of the
+	// error message which is stored with the jobs and returned to the clients
+	Body string
+	// RetryAfter is the delay asked by the Retry-After header of the response, 0 when missing
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("llm provider returned status %d", e.StatusCode)
+}
+
+// Message is a single chat message sent to the model
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ResponseFormatJSONSchema asks the model to answer with a JSON document matching a schema
+const ResponseFormatJSONSchema = "json_schema"
+
+// JSONSchema is a named JSON schema the answer of the model must conform to
+type JSONSchema struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema"`
+	Strict bool            `json:"strict"`
+}
+
+// ResponseFormat constrains the shape of the answer of the model
+type ResponseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+// CompletionRequest describes a chat completion call
+type CompletionRequest struct {
+	Messages    []Message
+	Temperature *float64
+	MaxTokens   *int
+	// ResponseFormat is optional, providers without structured output support may ignore it
+	ResponseFormat *ResponseFormat
+}
+
+// Usage reports the tokens consumed by a completion
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// CompletionResponse is the answer of the model
+type CompletionResponse struct {
+	Content string
+	Model   string
+	Usage   Usage
+}
+
+// Default chunk sizes of the providers, in estimated tokens
+const (
+	DefaultChunkTokens        = 6000
+	DefaultChunkOverlapTokens = 200
+)
+
+// Limits sizes the transcription chunks sent to a provider in a single completion, in estimated tokens
+type Limits struct {
+	ChunkTokens        int
+	ChunkOverlapTokens int
+}
+
+// LLMProvider is implemented by every model backend the service can summarize with
+type LLMProvider interface {
+	// Complete sends the request to the model and returns its answer.
+	// It must honour ctx cancellation and never terminate the process on failure.
+	Complete(ctx context.Context, request CompletionRequest) (*CompletionResponse, error)
+	// Model returns the name of the model used for completions
+	Model() string
+	// Limits returns the size of the transcription chunks fitting the context window of the model
+	Limits() Limits
+}
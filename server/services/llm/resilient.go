@@ -0,0 +1,228 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	log "eos2git.cec.lab.emc.com/ISG-Edge/hzp-go-commons/logger"
+)
+
+// Defaults of the ResilienceConfig
+const (
+	DefaultMaxAttempts      = 3
+	DefaultInitialBackoff   = 500 * time.Millisecond
+	DefaultMaxBackoff       = 30 * time.Second
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 * time.Second
+)
+
+// ReadinessReporter is implemented by the providers knowing in advance that they cannot answer
+type ReadinessReporter interface {
+	// Ready returns ErrProviderUnavailable while the provider is considered down
+	Ready() error
+}
+
+// ResilienceConfig sizes the retries and the circuit breaker of a resilient provider, the zero values use the defaults
+type ResilienceConfig struct {
+	// MaxAttempts bounds the calls of a completion, including the first one
+	MaxAttempts int
+	// the delay before a retry is drawn between 0 and InitialBackoff doubled at each attempt, up to MaxBackoff.
+	// A longer Retry-After than MaxBackoff is not waited for.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// AttemptTimeout bounds each call, 0 leaves the calls bounded by the context of the completion only
+	AttemptTimeout time.Duration
+	// BreakerThreshold consecutive transient failures open the circuit breaker, which then rejects the completions
+	// with ErrProviderUnavailable for BreakerCooldown
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+type resilientProvider struct {
+	provider LLMProvider
+	config   ResilienceConfig
+	breaker  *circuitBreaker
+	// sleep and random are replaced by the tests
+	sleep  func(ctx context.Context, d time.Duration) error
+	random func(n int64) int64
+}
+
+// NewResilientProvider wraps the provider with retries of the transient failures, like a 502 or a timeout, and a
+// circuit breaker failing fast while the provider is down
+func NewResilientProvider(provider LLMProvider, config ResilienceConfig) StreamingProvider {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = DefaultInitialBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultMaxBackoff
+	}
+	if config.BreakerThreshold <= 0 {
+		config.BreakerThreshold = DefaultBreakerThreshold
+	}
+	if config.BreakerCooldown <= 0 {
+		config.BreakerCooldown = DefaultBreakerCooldown
+	}
+	return &resilientProvider{
+		provider: provider,
+		config:   config,
+		breaker:  newCircuitBreaker(config.BreakerThreshold, config.BreakerCooldown),
+		sleep:    sleep,
+		random:   rand.Int64N,
+	}
+}
+
+func (p *resilientProvider) Model() string {
+	return p.provider.Model()
+}
+
+func (p *resilientProvider) Limits() Limits {
+	return p.provider.Limits()
+}
+
+func (p *resilientProvider) Ready() error {
+	return p.breaker.ready()
+}
+
+func (p *resilientProvider) Complete(ctx context.Context, request CompletionRequest) (*CompletionResponse, error) {
+	var response *CompletionResponse
+	err := p.retry(ctx, func() error {
+		attemptCtx, cancel := p.attemptContext(ctx)
+		defer cancel()
+		var err error
+		response, err = p.provider.Complete(attemptCtx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Stream retries the calls failing before the answer starts, an answer failing midway is not retried since its
+// deltas were already received. The answer of a provider which does not stream is sent as a single delta.
+func (p *resilientProvider) Stream(ctx context.Context, request CompletionRequest) (*Stream, error) {
+	streaming, ok := p.provider.(StreamingProvider)
+	if !ok {
+		response, err := p.Complete(ctx, request)
+		return completedStream(ctx, response, err)
+	}
+
+	var stream *Stream
+	var cancel context.CancelFunc
+	err := p.retry(ctx, func() error {
+		attemptCtx, attemptCancel := p.attemptContext(ctx)
+		s, err := streaming.Stream(attemptCtx, request)
+		if err != nil {
+			attemptCancel()
+			return err
+		}
+		stream, cancel = s, attemptCancel
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewStream(ctx, func(send func(delta string) bool) (*CompletionResponse, error) {
+		defer cancel()
+		for delta := range stream.Deltas() {
+			if !send(delta) {
+				return nil, ErrCompletionCanceled
+			}
+		}
+		response, err := stream.Response()
+		if err != nil {
+			p.breaker.record(classify(ctx, err))
+		}
+		return response, err
+	}), nil
+}
+
+// retry runs call until it succeeds, fails with an error which is not transient, or MaxAttempts is reached
+func (p *resilientProvider) retry(ctx context.Context, call func() error) error {
+	for attempt := 1; ; attempt++ {
+		if err := p.breaker.allow(); err != nil {
+			return err
+		}
+		err := call()
+		result := classify(ctx, err)
+		p.breaker.record(result)
+		if result != outcomeFailure || attempt >= p.config.MaxAttempts {
+			return err
+		}
+		delay, ok := p.backoff(attempt, err)
+		if !ok {
+			return err
+		}
+		log.Error(ctx, nil, "", err, "llm call attempt %d of %d failed, retrying in %s", attempt,
+			p.config.MaxAttempts, delay)
+		if p.sleep(ctx, delay) != nil {
+			return fmt.Errorf("%w: %w", ErrCompletionCanceled, ctx.Err())
+		}
+	}
+}
+
+// backoff returns the delay before the next attempt, false when the provider asks to wait longer than MaxBackoff
+func (p *resilientProvider) backoff(attempt int, err error) (time.Duration, bool) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return statusErr.RetryAfter, statusErr.RetryAfter <= p.config.MaxBackoff
+	}
+	ceiling := p.config.InitialBackoff
+	for i := 1; i < attempt && ceiling < p.config.MaxBackoff; i++ {
+		ceiling *= 2
+	}
+	ceiling = min(ceiling, p.config.MaxBackoff)
+	// full jitter spreads the retries of the workers failing at the same time
+	return time.Duration(p.random(int64(ceiling) + 1)), true
+}
+
+func (p *resilientProvider) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.config.AttemptTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.config.AttemptTimeout)
+}
+
+// classify tells whether the error of a call is a transient failure of the provider worth retrying
+func classify(ctx context.Context, err error) outcome {
+	if err == nil {
+		return outcomeSuccess
+	}
+	if ctx.Err() != nil {
+		return outcomeIgnored
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+			http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return outcomeFailure
+		}
+		return outcomeSuccess
+	}
+	if errors.Is(err, ErrEmptyCompletion) || errors.Is(err, ErrInvalidCompletion) {
+		return outcomeSuccess
+	}
+	// the network errors and the timeouts of the attempts
+	return outcomeFailure
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package llm
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const successBody = `{"choices":[{"message":{"role":"assistant","content":"summary"}}]}`
+
+// fakeGateway answers the completions with the statuses in order, then with a success
+type fakeGateway struct {
+	mu       sync.Mutex
+	statuses []int
+	calls    atomic.Int32
+	// retryAfter is sent with the failures when set
+	retryAfter string
+	// delay is waited by the first call
+	delay time.Duration
+}
+
+func (g *fakeGateway) handle(w http.ResponseWriter, r *http.Request) {
+	call := int(g.calls.Add(1))
+	if call == 1 && g.delay > 0 {
+		select {
+		case <-time.After(g.delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if call <= len(g.statuses) {
+		if g.retryAfter != "" {
+			w.Header().Set("Retry-After", g.retryAfter)
+		}
+		w.WriteHeader(g.statuses[call-1])
+		return
+	}
+	if r.Header.Get("Accept") == "text/event-stream" {
+		writeEvents(w, `data: {"choices":[{"delta":{"content":"sum"}}]}`,
+			`data: {"choices":[{"delta":{"content":"mary"}}]}`, `data: [DONE]`)
+		return
+	}
+	_, _ = w.Write([]byte(successBody))
+}
+
+type testClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Sleep(_ context.Context, d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return nil
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestResilientProvider wraps a provider of the gateway, whose waits are recorded by the returned clock and whose
+// jitter always draws the longest delay
+func newTestResilientProvider(t *testing.T, gateway *fakeGateway, config ResilienceConfig) (*resilientProvider, *testClock) {
+	clock := &testClock{now: time.Now()}
+	provider := NewResilientProvider(newTestProvider(t, gateway.handle), config).(*resilientProvider)
+	provider.sleep = clock.Sleep
+	provider.random = func(n int64) int64 { return n - 1 }
+	provider.breaker.now = clock.Now
+	return provider, clock
+}
+
+func TestResilientProvider_RetriesTransientFailures(t *testing.T) {
+	gateway := &fakeGateway{statuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable}}
+	provider, clock := newTestResilientProvider(t, gateway, ResilienceConfig{InitialBackoff: time.Second})
+
+	res, err := provider.Complete(context.Background(), CompletionRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "summary", res.Content)
+	assert.Equal(t, int32(3), gateway.calls.Load())
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.sleeps)
+}
+
+func TestResilientProvider_GivesUp(t *testing.T) {
+	tests := []struct {
+		name          string
+		gateway       *fakeGateway
+		expectedCalls int32
+		expectedCode  int
+	}{
+		{name: "AttemptsExhausted", gateway: &fakeGateway{statuses: []int{502, 502, 502, 502}}, expectedCalls: 3,
+			expectedCode: http.StatusBadGateway},
+		{name: "NotTransient", gateway: &fakeGateway{statuses: []int{400}}, expectedCalls: 1,
+			expectedCode: http.StatusBadRequest},
+		{name: "RetryAfterTooLong", gateway: &fakeGateway{statuses: []int{429}, retryAfter: "120"}, expectedCalls: 1,
+			expectedCode: http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, _ := newTestResilientProvider(t, tt.gateway, ResilienceConfig{BreakerThreshold: 10})
+
+			_, err := provider.Complete(context.Background(), CompletionRequest{})
+
+			var statusErr *StatusError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, tt.expectedCode, statusErr.StatusCode)
+			assert.Equal(t, tt.expectedCalls, tt.gateway.calls.Load())
+		})
+	}
+}
+
+func TestResilientProvider_RetryAfter(t *testing.T) {
+	gateway := &fakeGateway{statuses: []int{http.StatusTooManyRequests}, retryAfter: "7"}
+	provider, clock := newTestResilientProvider(t, gateway, ResilienceConfig{})
+
+	_, err := provider.Complete(context.Background(), CompletionRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{7 * time.Second}, clock.sleeps)
+}
+
+func TestResilientProvider_AttemptTimeout(t *testing.T) {
+	gateway := &fakeGateway{delay: time.Second}
+	provider, _ := newTestResilientProvider(t, gateway, ResilienceConfig{AttemptTimeout: 50 * time.Millisecond})
+
+	res, err := provider.Complete(context.Background(), CompletionRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "summary", res.Content)
+	assert.Equal(t, int32(2), gateway.calls.Load())
+}
+
+func TestResilientProvider_Backoff(t *testing.T) {
+	provider, _ := newTestResilientProvider(t, &fakeGateway{}, ResilienceConfig{InitialBackoff: time.Second,
+		MaxBackoff: 5 * time.Second})
+
+	delays := make([]time.Duration, 0)
+	for attempt := 1; attempt <= 5; attempt++ {
+		delay, ok := provider.backoff(attempt, &StatusError{StatusCode: 502})
+		require.True(t, ok)
+		delays = append(delays, delay)
+	}
+
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second},
+		delays)
+}
+
+func TestResilientProvider_CanceledDuringBackoff(t *testing.T) {
+	gateway := &fakeGateway{statuses: []int{http.StatusBadGateway}}
+	provider, _ := newTestResilientProvider(t, gateway, ResilienceConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	provider.sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return sleep(ctx, d)
+	}
+
+	_, err := provider.Complete(ctx, CompletionRequest{})
+
+	assert.ErrorIs(t, err, ErrCompletionCanceled)
+	assert.Equal(t, int32(1), gateway.calls.Load())
+}
+
+func TestResilientProvider_CircuitBreaker(t *testing.T) {
+	gateway := &fakeGateway{statuses: []int{502, 502, 502}}
+	provider, clock := newTestResilientProvider(t, gateway, ResilienceConfig{MaxAttempts: 1, BreakerThreshold: 2,
+		BreakerCooldown: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		_, err := provider.Complete(context.Background(), CompletionRequest{})
+		require.Error(t, err)
+	}
+	_, err := provider.Complete(context.Background(), CompletionRequest{})
+
+	// the breaker is open and fails fast without calling the gateway
+	assert.ErrorIs(t, err, ErrProviderUnavailable)
+	assert.ErrorIs(t, provider.Ready(), ErrProviderUnavailable)
+	assert.Equal(t, int32(2), gateway.calls.Load())
+
+	// the trial call after the cooldown fails and opens the breaker again
+	clock.Advance(time.Minute)
+	require.NoError(t, provider.Ready())
+	_, err = provider.Complete(context.Background(), CompletionRequest{})
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	_, err = provider.Complete(context.Background(), CompletionRequest{})
+	assert.ErrorIs(t, err, ErrProviderUnavailable)
+
+	// the next trial succeeds and closes the breaker
+	clock.Advance(time.Minute)
+	_, err = provider.Complete(context.Background(), CompletionRequest{})
+	require.NoError(t, err)
+	_, err = provider.Complete(context.Background(), CompletionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), gateway.calls.Load())
+}
+
+func TestResilientProvider_Stream(t *testing.T) {
+	gateway := &fakeGateway{statuses: []int{http.StatusServiceUnavailable}}
+	provider, clock := newTestResilientProvider(t, gateway, ResilienceConfig{})
+
+	stream, err := provider.Stream(context.Background(), CompletionRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"sum", "mary"}, collectDeltas(stream))
+	res, err := stream.Response()
+	require.NoError(t, err)
+	assert.Equal(t, "summary", res.Content)
+	assert.Len(t, clock.sleeps, 1)
+}
+
+// completeOnly hides the streaming of a provider
+type completeOnly struct {
+	LLMProvider
+}
+
+func TestResilientProvider_StreamWithoutStreamingProvider(t *testing.T) {
+	gateway := &fakeGateway{}
+	provider := NewResilientProvider(completeOnly{newTestProvider(t, gateway.handle)}, ResilienceConfig{})
+
+	stream, err := provider.Stream(context.Background(), CompletionRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"summary"}, collectDeltas(stream))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 30*time.Second, parseRetryAfter("30", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter("Mon, 01 Jan 2024 10:01:30 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Mon, 01 Jan 2024 09:00:00 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+}
@@ -0,0 +1,92 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// StreamingProvider is implemented by the providers able to send the answer of the model as it is generated
+type StreamingProvider interface {
+	LLMProvider
+	// Stream sends the request to the model and returns the stream of its answer. The errors of the request, like a
+	// StatusError, are returned right away, the ones happening while the answer is read by the Response of the stream.
+	Stream(ctx context.Context, request CompletionRequest) (*Stream, error)
+}
+
+// Stream is the answer of the model read incrementally. Range over Deltas to receive the parts of the answer as they
+// are generated, then call Response for the whole answer. The stream stops when its context is done.
+type Stream struct {
+	deltas   chan string
+	done     chan struct{}
+	response *CompletionResponse
+	err      error
+}
+
+// NewStream runs read in a goroutine. read passes every part of the answer to send, and stops when send returns
+// false because ctx is done. The Content of the response returned by read is replaced by the concatenation of the parts.
+func NewStream(ctx context.Context, read func(send func(delta string) bool) (*CompletionResponse, error)) *Stream {
+	s := &Stream{
+		deltas: make(chan string),
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(s.done)
+		var content strings.Builder
+		response, err := read(func(delta string) bool {
+			if delta == "" {
+				return ctx.Err() == nil
+			}
+			select {
+			case s.deltas <- delta:
+				content.WriteString(delta)
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		close(s.deltas)
+		switch {
+		case ctx.Err() != nil:
+			s.err = fmt.Errorf("%w: %w", ErrCompletionCanceled, ctx.Err())
+		case err != nil:
+			s.err = err
+		case strings.TrimSpace(content.String()) == "":
+			s.err = ErrEmptyCompletion
+		default:
+			if response == nil {
+				response = &CompletionResponse{}
+			}
+			response.Content = content.String()
+			s.response = response
+		}
+	}()
+	return s
+}
+
+// Deltas returns the channel of the parts of the answer, closed at the end of the stream
+func (s *Stream) Deltas() <-chan string {
+	return s.deltas
+}
+
+// Response waits for the end of the stream and returns the whole answer, or the error which ended the stream.
+// The parts not received from Deltas yet are discarded.
+func (s *Stream) Response() (*CompletionResponse, error) {
+	for range s.deltas {
+	}
+	<-s.done
+	return s.response, s.err
+}
+
+// completedStream returns the error of a completion, or else a stream sending its whole answer as a single delta
+func completedStream(ctx context.Context, response *CompletionResponse, err error) (*Stream, error) {
+	if err != nil {
+		return nil, err
+	}
+	return NewStream(ctx, func(send func(delta string) bool) (*CompletionResponse, error) {
+		send(response.Content)
+		return response, nil
+	}), nil
+}
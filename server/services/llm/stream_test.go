@@ -0,0 +1,168 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeEvents answers the request with the server-sent events, flushed one by one
+func writeEvents(w http.ResponseWriter, events ...string) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	for _, event := range events {
+		_, _ = fmt.Fprintf(w, "%s\n\n", event)
+		w.(http.Flusher).Flush()
+	}
+}
+
+func newTestStreamingProvider(t *testing.T, handler http.HandlerFunc) StreamingProvider {
+	provider, ok := newTestProvider(t, handler).(StreamingProvider)
+	require.True(t, ok)
+	return provider
+}
+
+func collectDeltas(stream *Stream) []string {
+	deltas := make([]string, 0)
+	for delta := range stream.Deltas() {
+		deltas = append(deltas, delta)
+	}
+	return deltas
+}
+
+func TestStream_Success(t *testing.T) {
+	provider := newTestStreamingProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		var body chatCompletionRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.True(t, body.Stream)
+		assert.Equal(t, &streamOptions{IncludeUsage: true}, body.StreamOptions)
+
+		writeEvents(w,
+			`data: {"model":"served-model","choices":[{"delta":{"role":"assistant","content":""}}]}`,
+			`: keep-alive`,
+			`data: {"model":"served-model","choices":[{"delta":{"content":"sum"}}]}`,
+			`data: {"model":"served-model","choices":[{"delta":{"content":"mary"}}]}`,
+			`data: {"model":"served-model","choices":[],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`,
+			`data: [DONE]`)
+	})
+
+	stream, err := provider.Stream(context.Background(), CompletionRequest{Messages: []Message{{Role: RoleUser, Content: "hello"}}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"sum", "mary"}, collectDeltas(stream))
+	res, err := stream.Response()
+	require.NoError(t, err)
+	assert.Equal(t, &CompletionResponse{Content: "summary", Model: "served-model",
+		Usage: Usage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7}}, res)
+}
+
+func TestStream_ResponseWithoutDeltas(t *testing.T) {
+	provider := newTestStreamingProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+		writeEvents(w, `data: {"choices":[{"delta":{"content":"sum"}}]}`,
+			`data: {"choices":[{"delta":{"content":"mary"}}]}`, `data: [DONE]`)
+	})
+
+	stream, err := provider.Stream(context.Background(), CompletionRequest{})
+	require.NoError(t, err)
+	res, err := stream.Response()
+
+	require.NoError(t, err)
+	assert.Equal(t, "summary", res.Content)
+	assert.Equal(t, "test-model", res.Model)
+}
+
+func TestStream_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		events   []string
+		expected error
+	}{
+		{name: "Empty", events: []string{`data: {"choices":[{"delta":{"content":" "}}]}`, `data: [DONE]`},
+			expected: ErrEmptyCompletion},
+		{name: "Malformed", events: []string{`data: {"choices":`}, expected: ErrInvalidCompletion},
+		{name: "Truncated", events: []string{`data: {"choices":[{"delta":{"content":"sum"}}]}`},
+			expected: ErrInvalidCompletion},
+		{name: "ErrorEvent", events: []string{`data: {"error":{"message":"overloaded"}}`}, expected: ErrInvalidCompletion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestStreamingProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+				writeEvents(w, tt.events...)
+			})
+
+			stream, err := provider.Stream(context.Background(), CompletionRequest{})
+			require.NoError(t, err)
+			_, err = stream.Response()
+
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestStream_StatusError(t *testing.T) {
+	provider := newTestStreamingProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(strings.Repeat("x", 2*maxErrorBodyLength)))
+	})
+
+	_, err := provider.Stream(context.Background(), CompletionRequest{})
+
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+	assert.Len(t, statusErr.Body, maxErrorBodyLength)
+}
+
+func TestStream_ContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	provider := newTestStreamingProvider(t, func(w http.ResponseWriter, _ *http.Request) {
+		writeEvents(w, `data: {"choices":[{"delta":{"content":"sum"}}]}`)
+		<-release
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := provider.Stream(ctx, CompletionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "sum", <-stream.Deltas())
+	cancel()
+
+	done := make(chan error)
+	go func() {
+		_, err := stream.Response()
+		done <- err
+	}()
+	select {
+	case err = <-done:
+		assert.ErrorIs(t, err, ErrCompletionCanceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the stream was not stopped by the cancellation")
+	}
+}
+
+func TestNewStream_StopsWhenNotRead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	stream := NewStream(ctx, func(send func(string) bool) (*CompletionResponse, error) {
+		defer close(stopped)
+		for send("token") {
+		}
+		return nil, ErrCompletionCanceled
+	})
+	<-stream.Deltas()
+
+	cancel()
+
+	<-stopped
+	_, err := stream.Response()
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,118 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package progress broadcasts the progress of the summary generations to the clients following a meeting
+package progress
+
+import (
+	"sync"
+)
+
+// DefaultBufferSize is the number of events buffered for a subscriber before it misses events
+const DefaultBufferSize = 64
+
+// EventType tells which field of an Event is set
+type EventType string
+
+// event types
+const (
+	// EventStatus is published when the job of the meeting changes status
+	EventStatus EventType = "status"
+	// EventChunk is published when a chunk of a long meeting is summarized
+	EventChunk EventType = "chunk"
+	// EventDelta is published with the next part of the output of the model generating the summary
+	EventDelta EventType = "delta"
+)
+
+// Event is a step of the summary generation of a meeting
+type Event struct {
+	Type EventType
+	// SummarizedChunks and TotalChunks are set by EventChunk
+	SummarizedChunks int
+	TotalChunks      int
+	// Attempt and Content are set by EventDelta
+	Attempt int
+	Content string
+}
+
+// Topic identifies the meeting whose events are published, the meeting ids are only unique within an estate
+type Topic struct {
+	EstateID  string
+	MeetingID string
+}
+
+// Broker fans the events of a meeting out to its subscribers
+type Broker struct {
+	bufferSize  int
+	mu          sync.Mutex
+	subscribers map[Topic]map[chan Event]struct{}
+}
+
+// NewBroker creates a broker buffering bufferSize events per subscriber
+func NewBroker(bufferSize int) *Broker {
+	if bufferSize < 1 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Broker{
+		bufferSize:  bufferSize,
+		subscribers: make(map[Topic]map[chan Event]struct{}),
+	}
+}
+
+// Subscribe returns the channel receiving the events published for the meeting, until unsubscribe is called.
+// A subscriber falling behind by more than the buffer size misses the next deltas, and its oldest buffered event makes
+// room for the status and chunk events, so that it stays connected and still receives the last status.
+func (b *Broker) Subscribe(topic Topic) (<-chan Event, func()) {
+	events := make(chan Event, b.bufferSize)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[chan Event]struct{})
+	}
+	b.subscribers[topic][events] = struct{}{}
+
+	var once sync.Once
+	return events, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			b.remove(topic, events)
+		})
+	}
+}
+
+// Publish sends the event to the subscribers of the meeting without blocking the summary generation
+func (b *Broker) Publish(topic Topic, event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for events := range b.subscribers[topic] {
+		select {
+		case events <- event:
+			continue
+		default:
+		}
+		if event.Type == EventDelta {
+			// the generated summary is sent with the status once done, the deltas only preview it
+			continue
+		}
+		select {
+		case <-events:
+		default:
+		}
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// remove closes the channel of the subscriber, b.mu must be held
+func (b *Broker) remove(topic Topic, events chan Event) {
+	if _, ok := b.subscribers[topic][events]; !ok {
+		return
+	}
+	delete(b.subscribers[topic], events)
+	close(events)
+	if len(b.subscribers[topic]) == 0 {
+		delete(b.subscribers, topic)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package progress
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	meeting1 = Topic{EstateID: "estate-1", MeetingID: "meeting-1"}
+	meeting2 = Topic{EstateID: "estate-1", MeetingID: "meeting-2"}
+)
+
+func TestBroker_Publish(t *testing.T) {
+	broker := NewBroker(4)
+	first, unsubscribeFirst := broker.Subscribe(meeting1)
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := broker.Subscribe(meeting1)
+	defer unsubscribeSecond()
+	other, unsubscribeOther := broker.Subscribe(meeting2)
+	defer unsubscribeOther()
+	// the same meeting id in another estate is another meeting
+	otherEstate, unsubscribeOtherEstate := broker.Subscribe(Topic{EstateID: "estate-2", MeetingID: "meeting-1"})
+	defer unsubscribeOtherEstate()
+
+	event := Event{Type: EventChunk, SummarizedChunks: 1, TotalChunks: 3}
+	broker.Publish(meeting1, event)
+
+	assert.Equal(t, event, <-first)
+	assert.Equal(t, event, <-second)
+	assert.Empty(t, other)
+	assert.Empty(t, otherEstate)
+}
+
+func TestBroker_Unsubscribe(t *testing.T) {
+	broker := NewBroker(4)
+	events, unsubscribe := broker.Subscribe(meeting1)
+
+	unsubscribe()
+	unsubscribe()
+	broker.Publish(meeting1, Event{Type: EventStatus})
+
+	_, ok := <-events
+	assert.False(t, ok)
+	assert.Empty(t, broker.subscribers)
+}
+
+func TestBroker_SlowSubscriber(t *testing.T) {
+	broker := NewBroker(2)
+	events, unsubscribe := broker.Subscribe(meeting1)
+	defer unsubscribe()
+
+	for i := 1; i <= 3; i++ {
+		broker.Publish(meeting1, Event{Type: EventDelta, Content: fmt.Sprintf("token %d", i)})
+	}
+	broker.Publish(meeting1, Event{Type: EventStatus})
+
+	assert.Equal(t, Event{Type: EventDelta, Content: "token 2"}, <-events)
+	assert.Equal(t, Event{Type: EventStatus}, <-events)
+	require.Len(t, broker.subscribers[meeting1], 1)
+	broker.Publish(meeting1, Event{Type: EventDelta, Content: "token 4"})
+	assert.Equal(t, Event{Type: EventDelta, Content: "token 4"}, <-events)
+}
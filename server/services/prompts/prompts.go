@@ -0,0 +1,163 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package prompts renders the prompts of the summary styles from versioned text/template files. The templates of a
+// style are in templates/<style>/v<version>.tmpl, which defines the instructions and reduce templates and may
+// redefine the shared transcript and map templates of templates/base.tmpl.
+package prompts
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"meeting-analyzer/server/models"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// DefaultStyle is the style of the summaries requested without a style
+const DefaultStyle = "default"
+
+// ErrUnknownStyle is returned for a style without templates
+var ErrUnknownStyle = errors.New("unknown summary style")
+
+const baseTemplate = "templates/base.tmpl"
+
+//go:embed templates
+var files embed.FS
+
+var versionFile = regexp.MustCompile(`^v([1-9][0-9]*)\.tmpl$`)
+
+// registry holds the latest version of each style, the templates are checked by the tests so that loading them
+// cannot fail at runtime
+var registry = mustLoad(files)
+
+// Template is a version of the prompts of a summary style
+type Template struct {
+	Style   string
+	Version int
+	tmpl    *template.Template
+}
+
+// Lookup returns the latest version of the templates of the style, or of DefaultStyle when style is empty
+func Lookup(style string) (*Template, error) {
+	if style == "" {
+		style = DefaultStyle
+	}
+	t, ok := registry[style]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStyle, style)
+	}
+	return t, nil
+}
+
+// Styles returns the sorted names of the styles
+func Styles() []string {
+	styles := make([]string, 0, len(registry))
+	for style := range registry {
+		styles = append(styles, style)
+	}
+	slices.Sort(styles)
+	return styles
+}
+
+// Instructions returns the system prompt of a transcription summarized in a single completion
+func (t *Template) Instructions() (string, error) {
+	return t.render("instructions", nil)
+}
+
+// MapInstructions returns the system prompt of the part of a transcription split in parts parts, part starts at 1
+func (t *Template) MapInstructions(part int, parts int) (string, error) {
+	return t.render("map", struct{ Part, Parts int }{Part: part, Parts: parts})
+}
+
+// ReduceInstructions returns the system prompt merging the partial summaries of the parts of a transcription
+func (t *Template) ReduceInstructions() (string, error) {
+	return t.render("reduce", nil)
+}
+
+// Transcript returns the meeting transcription as sent to the model
+func (t *Template) Transcript(meetingDetails *models.MeetingDetails) (string, error) {
+	return t.render("transcript", meetingDetails)
+}
+
+func (t *Template) render(name string, data any) (string, error) {
+	var b strings.Builder
+	if err := t.tmpl.ExecuteTemplate(&b, name, data); err != nil {
+		return "", fmt.Errorf("failed to render the %s prompt of style %s v%d: %w", name, t.Style, t.Version, err)
+	}
+	return b.String(), nil
+}
+
+func mustLoad(fsys fs.FS) map[string]*Template {
+	templates, err := load(fsys)
+	if err != nil {
+		panic(err)
+	}
+	return templates
+}
+
+// load parses the latest version of every style on top of the base templates
+func load(fsys fs.FS) (map[string]*Template, error) {
+	base, err := template.New("base").ParseFS(fsys, baseTemplate)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := fs.ReadDir(fsys, path.Dir(baseTemplate))
+	if err != nil {
+		return nil, err
+	}
+	templates := make(map[string]*Template)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		style := entry.Name()
+		version, err := latestVersion(fsys, path.Join(path.Dir(baseTemplate), style))
+		if err != nil {
+			return nil, fmt.Errorf("style %s: %w", style, err)
+		}
+		tmpl, err := template.Must(base.Clone()).ParseFS(fsys,
+			path.Join(path.Dir(baseTemplate), style, fmt.Sprintf("v%d.tmpl", version)))
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range []string{"instructions", "reduce"} {
+			if tmpl.Lookup(name) == nil {
+				return nil, fmt.Errorf("style %s v%d does not define the %s template", style, version, name)
+			}
+		}
+		templates[style] = &Template{Style: style, Version: version, tmpl: tmpl}
+	}
+	if templates[DefaultStyle] == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStyle, DefaultStyle)
+	}
+	return templates, nil
+}
+
+func latestVersion(fsys fs.FS, dir string) (int, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return 0, err
+	}
+	latest := 0
+	for _, entry := range entries {
+		match := versionFile.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return 0, fmt.Errorf("unexpected template file %s, expected v<version>.tmpl", entry.Name())
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, err
+		}
+		latest = max(latest, version)
+	}
+	if latest == 0 {
+		return 0, errors.New("no template version")
+	}
+	return latest, nil
+}
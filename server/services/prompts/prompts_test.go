@@ -0,0 +1,160 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package prompts
+
+import (
+	"meeting-analyzer/server/models"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMeetingDetails() *models.MeetingDetails {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	second := start.Add(5 * time.Second)
+	return &models.MeetingDetails{
+		MeetingID:    "meeting-1",
+		MeetingTitle: "Weekly sync",
+		Transcription: []models.Transcription{
+			{MemberName: "Alice", Timestamp: &start, Content: "Hello"},
+			{MemberName: "Bob", Timestamp: &second, Content: "Hi"},
+			{MemberName: "Carol", Content: "Untimed"},
+		},
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name          string
+		style         string
+		expectedStyle string
+		expectedErr   error
+	}{
+		{name: "Empty", style: "", expectedStyle: DefaultStyle},
+		{name: "Default", style: "default", expectedStyle: DefaultStyle},
+		{name: "Named", style: "retrospective", expectedStyle: "retrospective"},
+		{name: "Unknown", style: "haiku", expectedErr: ErrUnknownStyle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := Lookup(tt.style)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStyle, tmpl.Style)
+			assert.Equal(t, 1, tmpl.Version)
+		})
+	}
+}
+
+func TestStyles(t *testing.T) {
+	assert.Equal(t, []string{"customer_call", "default", "engineering_standup", "executive_brief", "retrospective"},
+		Styles())
+}
+
+// TestTemplates renders every prompt of every style
+func TestTemplates(t *testing.T) {
+	for _, style := range Styles() {
+		t.Run(style, func(t *testing.T) {
+			tmpl, err := Lookup(style)
+			require.NoError(t, err)
+
+			instructions, err := tmpl.Instructions()
+			require.NoError(t, err)
+			assert.NotEmpty(t, instructions)
+			reduce, err := tmpl.ReduceInstructions()
+			require.NoError(t, err)
+			assert.NotEmpty(t, reduce)
+			mapInstructions, err := tmpl.MapInstructions(2, 3)
+			require.NoError(t, err)
+			assert.Contains(t, mapInstructions, "part 2 of 3")
+			transcript, err := tmpl.Transcript(testMeetingDetails())
+			require.NoError(t, err)
+			assert.Contains(t, transcript, "Weekly sync")
+		})
+	}
+}
+
+func TestTemplate_Transcript(t *testing.T) {
+	expected := "Meeting Transcription: Weekly sync\n" +
+		"\"Alice\",\"2024-01-01T10:00:00Z\"\n\"Hello\"\n" +
+		"\"Bob\",\"2024-01-01T10:00:05Z\"\n\"Hi\"\n" +
+		"\"Carol\",\"\"\n\"Untimed\"\n"
+	tmpl, err := Lookup(DefaultStyle)
+	require.NoError(t, err)
+
+	transcript, err := tmpl.Transcript(testMeetingDetails())
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, transcript)
+}
+
+func TestTemplate_DefaultInstructions(t *testing.T) {
+	tmpl, err := Lookup(DefaultStyle)
+	require.NoError(t, err)
+
+	instructions, err := tmpl.Instructions()
+
+	require.NoError(t, err)
+	assert.Equal(t, "You summarize meeting transcriptions. Write the summary in the language of the meeting, "+
+		"list the key points, the decisions taken and the action items with the participant owning them.", instructions)
+}
+
+func TestLoad(t *testing.T) {
+	base := &fstest.MapFile{Data: []byte(`{{define "transcript"}}{{.MeetingTitle}}{{end}}{{define "map"}}map{{end}}`)}
+	style := func(instructions string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(`{{define "instructions"}}` + instructions +
+			`{{end}}{{define "reduce"}}reduce{{end}}`)}
+	}
+	tests := []struct {
+		name                 string
+		files                fstest.MapFS
+		expectedInstructions string
+		expectedErr          string
+	}{
+		{name: "LatestVersion", files: fstest.MapFS{
+			"templates/base.tmpl":        base,
+			"templates/default/v1.tmpl":  style("first"),
+			"templates/default/v2.tmpl":  style("second"),
+			"templates/default/v10.tmpl": style("tenth"),
+		}, expectedInstructions: "tenth"},
+		{name: "MissingDefault", files: fstest.MapFS{
+			"templates/base.tmpl":     base,
+			"templates/brief/v1.tmpl": style("brief"),
+		}, expectedErr: "unknown summary style: default"},
+		{name: "MissingReduce", files: fstest.MapFS{
+			"templates/base.tmpl":       base,
+			"templates/default/v1.tmpl": {Data: []byte(`{{define "instructions"}}x{{end}}`)},
+		}, expectedErr: "style default v1 does not define the reduce template"},
+		{name: "UnexpectedFile", files: fstest.MapFS{
+			"templates/base.tmpl":          base,
+			"templates/default/v1.tmpl":    style("first"),
+			"templates/default/draft.tmpl": style("draft"),
+		}, expectedErr: "unexpected template file draft.tmpl"},
+		{name: "InvalidTemplate", files: fstest.MapFS{
+			"templates/base.tmpl":       base,
+			"templates/default/v1.tmpl": style("{{"),
+		}, expectedErr: "v1.tmpl:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates, err := load(tt.files)
+
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			instructions, err := templates[DefaultStyle].Instructions()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedInstructions, instructions)
+		})
+	}
+}
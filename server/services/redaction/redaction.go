@@ -0,0 +1,246 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package redaction replaces the personal data and secrets of the transcripts by placeholders before they are sent to
+// the summarization model, and restores them in the summaries for the readers allowed to see them
+package redaction
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Category is a kind of sensitive value
+type Category string
+
+// categories of the sensitive values
+const (
+	CategoryEmail      Category = "email"
+	CategoryPhone      Category = "phone"
+	CategoryCreditCard Category = "credit_card"
+	CategoryNationalID Category = "national_id"
+	CategoryIPAddress  Category = "ip_address"
+	CategorySecret     Category = "secret"
+)
+
+// Categories are all the categories, the default configuration redacts them all
+var Categories = []Category{CategoryEmail, CategoryPhone, CategoryCreditCard, CategoryNationalID, CategoryIPAddress,
+	CategorySecret}
+
+// Instructions tells the model to keep the placeholders, it is added to the prompts of the redacted transcripts
+const Instructions = "Some values of the transcript were replaced by placeholders such as [EMAIL_1]. Copy the " +
+	"placeholders verbatim when you mention these values, never guess or invent the values they replace."
+
+// placeholderPattern matches the placeholders of all the categories
+var placeholderPattern = regexp.MustCompile(`\[(EMAIL|PHONE|CREDIT_CARD|NATIONAL_ID|IP_ADDRESS|SECRET)_\d+\]`)
+
+// detector finds the values of a category, the matches of the pattern are only redacted when valid accepts them
+type detector struct {
+	category Category
+	pattern  *regexp.Regexp
+	valid    func(match string) bool
+}
+
+// detectors run in order on the text redacted by the previous ones: the secrets and card numbers are replaced before
+// their digits are mistaken for phone numbers
+var detectors = []detector{
+	{category: CategorySecret, pattern: regexp.MustCompile(`\b(?:sk-[A-Za-z0-9_-]{20,}|AKIA[0-9A-Z]{16}|` +
+		`gh[pousr]_[A-Za-z0-9]{36,}|xox[abprs]-[A-Za-z0-9-]{10,}|AIza[0-9A-Za-z_-]{35}|` +
+		`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+)`)},
+	{category: CategorySecret, pattern: regexp.MustCompile(`\b[A-Za-z0-9_-]{32,}\b`), valid: isRandomToken},
+	{category: CategoryEmail, pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}\b`)},
+	{category: CategoryCreditCard, pattern: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`), valid: isCardNumber},
+	{category: CategoryNationalID, pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`), valid: isSSN},
+	{category: CategoryNationalID, pattern: regexp.MustCompile(`\b[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z] ?\d{2} ?\d{2} ?\d{2} ?[A-D]\b`)},
+	{category: CategoryIPAddress, pattern: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`)},
+	{category: CategoryIPAddress, pattern: regexp.MustCompile(`(?i)(?:[0-9a-f]{0,4}:){2,7}[0-9a-f]{1,4}\b`), valid: isIPv6},
+	{category: CategoryPhone, pattern: regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{1,4}\)[ .-]?|\b)\d{1,5}(?:[ .-]\d{2,5}){1,5}\b`),
+		valid: isPhoneNumber},
+}
+
+// Config selects the categories which are redacted, none disables the redaction. Sealer seals the redacted values
+// before they are stored, without it the values are dropped and their placeholders are never restored.
+type Config struct {
+	Categories []Category
+	Sealer     *Sealer
+}
+
+// Redactor replaces the sensitive values of the texts by placeholders
+type Redactor struct {
+	detectors []detector
+}
+
+// New returns the redactor of the categories of the config
+func New(config Config) (*Redactor, error) {
+	for _, category := range config.Categories {
+		if !slices.Contains(Categories, category) {
+			return nil, fmt.Errorf("unknown redaction category %q", category)
+		}
+	}
+	r := &Redactor{}
+	for _, d := range detectors {
+		if slices.Contains(config.Categories, d.category) {
+			r.detectors = append(r.detectors, d)
+		}
+	}
+	return r, nil
+}
+
+// ParseCategories parses a comma separated list of categories, "all" selects all the categories and "none" or an
+// empty list none of them
+func ParseCategories(value string) ([]Category, error) {
+	categories := make([]Category, 0)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		switch part {
+		case "", "none":
+			continue
+		case "all":
+			return slices.Clone(Categories), nil
+		}
+		if !slices.Contains(Categories, Category(part)) {
+			return nil, fmt.Errorf("unknown redaction category %q", part)
+		}
+		categories = append(categories, Category(part))
+	}
+	return categories, nil
+}
+
+// Enabled tells whether the redactor redacts any category
+func (r *Redactor) Enabled() bool {
+	return len(r.detectors) > 0
+}
+
+// Redact returns the text with its sensitive values replaced by their placeholders in the report, a value already
+// in the report keeps its placeholder
+func (r *Redactor) Redact(text string, report *Report) string {
+	for _, d := range r.detectors {
+		text = d.pattern.ReplaceAllStringFunc(text, func(match string) string {
+			if d.valid != nil && !d.valid(match) {
+				return match
+			}
+			return report.placeholder(d.category, match)
+		})
+	}
+	return text
+}
+
+// HasPlaceholders tells whether the text contains placeholders of redacted values
+func HasPlaceholders(text string) bool {
+	return placeholderPattern.MatchString(text)
+}
+
+// Entry is a redacted value and the placeholder replacing it
+type Entry struct {
+	Placeholder string
+	Category    Category
+	Value       string
+}
+
+// Report lists the values redacted from the texts of a meeting, each value has a single placeholder
+type Report struct {
+	Entries []Entry
+	// placeholders maps the values to their placeholder, it is built from the entries on first use
+	placeholders map[string]string
+}
+
+// placeholder returns the placeholder of the value, adding it to the report with the next number of its category
+// when it is new
+func (r *Report) placeholder(category Category, value string) string {
+	if r.placeholders == nil {
+		r.placeholders = make(map[string]string, len(r.Entries))
+		for _, entry := range r.Entries {
+			r.placeholders[entry.Value] = entry.Placeholder
+		}
+	}
+	if placeholder, ok := r.placeholders[value]; ok {
+		return placeholder
+	}
+	count := 1
+	for _, entry := range r.Entries {
+		if entry.Category == category {
+			count++
+		}
+	}
+	placeholder := "[" + strings.ToUpper(string(category)) + "_" + strconv.Itoa(count) + "]"
+	r.Entries = append(r.Entries, Entry{Placeholder: placeholder, Category: category, Value: value})
+	r.placeholders[value] = placeholder
+	return placeholder
+}
+
+// Restore returns the text with the placeholders of the report replaced by their values, the placeholders of the
+// values which were dropped, whose Value is empty, are kept
+func (r *Report) Restore(text string) string {
+	if len(r.Entries) == 0 {
+		return text
+	}
+	replacements := make([]string, 0, 2*len(r.Entries))
+	for _, entry := range r.Entries {
+		if entry.Value != "" {
+			replacements = append(replacements, entry.Placeholder, entry.Value)
+		}
+	}
+	return strings.NewReplacer(replacements...).Replace(text)
+}
+
+// isRandomToken accepts the long words mixing upper and lower case letters and digits, like the generated API keys
+// and unlike the identifiers and words of the transcripts
+func isRandomToken(match string) bool {
+	return strings.ContainsFunc(match, unicode.IsUpper) && strings.ContainsFunc(match, unicode.IsLower) &&
+		strings.ContainsFunc(match, unicode.IsDigit)
+}
+
+// isCardNumber accepts the numbers of 13 to 19 digits passing the Luhn check
+func isCardNumber(match string) bool {
+	digits := onlyDigits(match)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	for i := range digits {
+		digit := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// isSSN accepts the US social security numbers, whose area is not 000, 666 or 9xx and group and serial not zero
+func isSSN(match string) bool {
+	area, group, serial := match[:3], match[4:6], match[7:]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// isIPv6 accepts the valid IPv6 addresses, not the timestamps
+func isIPv6(match string) bool {
+	ip := net.ParseIP(match)
+	return ip != nil && ip.To4() == nil
+}
+
+// isPhoneNumber accepts the international numbers and the national numbers of 10 to 15 digits, the shorter ones
+// being mistaken for dates and amounts
+func isPhoneNumber(match string) bool {
+	digits := len(onlyDigits(match))
+	if strings.HasPrefix(match, "+") {
+		return digits >= 8 && digits <= 15
+	}
+	return digits >= 10 && digits <= 15
+}
+
+func onlyDigits(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, text)
+}
@@ -0,0 +1,158 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package redaction
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"meeting-analyzer/server/services/credentials"
+	"strings"
+	"unicode"
+)
+
+// KeySize is the size of the redaction key, in bytes
+const KeySize = 32
+
+// errors of the sealer
+var (
+	ErrInvalidKey    = errors.New("redaction key must be the base64 encoding of 32 bytes")
+	ErrInvalidSealed = errors.New("redacted value cannot be opened with the redaction key")
+	ErrUnknownKey    = errors.New("redacted value was sealed with a redaction key which is not configured")
+)
+
+// Sealer encrypts the redacted values with AES-256-GCM before they are stored, so that they are not readable in the
+// database nor its backups. The values are sealed with the current key and opened with the key they were sealed
+// with, the current or a previous one, so that the key can be rotated.
+type Sealer struct {
+	key      credentials.Provider
+	previous credentials.Provider
+}
+
+// sealingKey is a redaction key and its id
+type sealingKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// NewSealer returns the sealer of the key, whose secret is the base64 encoding of KeySize random bytes. previous, nil
+// when there is none, holds the keys the key replaced, separated by commas or spaces. The keys are loaded once so that
+// a misconfiguration fails at startup.
+func NewSealer(ctx context.Context, key credentials.Provider, previous credentials.Provider) (*Sealer, error) {
+	s := &Sealer{key: key, previous: previous}
+	if _, err := s.current(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := s.previousKeys(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Seal encrypts the value with the current key, whose id is returned to open it. binding is authenticated with the
+// value, e.g. the meeting and placeholder of the value, and must be given again to open it.
+func (s *Sealer) Seal(ctx context.Context, value string, binding string) (sealed []byte, keyID string, err error) {
+	key, err := s.current(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	aead := key.aead
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, "", err
+	}
+	return aead.Seal(nonce, nonce, []byte(value), []byte(binding)), key.id, nil
+}
+
+// Open decrypts the value sealed with the binding and the key of keyID. It fails with ErrUnknownKey when the key is
+// neither the current nor a previous key. The values sealed before the keys had ids are tried with all the keys.
+func (s *Sealer) Open(ctx context.Context, sealed []byte, keyID string, binding string) (string, error) {
+	current, err := s.current(ctx)
+	if err != nil {
+		return "", err
+	}
+	previous, err := s.previousKeys(ctx)
+	if err != nil {
+		return "", err
+	}
+	candidates := make([]sealingKey, 0, len(previous)+1)
+	for _, key := range append([]sealingKey{current}, previous...) {
+		if keyID == "" || key.id == keyID {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", ErrUnknownKey
+	}
+	for _, key := range candidates {
+		if value, ok := open(key.aead, sealed, binding); ok {
+			return value, nil
+		}
+	}
+	return "", ErrInvalidSealed
+}
+
+func open(aead cipher.AEAD, sealed []byte, binding string) (string, bool) {
+	if len(sealed) < aead.NonceSize() {
+		return "", false
+	}
+	value, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(binding))
+	if err != nil {
+		return "", false
+	}
+	return string(value), true
+}
+
+// current returns the current key, the keys are never returned in the errors
+func (s *Sealer) current(ctx context.Context) (sealingKey, error) {
+	secret, err := s.key.Get(ctx)
+	if err != nil {
+		return sealingKey{}, err
+	}
+	return parseKey(secret)
+}
+
+// previousKeys returns the keys the current key replaced
+func (s *Sealer) previousKeys(ctx context.Context) ([]sealingKey, error) {
+	if s.previous == nil {
+		return nil, nil
+	}
+	secret, err := s.previous.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secrets := strings.FieldsFunc(secret, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	keys := make([]sealingKey, 0, len(secrets))
+	for _, secret := range secrets {
+		key, err := parseKey(secret)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func parseKey(secret string) (sealingKey, error) {
+	key, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil || len(key) != KeySize {
+		return sealingKey{}, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return sealingKey{}, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return sealingKey{}, err
+	}
+	digest := sha256.Sum256(key)
+	return sealingKey{id: hex.EncodeToString(digest[:8]), aead: aead}, nil
+}
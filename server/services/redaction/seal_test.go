@@ -0,0 +1,108 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package redaction
+
+import (
+	"context"
+	"encoding/base64"
+	"meeting-analyzer/server/services/credentials"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKey  = credentials.Static(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", KeySize))))
+	otherKey = credentials.Static(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("o", KeySize))))
+)
+
+func TestSealer(t *testing.T) {
+	ctx := context.Background()
+	sealer, err := NewSealer(ctx, testKey, nil)
+	require.NoError(t, err)
+
+	sealed, keyID, err := sealer.Seal(ctx, "sk-live-secret", "e1/m1/[SECRET_1]")
+	require.NoError(t, err)
+	again, againKeyID, err := sealer.Seal(ctx, "sk-live-secret", "e1/m1/[SECRET_1]")
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(sealed), "sk-live-secret")
+	assert.NotEqual(t, sealed, again)
+	assert.Len(t, keyID, 16)
+	assert.Equal(t, keyID, againKeyID)
+	value, err := sealer.Open(ctx, sealed, keyID, "e1/m1/[SECRET_1]")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-live-secret", value)
+}
+
+func TestSealer_Rotation(t *testing.T) {
+	ctx := context.Background()
+	old, err := NewSealer(ctx, testKey, nil)
+	require.NoError(t, err)
+	sealed, oldKeyID, err := old.Seal(ctx, "bob@example.com", "e1/m1/[EMAIL_1]")
+	require.NoError(t, err)
+
+	// the values sealed with the previous keys are still opened, the new values are sealed with the current key
+	rotated, err := NewSealer(ctx, otherKey, credentials.Static(" "+string(testKey)+",\n"))
+	require.NoError(t, err)
+	value, err := rotated.Open(ctx, sealed, oldKeyID, "e1/m1/[EMAIL_1]")
+	require.NoError(t, err)
+	assert.Equal(t, "bob@example.com", value)
+	_, newKeyID, err := rotated.Seal(ctx, "bob@example.com", "e1/m1/[EMAIL_1]")
+	require.NoError(t, err)
+	assert.NotEqual(t, oldKeyID, newKeyID)
+
+	// the values sealed before the keys had ids are tried with every key
+	value, err = rotated.Open(ctx, sealed, "", "e1/m1/[EMAIL_1]")
+	require.NoError(t, err)
+	assert.Equal(t, "bob@example.com", value)
+
+	// the key was removed
+	removed, err := NewSealer(ctx, otherKey, nil)
+	require.NoError(t, err)
+	_, err = removed.Open(ctx, sealed, oldKeyID, "e1/m1/[EMAIL_1]")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestSealer_Open(t *testing.T) {
+	ctx := context.Background()
+	sealer, err := NewSealer(ctx, testKey, nil)
+	require.NoError(t, err)
+	sealed, keyID, err := sealer.Seal(ctx, "bob@example.com", "e1/m1/[EMAIL_1]")
+	require.NoError(t, err)
+	other, err := NewSealer(ctx, otherKey, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		sealer  *Sealer
+		sealed  []byte
+		keyID   string
+		binding string
+	}{
+		{name: "OtherBinding", sealer: sealer, sealed: sealed, keyID: keyID, binding: "e2/m1/[EMAIL_1]"},
+		{name: "OtherKey", sealer: other, sealed: sealed, binding: "e1/m1/[EMAIL_1]"},
+		{name: "Truncated", sealer: sealer, sealed: sealed[:4], keyID: keyID, binding: "e1/m1/[EMAIL_1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.sealer.Open(ctx, tt.sealed, tt.keyID, tt.binding)
+
+			assert.ErrorIs(t, err, ErrInvalidSealed)
+		})
+	}
+}
+
+func TestNewSealer_InvalidKey(t *testing.T) {
+	for _, key := range []credentials.Static{"", "not base64!", credentials.Static(base64.StdEncoding.EncodeToString([]byte("short")))} {
+		_, err := NewSealer(context.Background(), key, nil)
+
+		assert.ErrorIs(t, err, ErrInvalidKey)
+
+		_, err = NewSealer(context.Background(), testKey, credentials.Static(string(otherKey)+","+string(key)+"!"))
+
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package service
+
+import (
+	"context"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models/dbmodels"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *svc) ListActionItems(ctx context.Context, meetingID string) ([]generated.MeetingActionItem, error) {
+	meeting, err := s.repo.GetMeeting(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.repo.ListActionItems(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.revealedReport(ctx, meeting)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]generated.MeetingActionItem, 0, len(items))
+	for i := range items {
+		item := toMeetingActionItem(&items[i])
+		if report != nil {
+			revealActionItem(item, report)
+		}
+		res = append(res, *item)
+	}
+	return res, nil
+}
+
+func (s *svc) UpdateActionItem(ctx context.Context, meetingID string, itemID string, request *generated.UpdateActionItemRequest) (*generated.MeetingActionItem, error) {
+	item, err := s.repo.SetActionItemDone(ctx, meetingID, itemID, request.Done)
+	if err != nil {
+		return nil, err
+	}
+	meeting, err := s.repo.GetMeeting(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.revealedReport(ctx, meeting)
+	if err != nil {
+		return nil, err
+	}
+	res := toMeetingActionItem(item)
+	if report != nil {
+		revealActionItem(res, report)
+	}
+	return res, nil
+}
+
+// toMeetingActionItem builds the api representation of a stored action item
+func toMeetingActionItem(item *dbmodels.MeetingActionItem) *generated.MeetingActionItem {
+	res := &generated.MeetingActionItem{
+		Id:          item.ItemID,
+		MeetingId:   item.MeetingID,
+		Description: item.Description,
+		Owner:       item.Owner,
+		DuePhrase:   item.DuePhrase,
+		Done:        item.Done,
+		DoneAt:      item.DoneAt,
+		CreatedAt:   item.CreatedAt,
+		UpdatedAt:   item.UpdatedAt,
+	}
+	if item.DueDate != nil {
+		res.DueDate = &openapi_types.Date{Time: *item.DueDate}
+	}
+	if item.Segment != nil {
+		res.SourceSegment = &generated.SourceSegment{
+			Seq:        item.Segment.Seq,
+			MemberName: item.Segment.MemberName,
+			Timestamp:  item.Segment.Timestamp,
+			Content:    item.Segment.Content,
+		}
+	}
+	return res
+}
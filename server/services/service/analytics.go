@@ -0,0 +1,80 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package service
+
+import (
+	"context"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/services/analytics"
+)
+
+func (s *svc) GetMeetingAnalytics(ctx context.Context, meetingID string) (*generated.MeetingAnalytics, error) {
+	meeting, err := s.repo.GetMeeting(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	segments, err := s.repo.GetTranscriptSegments(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	res := toMeetingAnalytics(analytics.Compute(segments))
+	// the member names are stored redacted
+	report, err := s.revealedReport(ctx, meeting)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return res, nil
+	}
+	return revealAnalytics(res, report), nil
+}
+
+// toMeetingAnalytics builds the api representation of the analytics, durations are converted to seconds
+func toMeetingAnalytics(computed *analytics.Analytics) *generated.MeetingAnalytics {
+	res := &generated.MeetingAnalytics{
+		Timed:           computed.Timed,
+		DurationSeconds: computed.Duration.Seconds(),
+		TalkTimeSeconds: computed.TalkTime.Seconds(),
+		SilenceSeconds:  computed.Silence.Seconds(),
+		Speakers:        make([]generated.SpeakerAnalytics, 0, len(computed.Speakers)),
+		Interruptions:   make([]generated.Interruption, 0, len(computed.Interruptions)),
+		SilenceGaps:     make([]generated.SilenceGap, 0, len(computed.SilenceGaps)),
+	}
+	for _, speaker := range computed.Speakers {
+		res.Speakers = append(res.Speakers, generated.SpeakerAnalytics{
+			MemberName:      speaker.MemberName,
+			TalkTimeSeconds: speaker.TalkTime.Seconds(),
+			TalkShare:       speaker.TalkShare,
+			WordCount:       speaker.Words,
+			TurnCount:       speaker.Turns,
+			Interruptions:   speaker.Interruptions,
+			Interrupted:     speaker.Interrupted,
+		})
+	}
+	if monologue := computed.LongestMonologue; monologue != nil {
+		res.LongestMonologue = &generated.Monologue{
+			MemberName:      monologue.MemberName,
+			Seq:             monologue.Seq,
+			Start:           monologue.Start,
+			DurationSeconds: monologue.Duration.Seconds(),
+			WordCount:       monologue.Words,
+		}
+	}
+	for _, interruption := range computed.Interruptions {
+		res.Interruptions = append(res.Interruptions, generated.Interruption{
+			Seq:                   interruption.Seq,
+			MemberName:            interruption.MemberName,
+			InterruptedMemberName: interruption.Interrupted,
+			Timestamp:             interruption.Timestamp,
+			OverlapSeconds:        interruption.Overlap.Seconds(),
+		})
+	}
+	for _, gap := range computed.SilenceGaps {
+		res.SilenceGaps = append(res.SilenceGaps, generated.SilenceGap{
+			AfterSeq:        gap.AfterSeq,
+			Start:           gap.Start,
+			DurationSeconds: gap.Duration.Seconds(),
+		})
+	}
+	return res
+}
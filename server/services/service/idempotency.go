@@ -0,0 +1,83 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"time"
+
+	log "eos2git.cec.lab.emc.com/ISG-Edge/hzp-go-commons/logger"
+)
+
+// IdempotencyKeyTTL is how long the response of an idempotency key is replayed
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// hashRequest returns the hex SHA-256 of the request and its options, telling whether a request reusing an
+// idempotency key is the original one
+func hashRequest(options models.GenerateOptions, request any) (string, error) {
+	hash := sha256.New()
+	err := json.NewEncoder(hash).Encode(struct {
+		Force bool
+		// omitted when empty, so that the hashes of the requests stored before these options still match
+		SummaryStyle  string `json:",omitempty"`
+		SummaryEngine string `json:",omitempty"`
+		Request       any
+	}{Force: options.Force, SummaryStyle: options.SummaryStyle, SummaryEngine: options.SummaryEngine, Request: request})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// replay returns the response stored for the idempotency key, nil when the key was not used in the last
+// IdempotencyKeyTTL
+func (s *svc) replay(ctx context.Context, key string, requestHash string, now time.Time) (*generated.GenerateMeetingSummaryResponse, error) {
+	stored, err := s.repo.GetIdempotencyKey(ctx, key, now.Add(-IdempotencyKeyTTL))
+	if err != nil || stored == nil {
+		return nil, err
+	}
+	if stored.RequestHash != requestHash {
+		return nil, errorresponse.WithArgs(errorresponse.ErrIdempotencyKeyReused, key)
+	}
+	return &generated.GenerateMeetingSummaryResponse{
+		MeetingId: stored.MeetingID,
+		JobId:     stored.JobID,
+		Status:    stored.Status,
+	}, nil
+}
+
+// checkGeneration fails when a summary of the meeting is being generated, or already exists and is not forced to
+// be regenerated. A meeting whose last generation failed can be summarized again.
+func (s *svc) checkGeneration(ctx context.Context, meetingID string, force bool) error {
+	job, err := s.repo.GetLatestJob(ctx, meetingID)
+	if errors.Is(err, errorresponse.ErrJobIDNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	switch job.Status {
+	case generated.PENDING, generated.INPROGRESS:
+		return errorresponse.WithArgs(errorresponse.ErrSummaryInProgress, meetingID)
+	case generated.DONE:
+		if !force {
+			return errorresponse.WithArgs(errorresponse.ErrSummaryAlreadyExists, meetingID)
+		}
+	}
+	return nil
+}
+
+// saveIdempotencyKey stores the response of the key. The job is already queued, so a failure only loses the replay.
+func (s *svc) saveIdempotencyKey(ctx context.Context, key *dbmodels.IdempotencyKey) {
+	if err := s.repo.SaveIdempotencyKey(ctx, key, key.CreatedAt.Add(-IdempotencyKeyTTL)); err != nil {
+		log.Error(ctx, nil, "", err, "failed to save idempotency key of job %s", key.JobID)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/services/chunking"
+	"meeting-analyzer/server/services/llm"
+	"meeting-analyzer/server/services/progress"
+	"meeting-analyzer/server/services/prompts"
+	"meeting-analyzer/server/services/summary"
+)
+
+// summarizeTranscription summarizes the transcription in one completion when it fits the chunk size of the provider.
+// Longer transcriptions are split in chunks summarized separately (map), then the partial summaries are merged (reduce).
+// The prompts are rendered from the templates of the summary style. A progress event is published for each summarized
+// chunk, and the output of the last completion as delta events.
+func (s *svc) summarizeTranscription(ctx context.Context, provider llm.LLMProvider, meetingDetails *models.MeetingDetails,
+	tmpl *prompts.Template) (*summary.Summary, string, error) {
+	limits := provider.Limits()
+	chunks := chunking.Split(meetingDetails.Transcription, chunking.Config{
+		MaxTokens:     limits.ChunkTokens,
+		OverlapTokens: limits.ChunkOverlapTokens,
+	})
+	if len(chunks) <= 1 {
+		instructions, err := tmpl.Instructions()
+		if err != nil {
+			return nil, "", err
+		}
+		transcript, err := tmpl.Transcript(meetingDetails)
+		if err != nil {
+			return nil, "", err
+		}
+		return s.callAI(ctx, provider, meetingDetails.MeetingID, instructions, transcript, true)
+	}
+
+	partials := make([]*summary.Summary, 0, len(chunks))
+	for i, chunk := range chunks {
+		instructions, err := tmpl.MapInstructions(i+1, len(chunks))
+		if err != nil {
+			return nil, "", err
+		}
+		transcript, err := tmpl.Transcript(&models.MeetingDetails{
+			MeetingID:     meetingDetails.MeetingID,
+			MeetingTitle:  meetingDetails.MeetingTitle,
+			Transcription: chunk,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		partial, _, err := s.callAI(ctx, provider, meetingDetails.MeetingID, instructions, transcript, false)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to summarize part %d of %d: %w", i+1, len(chunks), err)
+		}
+		partials = append(partials, partial)
+		s.broker.Publish(topic(ctx, meetingDetails.MeetingID), progress.Event{Type: progress.EventChunk,
+			SummarizedChunks: i + 1, TotalChunks: len(chunks)})
+	}
+	return s.reduce(ctx, provider, meetingDetails.MeetingID, tmpl, partials, limits.ChunkTokens)
+}
+
+// reduce merges the partial summaries, in several rounds when they do not fit in a single completion
+func (s *svc) reduce(ctx context.Context, provider llm.LLMProvider, meetingID string, tmpl *prompts.Template,
+	partials []*summary.Summary, maxTokens int) (*summary.Summary, string, error) {
+	instructions, err := tmpl.ReduceInstructions()
+	if err != nil {
+		return nil, "", err
+	}
+	for {
+		groups, err := groupPartials(partials, maxTokens)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(groups) == 1 {
+			return s.callAI(ctx, provider, meetingID, instructions, groups[0].content, true)
+		}
+
+		merged := make([]*summary.Summary, 0, len(groups))
+		for _, group := range groups {
+			if len(group.partials) == 1 {
+				merged = append(merged, group.partials[0])
+				continue
+			}
+			partial, _, err := s.callAI(ctx, provider, meetingID, instructions, group.content, false)
+			if err != nil {
+				return nil, "", err
+			}
+			merged = append(merged, partial)
+		}
+		partials = merged
+	}
+}
+
+type partialGroup struct {
+	partials []*summary.Summary
+	content  string
+}
+
+// groupPartials packs consecutive partial summaries within maxTokens. A group always holds at least
+// two partials when possible, so that every reduce round shrinks their number.
+func groupPartials(partials []*summary.Summary, maxTokens int) ([]partialGroup, error) {
+	groups := make([]partialGroup, 0)
+	var current []*summary.Summary
+	for _, partial := range partials {
+		candidate, err := json.Marshal(append(current, partial))
+		if err != nil {
+			return nil, err
+		}
+		if len(current) >= 2 && chunking.EstimateTokens(string(candidate)) > maxTokens {
+			group, err := newPartialGroup(current)
+			if err != nil {
+				return nil, err
+			}
+			groups = append(groups, *group)
+			current = nil
+		}
+		current = append(current, partial)
+	}
+	group, err := newPartialGroup(current)
+	if err != nil {
+		return nil, err
+	}
+	return append(groups, *group), nil
+}
+
+func newPartialGroup(partials []*summary.Summary) (*partialGroup, error) {
+	content, err := json.Marshal(partials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the partial summaries: %w", err)
+	}
+	return &partialGroup{partials: partials, content: string(content)}, nil
+}
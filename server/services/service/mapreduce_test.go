@@ -0,0 +1,124 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/services/llm"
+	"meeting-analyzer/server/services/progress"
+	"meeting-analyzer/server/services/prompts"
+	"meeting-analyzer/server/services/summary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func longMeetingDetails(turns int) *models.MeetingDetails {
+	details := &models.MeetingDetails{MeetingID: "meeting-1", MeetingTitle: "All hands"}
+	for i := 0; i < turns; i++ {
+		details.Transcription = append(details.Transcription, models.Transcription{
+			MemberName: fmt.Sprintf("Speaker %d", i%3),
+			Content:    strings.Repeat("word ", 40),
+		})
+	}
+	return details
+}
+
+func lookupTemplate(t *testing.T, style string) *prompts.Template {
+	tmpl, err := prompts.Lookup(style)
+	require.NoError(t, err)
+	return tmpl
+}
+
+func TestSummarizeTranscription_SingleChunk(t *testing.T) {
+	provider := &fakeProvider{contents: []string{validSummary}, limits: llm.Limits{ChunkTokens: 100000}}
+	s := &svc{llm: provider, broker: progress.NewBroker(0)}
+
+	structured, model, err := s.summarizeTranscription(context.Background(), provider, longMeetingDetails(10),
+		lookupTemplate(t, prompts.DefaultStyle))
+
+	require.NoError(t, err)
+	assert.Equal(t, "summary", structured.Summary)
+	assert.Equal(t, "fake-model", model)
+	assert.Len(t, provider.Requests(), 1)
+}
+
+func TestSummarizeTranscription_MapReduce(t *testing.T) {
+	merged := `{"summary":"merged","key_points":[],"decisions":[],"action_items":[]}`
+	provider := &fakeProvider{
+		contents: []string{validSummary, validSummary, validSummary, merged},
+		limits:   llm.Limits{ChunkTokens: 150},
+	}
+	s := &svc{llm: provider, broker: progress.NewBroker(0)}
+	events, unsubscribe := s.broker.Subscribe(progress.Topic{EstateID: "e1", MeetingID: "meeting-1"})
+	defer unsubscribe()
+
+	// every turn is about 60 tokens, so that chunks hold 2 turns
+	tmpl := lookupTemplate(t, "retrospective")
+	reduceInstructions, err := tmpl.ReduceInstructions()
+	require.NoError(t, err)
+
+	structured, _, err := s.summarizeTranscription(tenantContext, provider, longMeetingDetails(6), tmpl)
+
+	require.NoError(t, err)
+	assert.Equal(t, "merged", structured.Summary)
+	requests := provider.Requests()
+	require.Len(t, requests, 4)
+	for i, request := range requests[:3] {
+		assert.Contains(t, request.Messages[0].Content, fmt.Sprintf("part %d of 3", i+1))
+		assert.Contains(t, request.Messages[1].Content, "Meeting Transcription: All hands")
+	}
+	assert.Contains(t, requests[3].Messages[0].Content, reduceInstructions)
+	assert.True(t, strings.HasPrefix(requests[3].Messages[1].Content, `[{"summary":"summary"`))
+
+	// the chunks report their progress, only the output of the reduce is published
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, progress.Event{Type: progress.EventChunk, SummarizedChunks: i, TotalChunks: 3}, <-events)
+	}
+	assert.Equal(t, progress.Event{Type: progress.EventDelta, Attempt: 1, Content: merged}, <-events)
+	assert.Empty(t, events)
+}
+
+func TestSummarizeTranscription_PartFails(t *testing.T) {
+	provider := &fakeProvider{err: &llm.StatusError{StatusCode: 500}, limits: llm.Limits{ChunkTokens: 150}}
+	s := &svc{llm: provider, broker: progress.NewBroker(0)}
+
+	_, _, err := s.summarizeTranscription(context.Background(), provider, longMeetingDetails(8),
+		lookupTemplate(t, prompts.DefaultStyle))
+
+	assert.ErrorContains(t, err, "failed to summarize part 1 of")
+}
+
+func TestReduce_SeveralRounds(t *testing.T) {
+	partials := make([]*summary.Summary, 0)
+	for i := 0; i < 5; i++ {
+		partials = append(partials, &summary.Summary{Summary: strings.Repeat("x", 200)})
+	}
+	provider := &fakeProvider{contents: []string{validSummary}}
+	s := &svc{llm: provider, broker: progress.NewBroker(0)}
+
+	structured, _, err := s.reduce(context.Background(), provider, "meeting-1", lookupTemplate(t, prompts.DefaultStyle),
+		partials, 120)
+
+	require.NoError(t, err)
+	assert.Equal(t, "summary", structured.Summary)
+	// 5 partials are merged in pairs: 2 calls and a leftover, then 3 partials: 1 call and a leftover, then the final call
+	assert.Len(t, provider.Requests(), 4)
+}
+
+func TestGroupPartials(t *testing.T) {
+	small := &summary.Summary{Summary: "small"}
+	large := &summary.Summary{Summary: strings.Repeat("x", 400)}
+
+	groups, err := groupPartials([]*summary.Summary{small, small, large, large, small}, 100)
+
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+	assert.Equal(t, []*summary.Summary{small, small}, groups[0].partials)
+	assert.Equal(t, []*summary.Summary{large, large}, groups[1].partials)
+	assert.Equal(t, []*summary.Summary{small}, groups[2].partials)
+}
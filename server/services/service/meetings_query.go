@@ -0,0 +1,117 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package service
+
+import (
+	"fmt"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/utils"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+var (
+	textOperators = []dbmodels.FilterOperator{dbmodels.OperatorEq, dbmodels.OperatorNeq, dbmodels.OperatorLike,
+		dbmodels.OperatorIlike}
+	participantOperators = []dbmodels.FilterOperator{dbmodels.OperatorEq, dbmodels.OperatorLike, dbmodels.OperatorIlike}
+	timeOperators        = []dbmodels.FilterOperator{dbmodels.OperatorEq, dbmodels.OperatorNeq, dbmodels.OperatorGt,
+		dbmodels.OperatorGte, dbmodels.OperatorLt, dbmodels.OperatorLte}
+)
+
+// toMeetingsQuery validates the listing parameters and converts them to a repository query
+func toMeetingsQuery(params generated.GetMeetingSummariesParams) (*dbmodels.MeetingsQuery, error) {
+	query := &dbmodels.MeetingsQuery{
+		Offset: utils.GetPtrValue(params.Offset, 0),
+		Limit:  utils.GetPtrValue(params.Limit, defaultPageLimit),
+	}
+	if query.Offset < 0 || query.Limit < 1 || query.Limit > maxPageLimit {
+		return nil, errorresponse.ErrBadPaginationParams
+	}
+
+	filters := []struct {
+		field       string
+		expressions *[]string
+		operators   []dbmodels.FilterOperator
+		isTime      bool
+	}{
+		{field: "meeting_id", expressions: params.MeetingId, operators: textOperators},
+		{field: "title", expressions: params.Title, operators: textOperators},
+		{field: "participant", expressions: params.Participant, operators: participantOperators},
+		{field: "created_at", expressions: params.CreatedAt, operators: timeOperators, isTime: true},
+		{field: "updated_at", expressions: params.UpdatedAt, operators: timeOperators, isTime: true},
+	}
+	for _, f := range filters {
+		for _, expression := range utils.GetPtrValue(f.expressions, nil) {
+			filter, err := parseFilter(f.field, expression, f.operators, f.isTime)
+			if err != nil {
+				return nil, err
+			}
+			query.Filters = append(query.Filters, *filter)
+		}
+	}
+
+	sort, err := parseOrder(utils.GetPtrValue(params.Order, ""))
+	if err != nil {
+		return nil, err
+	}
+	query.Sort = sort
+	return query, nil
+}
+
+// parseFilter parses an operator.value expression, * is the wildcard of the like operators
+func parseFilter(field string, expression string, operators []dbmodels.FilterOperator, isTime bool) (*dbmodels.Filter, error) {
+	op, value, found := strings.Cut(expression, ".")
+	if !found {
+		return nil, fmt.Errorf("%w %s=%s, expected operator.value", errorresponse.ErrInvalidFilterValue, field, expression)
+	}
+	operator := dbmodels.FilterOperator(op)
+	if !containsOperator(operators, operator) {
+		return nil, fmt.Errorf("%w %s on %s", errorresponse.ErrInvalidFilterOperator, op, field)
+	}
+
+	filter := &dbmodels.Filter{Field: field, Operator: operator, Value: value}
+	switch {
+	case isTime:
+		timestamp, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s=%s, expected an RFC 3339 time", errorresponse.ErrInvalidFilterValue, field, expression)
+		}
+		filter.Value = timestamp
+	case operator == dbmodels.OperatorLike || operator == dbmodels.OperatorIlike:
+		filter.Value = strings.ReplaceAll(value, "*", "%")
+	}
+	return filter, nil
+}
+
+// parseOrder parses a comma separated list of field[.asc|.desc] terms
+func parseOrder(order string) ([]dbmodels.SortField, error) {
+	if order == "" {
+		return nil, nil
+	}
+	terms := strings.Split(order, ",")
+	sort := make([]dbmodels.SortField, 0, len(terms))
+	for _, term := range terms {
+		field, direction, _ := strings.Cut(strings.TrimSpace(term), ".")
+		if field == "" || (direction != "" && direction != "asc" && direction != "desc") {
+			return nil, fmt.Errorf("%w %s", errorresponse.ErrInvalidSortField, term)
+		}
+		sort = append(sort, dbmodels.SortField{Field: field, Descending: direction == "desc"})
+	}
+	return sort, nil
+}
+
+func containsOperator(operators []dbmodels.FilterOperator, operator dbmodels.FilterOperator) bool {
+	for _, o := range operators {
+		if o == operator {
+			return true
+		}
+	}
+	return false
+}
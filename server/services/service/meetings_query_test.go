@@ -0,0 +1,107 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package service
+
+import (
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/utils"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToMeetingsQuery(t *testing.T) {
+	query, err := toMeetingsQuery(generated.GetMeetingSummariesParams{
+		Offset:      utils.ToPointer(20),
+		Limit:       utils.ToPointer(10),
+		Order:       utils.ToPointer("title.asc, created_at.desc,meeting_id"),
+		Title:       &[]string{"ilike.*weekly*"},
+		Participant: &[]string{"eq.Alice"},
+		CreatedAt:   &[]string{"gte.2024-01-01T00:00:00Z", "lt.2024-02-01T00:00:00Z"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &dbmodels.MeetingsQuery{
+		Offset: 20,
+		Limit:  10,
+		Filters: []dbmodels.Filter{
+			{Field: "title", Operator: dbmodels.OperatorIlike, Value: "%weekly%"},
+			{Field: "participant", Operator: dbmodels.OperatorEq, Value: "Alice"},
+			{Field: "created_at", Operator: dbmodels.OperatorGte, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{Field: "created_at", Operator: dbmodels.OperatorLt, Value: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		Sort: []dbmodels.SortField{
+			{Field: "title"},
+			{Field: "created_at", Descending: true},
+			{Field: "meeting_id"},
+		},
+	}, query)
+}
+
+func TestToMeetingsQuery_Defaults(t *testing.T) {
+	query, err := toMeetingsQuery(generated.GetMeetingSummariesParams{})
+
+	require.NoError(t, err)
+	assert.Equal(t, &dbmodels.MeetingsQuery{Offset: 0, Limit: defaultPageLimit}, query)
+}
+
+func TestToMeetingsQuery_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		params generated.GetMeetingSummariesParams
+		err    error
+	}{
+		{
+			name:   "NegativeOffset",
+			params: generated.GetMeetingSummariesParams{Offset: utils.ToPointer(-1)},
+			err:    errorresponse.ErrBadPaginationParams,
+		},
+		{
+			name:   "LimitTooLarge",
+			params: generated.GetMeetingSummariesParams{Limit: utils.ToPointer(maxPageLimit + 1)},
+			err:    errorresponse.ErrBadPaginationParams,
+		},
+		{
+			name:   "ZeroLimit",
+			params: generated.GetMeetingSummariesParams{Limit: utils.ToPointer(0)},
+			err:    errorresponse.ErrBadPaginationParams,
+		},
+		{
+			name:   "MissingOperator",
+			params: generated.GetMeetingSummariesParams{Title: &[]string{"weekly"}},
+			err:    errorresponse.ErrInvalidFilterValue,
+		},
+		{
+			name:   "UnsupportedOperator",
+			params: generated.GetMeetingSummariesParams{CreatedAt: &[]string{"ilike.2024"}},
+			err:    errorresponse.ErrInvalidFilterOperator,
+		},
+		{
+			name:   "NeqOnParticipant",
+			params: generated.GetMeetingSummariesParams{Participant: &[]string{"neq.Alice"}},
+			err:    errorresponse.ErrInvalidFilterOperator,
+		},
+		{
+			name:   "InvalidTime",
+			params: generated.GetMeetingSummariesParams{UpdatedAt: &[]string{"gt.yesterday"}},
+			err:    errorresponse.ErrInvalidFilterValue,
+		},
+		{
+			name:   "InvalidSortDirection",
+			params: generated.GetMeetingSummariesParams{Order: utils.ToPointer("title.up")},
+			err:    errorresponse.ErrInvalidSortField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := toMeetingsQuery(tt.params)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
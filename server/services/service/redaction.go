@@ -0,0 +1,256 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/services/access"
+	"meeting-analyzer/server/services/redaction"
+	"slices"
+
+	log "eos2git.cec.lab.emc.com/ISG-Edge/hzp-go-commons/logger"
+)
+
+// redactionBatchSize is the number of meetings stored before their redaction which are redacted at once
+const redactionBatchSize = 100
+
+// redact returns copies of the meeting and its segments whose title, member names and contents have their personal
+// data and secrets replaced by the placeholders of the report
+func (s *svc) redact(meeting *dbmodels.Meeting, segments []dbmodels.TranscriptSegment,
+	report *redaction.Report) (*dbmodels.Meeting, []dbmodels.TranscriptSegment) {
+	redactedMeeting := *meeting
+	redactedMeeting.Title = s.redactor.Redact(meeting.Title, report)
+	redactedSegments := make([]dbmodels.TranscriptSegment, 0, len(segments))
+	for _, segment := range segments {
+		segment.MemberName = s.redactor.Redact(segment.MemberName, report)
+		segment.Content = s.redactor.Redact(segment.Content, report)
+		redactedSegments = append(redactedSegments, segment)
+	}
+	return &redactedMeeting, redactedSegments
+}
+
+// redactStoredMeetings runs in the background until ctx is done and redacts the title and transcript of the meetings
+// stored before they were redacted, in batches of redactionBatchSize. The meetings which fail are logged and skipped,
+// they are retried on the next start, like the meetings left when the service stops.
+func (s *svc) redactStoredMeetings(ctx context.Context) {
+	defer close(s.redactionDone)
+	var afterEstateID, afterMeetingID string
+	for {
+		meetings, err := s.repo.ListUnredactedMeetings(ctx, afterEstateID, afterMeetingID, redactionBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Error(ctx, nil, "", err, "failed to list the meetings stored before they were redacted")
+			}
+			return
+		}
+		for i := range meetings {
+			if ctx.Err() != nil {
+				return
+			}
+			if err = s.redactStoredMeeting(ctx, &meetings[i]); err != nil {
+				log.Error(ctx, nil, "", err, "failed to redact meeting %s of estate %s", meetings[i].MeetingID,
+					meetings[i].EstateID)
+			}
+		}
+		if len(meetings) < redactionBatchSize {
+			return
+		}
+		afterEstateID, afterMeetingID = meetings[len(meetings)-1].EstateID, meetings[len(meetings)-1].MeetingID
+	}
+}
+
+// redactStoredMeeting redacts the title and transcript of the meeting stored before they were redacted. The
+// placeholders of the values dropped from its summary are kept.
+func (s *svc) redactStoredMeeting(ctx context.Context, meeting *dbmodels.Meeting) error {
+	ctx = tenancy.NewContext(ctx, tenancy.Tenant{EstateID: meeting.EstateID})
+	segments, err := s.repo.GetTranscriptSegments(ctx, meeting.MeetingID)
+	if err != nil {
+		return err
+	}
+	report, err := s.reportOf(ctx, meeting, false)
+	if err != nil {
+		return err
+	}
+	known := len(report.Entries)
+	redactedMeeting, redactedSegments := s.redact(meeting, segments, report)
+	values, err := s.seal(ctx, meeting, report.Entries[known:])
+	if err != nil {
+		return err
+	}
+	return s.repo.RedactTranscript(ctx, redactedMeeting, redactedSegments, values)
+}
+
+// seal returns the entries redacted from the meeting as they are stored, with their values sealed. The values are
+// dropped when no redaction key is configured.
+func (s *svc) seal(ctx context.Context, meeting *dbmodels.Meeting, entries []redaction.Entry) ([]dbmodels.RedactedValue, error) {
+	values := make([]dbmodels.RedactedValue, 0, len(entries))
+	for _, entry := range entries {
+		value := dbmodels.RedactedValue{
+			Placeholder: entry.Placeholder,
+			Category:    generated.RedactionCategoryEnum(entry.Category),
+		}
+		if s.sealer != nil {
+			sealed, keyID, err := s.sealer.Seal(ctx, entry.Value, binding(meeting, entry.Placeholder))
+			if err != nil {
+				return nil, fmt.Errorf("failed to seal redacted value %s of meeting %s: %w", entry.Placeholder,
+					meeting.MeetingID, err)
+			}
+			value.Sealed, value.KeyID = sealed, keyID
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// reportOf returns the report of the values redacted from the meeting, their values are only opened when reveal is
+// set. The values which cannot be opened, e.g. once their key is removed, keep their placeholder.
+func (s *svc) reportOf(ctx context.Context, meeting *dbmodels.Meeting, reveal bool) (*redaction.Report, error) {
+	values, err := s.repo.GetRedactedValues(ctx, meeting.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+	report := &redaction.Report{Entries: make([]redaction.Entry, 0, len(values))}
+	for _, value := range values {
+		entry := redaction.Entry{Placeholder: value.Placeholder, Category: redaction.Category(value.Category)}
+		if reveal && value.Sealed != nil {
+			entry.Value = s.open(ctx, meeting, value)
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	return report, nil
+}
+
+// open returns the redacted value, or an empty value, which keeps the placeholder, when it cannot be opened
+func (s *svc) open(ctx context.Context, meeting *dbmodels.Meeting, value dbmodels.RedactedValue) string {
+	err := ErrNoRedactionKey
+	if s.sealer != nil {
+		var opened string
+		if opened, err = s.sealer.Open(ctx, value.Sealed, value.KeyID, binding(meeting, value.Placeholder)); err == nil {
+			return opened
+		}
+	}
+	log.Error(ctx, nil, "", err, "failed to open redacted value %s of meeting %s, its placeholder is returned",
+		value.Placeholder, meeting.MeetingID)
+	return ""
+}
+
+// revealedReport returns the report of the values redacted from the meeting, opened, when the initiator of ctx may
+// read them, and nil otherwise
+func (s *svc) revealedReport(ctx context.Context, meeting *dbmodels.Meeting) (*redaction.Report, error) {
+	if !mayReveal(ctx, meeting) {
+		return nil, nil
+	}
+	return s.reportOf(ctx, meeting, true)
+}
+
+// binding ties the sealed value to its meeting and placeholder, it cannot be opened as the value of another one
+func binding(meeting *dbmodels.Meeting, placeholder string) string {
+	return meeting.EstateID + "/" + meeting.MeetingID + "/" + placeholder
+}
+
+// mayReveal tells whether the initiator of ctx may read the values redacted from the meeting
+func mayReveal(ctx context.Context, meeting *dbmodels.Meeting) bool {
+	tenant, _ := tenancy.FromContext(ctx)
+	return access.Allowed(tenant, access.ACL{Owner: meeting.CreatedBy}, access.ActionReveal)
+}
+
+// revealSummary restores the redacted values in the meeting summary when the initiator of ctx may read them. The
+// values are only read when the summary holds placeholders.
+func (s *svc) revealSummary(ctx context.Context, meeting *dbmodels.Meeting, res *generated.MeetingSummary) error {
+	if res.Redactions == nil && !redaction.HasPlaceholders(res.Title) &&
+		!slices.ContainsFunc(res.Participants, redaction.HasPlaceholders) {
+		return nil
+	}
+	report, err := s.revealedReport(ctx, meeting)
+	if err != nil || report == nil {
+		return err
+	}
+	res.Title = report.Restore(res.Title)
+	res.Participants = restoreAll(report, res.Participants)
+	if res.Abstract != nil {
+		abstract := report.Restore(*res.Abstract)
+		res.Abstract = &abstract
+	}
+	res.KeyPoints = restoreAll(report, res.KeyPoints)
+	res.Decisions = restoreAll(report, res.Decisions)
+	for i, item := range res.ActionItems {
+		res.ActionItems[i].Description = report.Restore(item.Description)
+		res.ActionItems[i].Owner = restore(report, item.Owner)
+	}
+	if res.Analytics != nil {
+		res.Analytics = revealAnalytics(res.Analytics, report)
+	}
+	return nil
+}
+
+// revealActionItem restores the redacted values in the texts of the action item and of its source segment
+func revealActionItem(res *generated.MeetingActionItem, report *redaction.Report) {
+	res.Description = report.Restore(res.Description)
+	res.Owner = restore(report, res.Owner)
+	res.DuePhrase = restore(report, res.DuePhrase)
+	if res.SourceSegment != nil {
+		res.SourceSegment.MemberName = report.Restore(res.SourceSegment.MemberName)
+		res.SourceSegment.Content = report.Restore(res.SourceSegment.Content)
+	}
+}
+
+// revealAnalytics returns a copy of the analytics with the redacted member names restored
+func revealAnalytics(analytics *generated.MeetingAnalytics, report *redaction.Report) *generated.MeetingAnalytics {
+	res := *analytics
+	res.Speakers = slices.Clone(analytics.Speakers)
+	for i := range res.Speakers {
+		res.Speakers[i].MemberName = report.Restore(res.Speakers[i].MemberName)
+	}
+	if analytics.LongestMonologue != nil {
+		monologue := *analytics.LongestMonologue
+		monologue.MemberName = report.Restore(monologue.MemberName)
+		res.LongestMonologue = &monologue
+	}
+	res.Interruptions = slices.Clone(analytics.Interruptions)
+	for i := range res.Interruptions {
+		res.Interruptions[i].MemberName = report.Restore(res.Interruptions[i].MemberName)
+		res.Interruptions[i].InterruptedMemberName = report.Restore(res.Interruptions[i].InterruptedMemberName)
+	}
+	return &res
+}
+
+func restore(report *redaction.Report, text *string) *string {
+	if text == nil {
+		return nil
+	}
+	restored := report.Restore(*text)
+	return &restored
+}
+
+func restoreAll(report *redaction.Report, texts []string) []string {
+	restored := make([]string, 0, len(texts))
+	for _, text := range texts {
+		restored = append(restored, report.Restore(text))
+	}
+	return restored
+}
+
+// toDBRedactions lists the placeholders of the report, without their values
+func toDBRedactions(report *redaction.Report) []dbmodels.Redaction {
+	redactions := make([]dbmodels.Redaction, 0, len(report.Entries))
+	for _, entry := range report.Entries {
+		redactions = append(redactions, dbmodels.Redaction{
+			Placeholder: entry.Placeholder,
+			Category:    generated.RedactionCategoryEnum(entry.Category),
+		})
+	}
+	return redactions
+}
+
+// toRedactions lists the placeholders of the redacted values, without the values
+func toRedactions(redactions []dbmodels.Redaction) []generated.Redaction {
+	res := make([]generated.Redaction, 0, len(redactions))
+	for _, r := range redactions {
+		res = append(res, generated.Redaction{Placeholder: r.Placeholder, Category: r.Category})
+	}
+	return res
+}
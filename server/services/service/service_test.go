@@ -1,3 +1,1095 @@
 // Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
 
 package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/commons/utils"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"meeting-analyzer/server/repositories/repotest"
+	"meeting-analyzer/server/services/credentials"
+	"meeting-analyzer/server/services/extractive"
+	"meeting-analyzer/server/services/jobs"
+	"meeting-analyzer/server/services/llm"
+	"meeting-analyzer/server/services/prompts"
+	"meeting-analyzer/server/services/redaction"
+	"meeting-analyzer/server/services/summary"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tenantContext is the context of the requests of the estate e1
+var tenantContext = tenancy.NewContext(context.Background(), tenancy.Tenant{EstateID: "e1", InitiatorID: "u1"})
+
+// key returns the key of the meeting in the estate of tenantContext
+func key(meetingID string) repotest.Key {
+	return repotest.Key{EstateID: "e1", MeetingID: meetingID}
+}
+
+const validSummary = `{"summary":"summary","key_points":["point"],"decisions":["decision"],` +
+	`"action_items":[{"description":"Send the notes","owner":"Alice","due_date":"2024-01-05"}]}`
+
+// fakeProvider answers with contents in order, the last one is repeated
+type fakeProvider struct {
+	mu       sync.Mutex
+	requests []llm.CompletionRequest
+	contents []string
+	err      error
+	block    chan struct{}
+	limits   llm.Limits
+}
+
+func (f *fakeProvider) Complete(ctx context.Context, request llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	if f.block != nil {
+		select {
+		case <-f.block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, request)
+	if f.err != nil {
+		return nil, f.err
+	}
+	content := f.contents[min(len(f.requests), len(f.contents))-1]
+	return &llm.CompletionResponse{Content: content, Model: f.Model()}, nil
+}
+
+func (f *fakeProvider) Model() string {
+	return "fake-model"
+}
+
+func (f *fakeProvider) Limits() llm.Limits {
+	return f.limits
+}
+
+func (f *fakeProvider) Requests() []llm.CompletionRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]llm.CompletionRequest(nil), f.requests...)
+}
+
+// fakeStreamingProvider streams the answers of fakeProvider in deltas of 16 bytes
+type fakeStreamingProvider struct {
+	*fakeProvider
+}
+
+func (f *fakeStreamingProvider) Stream(ctx context.Context, request llm.CompletionRequest) (*llm.Stream, error) {
+	response, err := f.Complete(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return llm.NewStream(ctx, func(send func(delta string) bool) (*llm.CompletionResponse, error) {
+		for content := response.Content; content != ""; content = content[min(len(content), 16):] {
+			if !send(content[:min(len(content), 16)]) {
+				return nil, llm.ErrCompletionCanceled
+			}
+		}
+		return &llm.CompletionResponse{Model: response.Model}, nil
+	}), nil
+}
+
+// timestamp parses an RFC 3339 timestamp of the fixtures
+func timestamp(value string) *time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		panic(err)
+	}
+	return &parsed
+}
+
+func testMeetingDetails() *models.MeetingDetails {
+	return meetingDetails("meeting-1")
+}
+
+func meetingDetails(meetingID string) *models.MeetingDetails {
+	return &models.MeetingDetails{
+		MeetingID:    meetingID,
+		MeetingTitle: "Weekly sync",
+		Transcription: []models.Transcription{
+			{MemberName: "Alice", Timestamp: timestamp("2024-01-01T10:00:00Z"), Content: "Hello"},
+			{MemberName: "Bob", Timestamp: timestamp("2024-01-01T10:00:05Z"), Content: "Hi"},
+		},
+	}
+}
+
+// newTestSealer returns the sealer of a test redaction key
+func newTestSealer(t *testing.T) *redaction.Sealer {
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", redaction.KeySize)))
+	sealer, err := redaction.NewSealer(context.Background(), credentials.Static(key), nil)
+	require.NoError(t, err)
+	return sealer
+}
+
+func newTestSvc(t *testing.T, repo *repotest.Repository, provider llm.LLMProvider, config jobs.Config) Service {
+	s, err := NewSvc(context.Background(), repo, provider, config, redaction.Config{Categories: redaction.Categories,
+		Sealer: newTestSealer(t)})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = s.Shutdown(context.Background())
+	})
+	return s
+}
+
+func waitForJobStatus(t *testing.T, repo *repotest.Repository, jobID string, status generated.JobStatusEnum) {
+	require.Eventually(t, func() bool {
+		return repo.JobStatus(jobID) == status
+	}, 2*time.Second, 5*time.Millisecond)
+}
+
+func TestNewSvc_NilProvider(t *testing.T) {
+	_, err := NewSvc(context.Background(), repotest.NewRepository(), nil, jobs.Config{}, redaction.Config{})
+	assert.ErrorIs(t, err, ErrNilLLMProvider)
+}
+
+func TestNewSvc_FailsInterruptedJobs(t *testing.T) {
+	repo := repotest.NewRepository()
+	now := time.Now().UTC()
+	repo.Jobs["j1"] = dbmodels.SummaryJob{JobID: "j1", Status: generated.INPROGRESS, Owner: "i1", HeartbeatAt: now}
+	repo.Jobs["j2"] = dbmodels.SummaryJob{JobID: "j2", Status: generated.DONE, Owner: "i1", HeartbeatAt: now}
+	repo.Jobs["j3"] = dbmodels.SummaryJob{JobID: "j3", Status: generated.PENDING, Owner: "i2", HeartbeatAt: now}
+	repo.Jobs["j4"] = dbmodels.SummaryJob{JobID: "j4", Status: generated.INPROGRESS, Owner: "i2",
+		HeartbeatAt: now.Add(-time.Hour)}
+
+	newTestSvc(t, repo, &fakeProvider{}, jobs.Config{InstanceID: "i1", Lease: time.Minute})
+
+	assert.Equal(t, generated.FAILED, repo.JobStatus("j1"), "the job of the previous run of the instance")
+	assert.Equal(t, generated.DONE, repo.JobStatus("j2"))
+	assert.Equal(t, generated.PENDING, repo.JobStatus("j3"), "the job of another running instance")
+	assert.Equal(t, generated.FAILED, repo.JobStatus("j4"), "the job whose lease expired")
+}
+
+func TestNewSvc_RenewsLeases(t *testing.T) {
+	repo := repotest.NewRepository()
+	newTestSvc(t, repo, &fakeProvider{}, jobs.Config{InstanceID: "i1", Lease: 40 * time.Millisecond})
+	started := time.Now().UTC()
+	require.NoError(t, repo.CreateMeeting(tenantContext, &dbmodels.Meeting{MeetingID: "m1", EstateID: "e1"}, nil, nil, nil,
+		&dbmodels.SummaryJob{JobID: "j1", MeetingID: "m1", Status: generated.INPROGRESS, Owner: "i1", HeartbeatAt: started}))
+	require.NoError(t, repo.CreateMeeting(tenantContext, &dbmodels.Meeting{MeetingID: "m2", EstateID: "e1"}, nil, nil, nil,
+		&dbmodels.SummaryJob{JobID: "j2", MeetingID: "m2", Status: generated.INPROGRESS, Owner: "i2", HeartbeatAt: started}))
+
+	waitForJobStatus(t, repo, "j2", generated.FAILED)
+
+	assert.Equal(t, generated.INPROGRESS, repo.JobStatus("j1"))
+	assert.True(t, repo.Job("j1").HeartbeatAt.After(started))
+}
+
+func TestGenerateMeetingSummary_Success(t *testing.T) {
+	provider := &fakeProvider{contents: []string{validSummary}, block: make(chan struct{})}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "meeting-1", res.MeetingId)
+	assert.NotEmpty(t, res.JobId)
+	assert.Equal(t, generated.PENDING, res.Status)
+	waitForJobStatus(t, repo, res.JobId, generated.INPROGRESS)
+
+	close(provider.block)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+
+	requests := provider.Requests()
+	require.Len(t, requests, 1)
+	require.Len(t, requests[0].Messages, 2)
+	assert.Equal(t, llm.RoleSystem, requests[0].Messages[0].Role)
+	assert.Equal(t, llm.RoleUser, requests[0].Messages[1].Role)
+	assert.Contains(t, requests[0].Messages[1].Content, "Meeting Transcription: Weekly sync")
+	assert.Equal(t, llm.ResponseFormatJSONSchema, requests[0].ResponseFormat.Type)
+
+	assert.Equal(t, "Weekly sync", repo.Meetings[key("meeting-1")].Title)
+	segments := repo.Segments[key("meeting-1")]
+	require.Len(t, segments, 2)
+	assert.Equal(t, 1, segments[1].Seq)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 5, 0, time.UTC), *segments[1].Timestamp)
+	summary, err := repo.GetSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, "summary", summary.Content)
+	assert.Equal(t, []string{"point"}, summary.KeyPoints)
+	assert.Equal(t, []string{"decision"}, summary.Decisions)
+	assert.Equal(t, []dbmodels.ActionItem{{Description: "Send the notes", Owner: utils.ToPointer("Alice"),
+		DueDate: utils.ToPointer("2024-01-05")}}, summary.ActionItems)
+	assert.Equal(t, "fake-model", summary.Model)
+	assert.Equal(t, prompts.DefaultStyle, summary.PromptTemplate)
+	assert.Equal(t, 1, summary.PromptVersion)
+	require.NotNil(t, summary.Analytics)
+	assert.Len(t, summary.Analytics.Speakers, 2)
+}
+
+func TestGenerateMeetingSummary_StampsTenant(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{validSummary}}, jobs.Config{Workers: 1, QueueSize: 1})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(),
+		models.GenerateOptions{IdempotencyKey: "key-1"})
+
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+	meeting, job, summary := repo.Meetings[key("meeting-1")], repo.Jobs[res.JobId], repo.Summaries[key("meeting-1")]
+	assert.Equal(t, []string{"e1", "u1"}, []string{meeting.EstateID, meeting.CreatedBy})
+	assert.Equal(t, []string{"e1", "u1"}, []string{job.EstateID, job.CreatedBy})
+	assert.Equal(t, []string{"e1", "u1"}, []string{summary.EstateID, summary.CreatedBy})
+	assert.Equal(t, "e1", repo.Keys[[2]string{"e1", "key-1"}].EstateID)
+}
+
+func TestGenerateMeetingSummary_SharesWithParticipants(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{validSummary}}, jobs.Config{Workers: 1, QueueSize: 1})
+	details := testMeetingDetails()
+	details.Transcription[0].MemberID = "u1"
+	details.Transcription[1].MemberID = "bob"
+	details.Transcription = append(details.Transcription, models.Transcription{MemberName: "Bob", MemberID: " bob ",
+		Content: "Bye"}, models.Transcription{MemberName: "Carol", Content: "See you"})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, details, models.GenerateOptions{})
+
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+	// the initiator storing the meeting already owns it, the speakers without initiator are not shared it
+	shares := repo.Shares[key("meeting-1")]
+	require.Len(t, shares, 1)
+	assert.Equal(t, []string{"bob", "u1"}, []string{shares[0].PrincipalID, shares[0].CreatedBy})
+	assert.Equal(t, generated.User, shares[0].PrincipalType)
+
+	// the shares are kept when the meeting is stored again
+	details.Transcription = details.Transcription[:1]
+	res, err = s.GenerateMeetingSummary(tenantContext, details, models.GenerateOptions{Force: true})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+	assert.Len(t, repo.Shares[key("meeting-1")], 1)
+}
+
+func TestGenerateMeetingSummary_WithoutTenant(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{validSummary}}, jobs.Config{Workers: 1, QueueSize: 1})
+
+	_, err := s.GenerateMeetingSummary(context.Background(), testMeetingDetails(), models.GenerateOptions{})
+
+	assert.ErrorIs(t, err, errorresponse.ErrUnauthenticated)
+	assert.Zero(t, repo.JobCount())
+}
+
+func TestGenerateMeetingSummary_SummaryStyle(t *testing.T) {
+	provider := &fakeProvider{contents: []string{validSummary}}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+	tmpl, err := prompts.Lookup("engineering_standup")
+	require.NoError(t, err)
+	instructions, err := tmpl.Instructions()
+	require.NoError(t, err)
+
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(),
+		models.GenerateOptions{SummaryStyle: "engineering_standup"})
+
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+	requests := provider.Requests()
+	require.Len(t, requests, 1)
+	assert.True(t, strings.HasPrefix(requests[0].Messages[0].Content, instructions))
+	done, err := s.GetMeetingSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, utils.ToPointer(generated.EngineeringStandup), done.SummaryStyle)
+	assert.Equal(t, utils.ToPointer(tmpl.Version), done.PromptVersion)
+}
+
+func TestGenerateMeetingSummary_UnknownSummaryStyle(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{Workers: 1, QueueSize: 1})
+
+	_, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(),
+		models.GenerateOptions{SummaryStyle: "haiku"})
+
+	assert.ErrorIs(t, err, errorresponse.ErrUnknownSummaryStyle)
+	assert.Empty(t, repo.Meetings)
+	assert.Zero(t, repo.JobCount())
+}
+
+func TestGenerateMeetingSummary_ProviderError(t *testing.T) {
+	providerErr := &llm.StatusError{StatusCode: 502, Body: `{"error":"cannot summarize Hello"}`}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{err: providerErr}, jobs.Config{Workers: 1, QueueSize: 1})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.FAILED)
+	job, err := repo.GetJob(tenantContext, res.JobId)
+	require.NoError(t, err)
+	// the body is not returned, it may echo the transcript
+	assert.Equal(t, "LLM_UNAVAILABLE: llm provider returned status 502", *job.Error)
+	_, err = repo.GetSummary(tenantContext, "meeting-1")
+	assert.ErrorIs(t, err, errorresponse.ErrSummaryNotFound)
+}
+
+func TestGenerateMeetingSummary_RepairsInvalidAnswer(t *testing.T) {
+	provider := &fakeProvider{contents: []string{"The meeting was short.", validSummary}}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+	requests := provider.Requests()
+	require.Len(t, requests, 2)
+	retry := requests[1].Messages
+	require.Len(t, retry, 4)
+	assert.Equal(t, llm.Message{Role: llm.RoleAssistant, Content: "The meeting was short."}, retry[2])
+	assert.Equal(t, llm.RoleUser, retry[3].Role)
+	assert.Contains(t, retry[3].Content, summary.ErrInvalidSummary.Error())
+}
+
+func TestGenerateMeetingSummary_InvalidAnswers(t *testing.T) {
+	provider := &fakeProvider{contents: []string{`{"summary":""}`}}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.FAILED)
+	assert.Len(t, provider.Requests(), maxSummaryAttempts)
+	job, err := repo.GetJob(tenantContext, res.JobId)
+	require.NoError(t, err)
+	assert.Contains(t, *job.Error, summary.ErrInvalidSummary.Error())
+	assert.Contains(t, *job.Error, errorresponse.ErrLLMOutputInvalid.Error())
+}
+
+func TestGenerateMeetingSummary_QueueFull(t *testing.T) {
+	provider := &fakeProvider{contents: []string{validSummary}, block: make(chan struct{})}
+	defer close(provider.block)
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+
+	running, err := s.GenerateMeetingSummary(tenantContext, meetingDetails("meeting-1"), models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, running.JobId, generated.INPROGRESS)
+	_, err = s.GenerateMeetingSummary(tenantContext, meetingDetails("meeting-2"), models.GenerateOptions{})
+	require.NoError(t, err)
+
+	_, err = s.GenerateMeetingSummary(tenantContext, meetingDetails("meeting-3"), models.GenerateOptions{})
+
+	assert.ErrorIs(t, err, errorresponse.ErrJobQueueFull)
+}
+
+func TestGenerateMeetingSummary_InProgress(t *testing.T) {
+	provider := &fakeProvider{contents: []string{validSummary}, block: make(chan struct{})}
+	defer close(provider.block)
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+	_, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+
+	segments := repo.Segments[key("meeting-1")]
+	details := testMeetingDetails()
+	details.Transcription[0].Content = "A transcript posted during the generation"
+
+	_, err = s.GenerateMeetingSummary(tenantContext, details, models.GenerateOptions{})
+	assert.ErrorIs(t, err, errorresponse.ErrSummaryInProgress)
+
+	_, err = s.GenerateMeetingSummary(tenantContext, details, models.GenerateOptions{Force: true})
+	assert.ErrorIs(t, err, errorresponse.ErrSummaryInProgress, "a running generation is not forced")
+	assert.Equal(t, 1, repo.JobCount())
+	assert.Equal(t, segments, repo.Segments[key("meeting-1")], "the transcript being summarized is kept")
+}
+
+func TestGenerateMeetingSummary_Force(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{validSummary}}, jobs.Config{Workers: 1, QueueSize: 1})
+	first, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, first.JobId, generated.DONE)
+
+	_, err = s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	assert.ErrorIs(t, err, errorresponse.ErrSummaryAlreadyExists)
+
+	second, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{Force: true})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.JobId, second.JobId)
+	waitForJobStatus(t, repo, second.JobId, generated.DONE)
+}
+
+func TestGenerateMeetingSummary_RetriesFailedGeneration(t *testing.T) {
+	repo := repotest.NewRepository()
+	repo.Jobs["j1"] = dbmodels.SummaryJob{JobID: "j1", MeetingID: "meeting-1", Status: generated.FAILED}
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{validSummary}}, jobs.Config{Workers: 1, QueueSize: 1})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+}
+
+func TestGenerateMeetingSummary_IdempotencyKey(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{validSummary}}, jobs.Config{Workers: 1, QueueSize: 1})
+	options := models.GenerateOptions{IdempotencyKey: "key-1"}
+	first, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), options)
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, first.JobId, generated.DONE)
+
+	replayed, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), options)
+
+	require.NoError(t, err)
+	assert.Equal(t, first, replayed)
+	assert.Equal(t, 1, repo.JobCount())
+
+	details := testMeetingDetails()
+	details.MeetingTitle = "Another title"
+	_, err = s.GenerateMeetingSummary(tenantContext, details, options)
+	assert.ErrorIs(t, err, errorresponse.ErrIdempotencyKeyReused)
+	_, err = s.GenerateMeetingSummary(tenantContext, testMeetingDetails(),
+		models.GenerateOptions{IdempotencyKey: "key-1", Force: true})
+	assert.ErrorIs(t, err, errorresponse.ErrIdempotencyKeyReused)
+}
+
+func TestGenerateMeetingSummary_ExpiredIdempotencyKey(t *testing.T) {
+	repo := repotest.NewRepository()
+	repo.Keys[[2]string{"e1", "key-1"}] = dbmodels.IdempotencyKey{Key: "key-1", MeetingID: "meeting-1", JobID: "j1",
+		Status: generated.PENDING, CreatedAt: time.Now().Add(-IdempotencyKeyTTL - time.Minute)}
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{validSummary}}, jobs.Config{Workers: 1, QueueSize: 1})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(),
+		models.GenerateOptions{IdempotencyKey: "key-1"})
+
+	require.NoError(t, err)
+	assert.NotEqual(t, "j1", res.JobId)
+	assert.Equal(t, res.JobId, repo.Keys[[2]string{"e1", "key-1"}].JobID)
+}
+
+// downProvider is a provider whose circuit breaker is open
+type downProvider struct {
+	*fakeProvider
+}
+
+func (downProvider) Ready() error {
+	return llm.ErrProviderUnavailable
+}
+
+func TestGenerateMeetingSummary_ProviderUnavailable(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, downProvider{&fakeProvider{}}, jobs.Config{Workers: 1, QueueSize: 1})
+
+	_, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+
+	assert.ErrorIs(t, err, errorresponse.ErrLLMUnavailable)
+	assert.Empty(t, repo.Meetings)
+}
+
+func TestGenerateMeetingSummary_ExtractiveEngine(t *testing.T) {
+	provider := downProvider{&fakeProvider{}}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+	details := testMeetingDetails()
+	details.Transcription = append(details.Transcription, models.Transcription{MemberName: "Alice",
+		Content: "We decided to ship the release on Monday. I'll write the release notes by Friday."})
+
+	// the extractive engine does not need the provider which is down
+	res, err := s.GenerateMeetingSummary(tenantContext, details,
+		models.GenerateOptions{SummaryEngine: string(generated.Extractive)})
+
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+	assert.Empty(t, provider.Requests())
+	done, err := s.GetMeetingSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, extractive.Model, *done.Model)
+	assert.NotEmpty(t, *done.Abstract)
+	assert.Equal(t, []string{"Alice: We decided to ship the release on Monday."}, done.Decisions)
+	require.Len(t, done.ActionItems, 1)
+	assert.Equal(t, utils.ToPointer("Alice"), done.ActionItems[0].Owner)
+	items, err := s.ListActionItems(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, utils.ToPointer("by Friday"), items[0].DuePhrase)
+}
+
+func TestGenerateMeetingSummary_UnknownSummaryEngine(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{Workers: 1, QueueSize: 1})
+
+	_, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(),
+		models.GenerateOptions{SummaryEngine: "quantum"})
+
+	assert.ErrorIs(t, err, errorresponse.ErrUnknownSummaryEngine)
+	assert.Empty(t, repo.Meetings)
+}
+
+func TestGenerateMeetingSummary_RepositoryError(t *testing.T) {
+	provider := &fakeProvider{contents: []string{validSummary}}
+	repo := repotest.NewRepository()
+	repo.Err = errors.New("db down")
+	s := newTestSvc(t, repo, provider, jobs.Config{})
+
+	_, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+
+	assert.EqualError(t, err, "db down")
+	assert.Empty(t, repo.Jobs)
+}
+
+func TestGetMeetingSummary(t *testing.T) {
+	provider := &fakeProvider{contents: []string{validSummary}, block: make(chan struct{})}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+	details := testMeetingDetails()
+	details.Transcription = append(details.Transcription, models.Transcription{MemberName: "Alice", Content: "Bye"})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, details, models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.INPROGRESS)
+
+	pending, err := s.GetMeetingSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, &generated.MeetingSummary{
+		MeetingId:    "meeting-1",
+		Title:        "Weekly sync",
+		Participants: []string{"Alice", "Bob"},
+		KeyPoints:    []string{},
+		Decisions:    []string{},
+		ActionItems:  []generated.ActionItem{},
+		Status:       generated.INPROGRESS,
+	}, pending)
+
+	close(provider.block)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+
+	done, err := s.GetMeetingSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, generated.DONE, done.Status)
+	assert.Equal(t, "summary", *done.Abstract)
+	assert.Equal(t, []string{"point"}, done.KeyPoints)
+	assert.Equal(t, []string{"decision"}, done.Decisions)
+	assert.Equal(t, []generated.ActionItem{{
+		Description: "Send the notes",
+		Owner:       utils.ToPointer("Alice"),
+		DueDate:     &openapi_types.Date{Time: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+	}}, done.ActionItems)
+	assert.Equal(t, "fake-model", *done.Model)
+	assert.NotNil(t, done.CreatedAt)
+	assert.Nil(t, done.Error)
+}
+
+func TestGetMeetingSummary_Failed(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{err: errors.New("boom")}, jobs.Config{Workers: 1, QueueSize: 1})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.FAILED)
+
+	summary, err := s.GetMeetingSummary(tenantContext, "meeting-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, generated.FAILED, summary.Status)
+	assert.Contains(t, *summary.Error, "boom")
+	assert.Nil(t, summary.Abstract)
+}
+
+func TestGetMeetingSummary_UnknownMeeting(t *testing.T) {
+	s := newTestSvc(t, repotest.NewRepository(), &fakeProvider{}, jobs.Config{})
+
+	_, err := s.GetMeetingSummary(tenantContext, "unknown")
+
+	assert.ErrorIs(t, err, errorresponse.ErrMeetingIDNotFound)
+}
+
+func TestGenerateMeetingSummary_ExtractsActionItems(t *testing.T) {
+	content := `{"summary":"summary","key_points":[],"decisions":[],"action_items":[` +
+		`{"description":"Send the notes","owner":"bob","due_date":null,"due_phrase":"by Friday","quote":"Hi"}]}`
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{content}}, jobs.Config{Workers: 1, QueueSize: 1})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+
+	items, err := s.ListActionItems(tenantContext, "meeting-1")
+
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Send the notes", items[0].Description)
+	assert.Equal(t, utils.ToPointer("Bob"), items[0].Owner)
+	assert.Equal(t, &openapi_types.Date{Time: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)}, items[0].DueDate)
+	assert.Equal(t, utils.ToPointer(1), repo.ActionItems[key("meeting-1")][0].SegmentSeq)
+	assert.False(t, items[0].Done)
+}
+
+func TestGenerateMeetingSummary_KeepsActionItems(t *testing.T) {
+	content := `{"summary":"summary","key_points":[],"decisions":[],"action_items":[` +
+		`{"description":"Send the notes","owner":"bob","due_date":null,"quote":"Hi"},` +
+		`{"description":"Book a room","owner":null,"due_date":null}]}`
+	regenerated := `{"summary":"summary","key_points":[],"decisions":[],"action_items":[` +
+		`{"description":"Book a room","owner":null,"due_date":null},` +
+		`{"description":"Send the notes.","owner":"Bob","due_date":null,"quote":"Hi"}]}`
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{content, regenerated}},
+		jobs.Config{Workers: 1, QueueSize: 1})
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+	items, err := s.ListActionItems(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	_, err = s.UpdateActionItem(tenantContext, "meeting-1", items[0].Id, &generated.UpdateActionItemRequest{Done: true})
+	require.NoError(t, err)
+
+	res, err = s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{Force: true})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+
+	// the items keep their id, so that their urls still work, and their status
+	regeneratedItems, err := s.ListActionItems(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	require.Len(t, regeneratedItems, 2)
+	assert.Equal(t, items[1].Id, regeneratedItems[0].Id)
+	assert.False(t, regeneratedItems[0].Done)
+	assert.Equal(t, items[0].Id, regeneratedItems[1].Id)
+	assert.True(t, regeneratedItems[1].Done)
+	item, err := s.UpdateActionItem(tenantContext, "meeting-1", items[0].Id,
+		&generated.UpdateActionItemRequest{Done: false})
+	require.NoError(t, err)
+	assert.Equal(t, "Send the notes.", item.Description)
+}
+
+func TestListActionItems(t *testing.T) {
+	repo := repotest.NewRepository()
+	repo.Meetings[key("meeting-1")] = dbmodels.Meeting{MeetingID: "meeting-1", EstateID: "e1"}
+	timestamp := time.Date(2024, 1, 1, 10, 0, 5, 0, time.UTC)
+	dueDate := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	repo.ActionItems[key("meeting-1")] = []dbmodels.MeetingActionItem{{
+		ItemID:      "i1",
+		MeetingID:   "meeting-1",
+		Description: "Send the notes",
+		Owner:       utils.ToPointer("Bob"),
+		DueDate:     &dueDate,
+		DuePhrase:   utils.ToPointer("by Friday"),
+		SegmentSeq:  utils.ToPointer(1),
+		Segment:     &dbmodels.TranscriptSegment{Seq: 1, MemberName: "Bob", Timestamp: &timestamp, Content: "Hi"},
+	}}
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{})
+
+	items, err := s.ListActionItems(tenantContext, "meeting-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []generated.MeetingActionItem{{
+		Id:            "i1",
+		MeetingId:     "meeting-1",
+		Description:   "Send the notes",
+		Owner:         utils.ToPointer("Bob"),
+		DueDate:       &openapi_types.Date{Time: dueDate},
+		DuePhrase:     utils.ToPointer("by Friday"),
+		SourceSegment: &generated.SourceSegment{Seq: 1, MemberName: "Bob", Timestamp: &timestamp, Content: "Hi"},
+	}}, items)
+
+	_, err = s.ListActionItems(tenantContext, "unknown")
+	assert.ErrorIs(t, err, errorresponse.ErrMeetingIDNotFound)
+}
+
+func TestUpdateActionItem(t *testing.T) {
+	repo := repotest.NewRepository()
+	repo.Meetings[key("meeting-1")] = dbmodels.Meeting{MeetingID: "meeting-1", EstateID: "e1"}
+	repo.ActionItems[key("meeting-1")] = []dbmodels.MeetingActionItem{{ItemID: "i1", MeetingID: "meeting-1", Description: "Send the notes"}}
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{})
+
+	item, err := s.UpdateActionItem(tenantContext, "meeting-1", "i1", &generated.UpdateActionItemRequest{Done: true})
+
+	require.NoError(t, err)
+	assert.True(t, item.Done)
+	assert.True(t, repo.ActionItems[key("meeting-1")][0].Done)
+
+	_, err = s.UpdateActionItem(tenantContext, "meeting-2", "i1", &generated.UpdateActionItemRequest{Done: true})
+	assert.ErrorIs(t, err, errorresponse.ErrActionItemNotFound)
+}
+
+func TestGenerateMeetingSummary_RedactsTranscript(t *testing.T) {
+	content := `{"summary":"Alice shared [EMAIL_1]","key_points":["Call [PHONE_1]"],"decisions":[],"action_items":[` +
+		`{"description":"Write to [EMAIL_1]","owner":"Bob","due_date":null,"quote":"I will write to [EMAIL_1]"}]}`
+	provider := &fakeProvider{contents: []string{content}}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+	details := testMeetingDetails()
+	details.MeetingTitle = "Sync with alice@example.com"
+	details.Transcription[0].Content = "Mail alice@example.com or call +1 555 123 4567"
+	details.Transcription[1].Content = "I will write to alice@example.com"
+	readerContext := tenancy.NewContext(context.Background(), tenancy.Tenant{EstateID: "e1", InitiatorID: "reader"})
+
+	res, err := s.GenerateMeetingSummary(tenantContext, details, models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+
+	requests := provider.Requests()
+	require.Len(t, requests, 1)
+	assert.Contains(t, requests[0].Messages[0].Content, redaction.Instructions)
+	assert.Contains(t, requests[0].Messages[1].Content, "Mail [EMAIL_1] or call [PHONE_1]")
+	assert.NotContains(t, requests[0].Messages[1].Content, "alice@example.com")
+	// the title, transcript and summary are stored redacted, the values are sealed
+	assert.Equal(t, "Sync with [EMAIL_1]", repo.Meetings[key("meeting-1")].Title)
+	assert.Equal(t, "Mail [EMAIL_1] or call [PHONE_1]", repo.Segments[key("meeting-1")][0].Content)
+	values := repo.Redactions[key("meeting-1")]
+	require.Len(t, values, 2)
+	for _, value := range values {
+		assert.NotContains(t, string(value.Sealed), "alice@example.com")
+		assert.NotContains(t, string(value.Sealed), "555")
+	}
+	stored, err := repo.GetSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice shared [EMAIL_1]", stored.Content)
+	assert.Equal(t, []dbmodels.Redaction{
+		{Placeholder: "[EMAIL_1]", Category: generated.Email},
+		{Placeholder: "[PHONE_1]", Category: generated.Phone},
+	}, stored.Redactions)
+	assert.Equal(t, utils.ToPointer(1), repo.ActionItems[key("meeting-1")][0].SegmentSeq)
+
+	owned, err := s.GetMeetingSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Sync with alice@example.com", owned.Title)
+	assert.Equal(t, "Alice shared alice@example.com", *owned.Abstract)
+	assert.Equal(t, []string{"Call +1 555 123 4567"}, owned.KeyPoints)
+	assert.Equal(t, "Write to alice@example.com", owned.ActionItems[0].Description)
+	assert.Equal(t, &[]generated.Redaction{{Placeholder: "[EMAIL_1]", Category: generated.Email},
+		{Placeholder: "[PHONE_1]", Category: generated.Phone}}, owned.Redactions)
+	shared, err := s.GetMeetingSummary(readerContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Sync with [EMAIL_1]", shared.Title)
+	assert.Equal(t, "Alice shared [EMAIL_1]", *shared.Abstract)
+	assert.Equal(t, []string{"Call [PHONE_1]"}, shared.KeyPoints)
+	assert.Equal(t, owned.Redactions, shared.Redactions)
+
+	items, err := s.ListActionItems(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Write to alice@example.com", items[0].Description)
+	assert.Equal(t, "I will write to alice@example.com", items[0].SourceSegment.Content)
+	items, err = s.ListActionItems(readerContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Write to [EMAIL_1]", items[0].Description)
+	assert.Equal(t, "I will write to [EMAIL_1]", items[0].SourceSegment.Content)
+}
+
+func TestGenerateMeetingSummary_WithoutRedactionKey(t *testing.T) {
+	repo := repotest.NewRepository()
+	s, err := NewSvc(context.Background(), repo, &fakeProvider{contents: []string{validSummary}},
+		jobs.Config{Workers: 1, QueueSize: 1}, redaction.Config{Categories: redaction.Categories})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = s.Shutdown(context.Background())
+	})
+	details := testMeetingDetails()
+	details.Transcription[0].Content = "Mail alice@example.com"
+
+	res, err := s.GenerateMeetingSummary(tenantContext, details, models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+
+	// the value is dropped, the owner reads the placeholder
+	assert.Equal(t, []dbmodels.RedactedValue{{Placeholder: "[EMAIL_1]", Category: generated.Email}},
+		repo.Redactions[key("meeting-1")])
+	analytics, err := s.GetMeetingAnalytics(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", analytics.Speakers[0].MemberName)
+	assert.Equal(t, "Mail [EMAIL_1]", repo.Segments[key("meeting-1")][0].Content)
+}
+
+func TestGetMeetingSummary_RemovedRedactionKey(t *testing.T) {
+	repo := repotest.NewRepository()
+	meeting := dbmodels.Meeting{MeetingID: "meeting-1", EstateID: "e1", CreatedBy: "u1", Title: "Sync with [EMAIL_1]"}
+	repo.Meetings[key("meeting-1")] = meeting
+	removedKey := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("r", redaction.KeySize)))
+	removed, err := redaction.NewSealer(context.Background(), credentials.Static(removedKey), nil)
+	require.NoError(t, err)
+	sealed, keyID, err := removed.Seal(context.Background(), "alice@example.com", binding(&meeting, "[EMAIL_1]"))
+	require.NoError(t, err)
+	repo.Redactions[key("meeting-1")] = []dbmodels.RedactedValue{
+		{Placeholder: "[EMAIL_1]", Category: generated.Email, Sealed: sealed, KeyID: keyID}}
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{})
+
+	// the value sealed with a key which is no longer configured keeps its placeholder
+	res, err := s.GetMeetingSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Sync with [EMAIL_1]", res.Title)
+}
+
+func TestListActionItems_RedactsSourceSegment(t *testing.T) {
+	repo := repotest.NewRepository()
+	meeting := dbmodels.Meeting{MeetingID: "meeting-1", EstateID: "e1", CreatedBy: "u1"}
+	repo.Meetings[key("meeting-1")] = meeting
+	repo.Segments[key("meeting-1")] = []dbmodels.TranscriptSegment{{MemberName: "Bob", Content: "Key [SECRET_1]"}}
+	sealed, keyID, err := newTestSealer(t).Seal(context.Background(), "sk-abcdefghijklmnopqrstuvwxyz",
+		binding(&meeting, "[SECRET_1]"))
+	require.NoError(t, err)
+	repo.Redactions[key("meeting-1")] = []dbmodels.RedactedValue{
+		{Placeholder: "[SECRET_1]", Category: generated.Secret, Sealed: sealed, KeyID: keyID}}
+	repo.ActionItems[key("meeting-1")] = []dbmodels.MeetingActionItem{{
+		ItemID:      "i1",
+		MeetingID:   "meeting-1",
+		Description: "Rotate [SECRET_1]",
+		Owner:       utils.ToPointer("Bob"),
+		Segment:     &dbmodels.TranscriptSegment{MemberName: "Bob", Content: "Key [SECRET_1]"},
+	}}
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{})
+
+	owner, err := s.ListActionItems(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	// the speaker bob does not see the values either
+	reader, err := s.UpdateActionItem(tenancy.NewContext(context.Background(),
+		tenancy.Tenant{EstateID: "e1", InitiatorID: "bob"}), "meeting-1", "i1", &generated.UpdateActionItemRequest{Done: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Rotate sk-abcdefghijklmnopqrstuvwxyz", owner[0].Description)
+	assert.Equal(t, "Key sk-abcdefghijklmnopqrstuvwxyz", owner[0].SourceSegment.Content)
+	assert.Equal(t, "Rotate [SECRET_1]", reader.Description)
+	assert.Equal(t, "Key [SECRET_1]", reader.SourceSegment.Content)
+}
+
+func TestNewSvc_RedactsStoredMeetings(t *testing.T) {
+	repo := repotest.NewRepository()
+	// the meeting and its transcript were stored before they were redacted, the value of [EMAIL_1] was dropped from
+	// its summary
+	repo.Meetings[key("m1")] = dbmodels.Meeting{MeetingID: "m1", EstateID: "e1", CreatedBy: "u1",
+		Title: "Call +1 555 123 4567"}
+	repo.Segments[key("m1")] = []dbmodels.TranscriptSegment{{MeetingID: "m1", MemberName: "bob@example.com",
+		Content: "Mail alice@example.com"}}
+	repo.Redactions[key("m1")] = []dbmodels.RedactedValue{{Placeholder: "[EMAIL_1]", Category: generated.Email}}
+	repo.Unredacted[key("m1")] = true
+	repo.Meetings[key("m2")] = dbmodels.Meeting{MeetingID: "m2", EstateID: "e1", Title: "Redacted alice@example.com"}
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{})
+	<-s.(*svc).redactionDone
+
+	assert.Empty(t, repo.Unredacted)
+	assert.Equal(t, "Call [PHONE_1]", repo.Meetings[key("m1")].Title)
+	assert.Equal(t, []dbmodels.TranscriptSegment{{MeetingID: "m1", MemberName: "[EMAIL_2]", Content: "Mail [EMAIL_3]"}},
+		repo.Segments[key("m1")])
+	assert.Equal(t, "Redacted alice@example.com", repo.Meetings[key("m2")].Title, "the redacted meetings are kept")
+	res, err := s.GetMeetingSummary(tenantContext, "m1")
+	require.NoError(t, err)
+	assert.Equal(t, "Call +1 555 123 4567", res.Title)
+	assert.Equal(t, []string{"bob@example.com"}, res.Participants)
+}
+
+// failingSegmentsRepository fails to read the transcript of the meeting failing
+type failingSegmentsRepository struct {
+	*repotest.Repository
+	failing string
+}
+
+func (r *failingSegmentsRepository) GetTranscriptSegments(ctx context.Context,
+	meetingID string) ([]dbmodels.TranscriptSegment, error) {
+	if meetingID == r.failing {
+		return nil, errors.New("boom")
+	}
+	return r.Repository.GetTranscriptSegments(ctx, meetingID)
+}
+
+func TestNewSvc_RedactStoredMeetingsFailure(t *testing.T) {
+	repo := repotest.NewRepository()
+	for _, meetingID := range []string{"m1", "m2", "m3"} {
+		repo.Meetings[key(meetingID)] = dbmodels.Meeting{MeetingID: meetingID, EstateID: "e1",
+			Title: "Mail alice@example.com"}
+		repo.Unredacted[key(meetingID)] = true
+	}
+	s, err := NewSvc(context.Background(), &failingSegmentsRepository{Repository: repo, failing: "m2"},
+		&fakeProvider{}, jobs.Config{}, redaction.Config{Categories: redaction.Categories, Sealer: newTestSealer(t)})
+	require.NoError(t, err, "the failures do not fail the startup")
+	t.Cleanup(func() {
+		_ = s.Shutdown(context.Background())
+	})
+	<-s.(*svc).redactionDone
+
+	// the meeting which failed is left for the next start, the next ones are redacted
+	assert.Equal(t, map[repotest.Key]bool{key("m2"): true}, repo.Unredacted)
+	assert.Equal(t, "Mail [EMAIL_1]", repo.Meetings[key("m1")].Title)
+	assert.Equal(t, "Mail alice@example.com", repo.Meetings[key("m2")].Title)
+	assert.Equal(t, "Mail [EMAIL_1]", repo.Meetings[key("m3")].Title)
+}
+
+func TestGetMeetingAnalytics(t *testing.T) {
+	repo := repotest.NewRepository()
+	meeting, segments := models.ToDBMeeting(testMeetingDetails(), time.Now())
+	meeting.EstateID = "e1"
+	repo.Meetings[key(meeting.MeetingID)] = *meeting
+	repo.Segments[key(meeting.MeetingID)] = segments
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{})
+
+	res, err := s.GetMeetingAnalytics(tenantContext, "meeting-1")
+
+	require.NoError(t, err)
+	assert.True(t, res.Timed)
+	// "Hello" lasts 400ms at 150 words per minute, Bob answers 4.6s later
+	assert.InDelta(t, 5.4, res.DurationSeconds, 1e-9)
+	assert.InDelta(t, 0.8, res.TalkTimeSeconds, 1e-9)
+	assert.InDelta(t, 4.6, res.SilenceSeconds, 1e-9)
+	require.Len(t, res.Speakers, 2)
+	assert.Equal(t, generated.SpeakerAnalytics{MemberName: "Alice", TalkTimeSeconds: 0.4, TalkShare: 0.5, WordCount: 1,
+		TurnCount: 1}, res.Speakers[0])
+	assert.Empty(t, res.Interruptions)
+	require.Len(t, res.SilenceGaps, 1)
+	assert.Equal(t, 0, res.SilenceGaps[0].AfterSeq)
+
+	_, err = s.GetMeetingAnalytics(tenantContext, "unknown")
+	assert.ErrorIs(t, err, errorresponse.ErrMeetingIDNotFound)
+}
+
+func TestDeleteMeeting(t *testing.T) {
+	repo := repotest.NewRepository()
+	repo.Meetings[key("meeting-1")] = dbmodels.Meeting{MeetingID: "meeting-1", EstateID: "e1"}
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{})
+
+	require.NoError(t, s.DeleteMeeting(tenantContext, "meeting-1"))
+
+	assert.Empty(t, repo.Meetings)
+	assert.ErrorIs(t, s.DeleteMeeting(tenantContext, "meeting-1"), errorresponse.ErrMeetingIDNotFound)
+}
+
+func TestShareMeeting(t *testing.T) {
+	repo := repotest.NewRepository()
+	repo.Meetings[key("meeting-1")] = dbmodels.Meeting{MeetingID: "meeting-1", EstateID: "e1", CreatedBy: "u1"}
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{})
+
+	share, err := s.ShareMeeting(tenantContext, "meeting-1",
+		&generated.ShareMeetingRequest{PrincipalType: generated.Group, PrincipalId: " g1 "})
+	require.NoError(t, err)
+	assert.Equal(t, "g1", share.PrincipalId)
+	assert.Equal(t, "u1", share.CreatedBy)
+
+	ctx := tenancy.NewContext(context.Background(), tenancy.Tenant{EstateID: "e1", InitiatorID: "u2"})
+	again, err := s.ShareMeeting(ctx, "meeting-1",
+		&generated.ShareMeetingRequest{PrincipalType: generated.Group, PrincipalId: "g1"})
+	require.NoError(t, err)
+	assert.Equal(t, share, again)
+
+	shares, err := s.ListMeetingShares(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Equal(t, []generated.MeetingShare{*share}, shares)
+
+	require.NoError(t, s.UnshareMeeting(tenantContext, "meeting-1", generated.Group, "g1"))
+	assert.ErrorIs(t, s.UnshareMeeting(tenantContext, "meeting-1", generated.Group, "g1"), errorresponse.ErrShareNotFound)
+	shares, err = s.ListMeetingShares(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	assert.Empty(t, shares)
+
+	_, err = s.ListMeetingShares(tenantContext, "unknown")
+	assert.ErrorIs(t, err, errorresponse.ErrMeetingIDNotFound)
+}
+
+func TestShareMeeting_InvalidRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *generated.ShareMeetingRequest
+	}{
+		{name: "EmptyPrincipal", request: &generated.ShareMeetingRequest{PrincipalType: generated.User, PrincipalId: " "}},
+		{name: "UnknownPrincipalType", request: &generated.ShareMeetingRequest{PrincipalType: "role", PrincipalId: "admin"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repotest.NewRepository()
+			repo.Meetings[key("meeting-1")] = dbmodels.Meeting{MeetingID: "meeting-1", EstateID: "e1"}
+			s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{})
+
+			_, err := s.ShareMeeting(tenantContext, "meeting-1", tt.request)
+
+			assert.ErrorIs(t, err, errorresponse.ErrInvalidRequest)
+			assert.Empty(t, repo.Shares)
+		})
+	}
+}
+
+func TestListMeetingSummaries(t *testing.T) {
+	repo := repotest.NewRepository()
+	for _, id := range []string{"m1", "m2", "m3"} {
+		repo.Meetings[key(id)] = dbmodels.Meeting{MeetingID: id, EstateID: "e1", CreatedBy: "u1", Title: "Meeting " + id}
+	}
+	repo.Segments[key("m2")] = []dbmodels.TranscriptSegment{{MemberName: "Alice"}, {MemberName: "Alice"}}
+	repo.Summaries[key("m2")] = dbmodels.Summary{MeetingID: "m2", EstateID: "e1", Content: "summary"}
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{})
+
+	summaries, total, err := s.ListMeetingSummaries(tenantContext, generated.GetMeetingSummariesParams{
+		Offset: utils.ToPointer(1),
+		Limit:  utils.ToPointer(1),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "m2", summaries[0].MeetingId)
+	assert.Equal(t, []string{"Alice"}, summaries[0].Participants)
+	assert.Equal(t, generated.DONE, summaries[0].Status)
+	assert.Equal(t, "summary", *summaries[0].Abstract)
+}
+
+func TestListMeetingSummaries_InvalidParams(t *testing.T) {
+	s := newTestSvc(t, repotest.NewRepository(), &fakeProvider{}, jobs.Config{})
+
+	_, _, err := s.ListMeetingSummaries(tenantContext, generated.GetMeetingSummariesParams{
+		Title: &[]string{"contains.sync"},
+	})
+
+	assert.ErrorIs(t, err, errorresponse.ErrInvalidFilterOperator)
+}
+
+func TestImportMeetingTranscript(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{validSummary}}, jobs.Config{Workers: 1, QueueSize: 1})
+	content := "WEBVTT\n\n00:00:01.000 --> 00:00:03.000\n<v Alice>Hello</v>\n\n" +
+		"00:00:04.000 --> 00:00:05.000\n<v Alice>everyone</v>\n\n00:00:06.500 --> 00:00:08.000\n<v Bob>Hi</v>\n"
+
+	res, err := s.ImportMeetingTranscript(tenantContext, &models.TranscriptUpload{
+		MeetingID:    "meeting-1",
+		MeetingTitle: "Weekly sync",
+		StartedAt:    time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		Content:      []byte(content),
+	}, models.GenerateOptions{})
+
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+	segments := repo.Segments[key("meeting-1")]
+	require.Len(t, segments, 2)
+	assert.Equal(t, "Alice", segments[0].MemberName)
+	assert.Equal(t, "Hello everyone", segments[0].Content)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 6, 500000000, time.UTC), *segments[1].Timestamp)
+}
+
+func TestImportMeetingTranscript_IdempotencyKey(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{validSummary}}, jobs.Config{Workers: 1, QueueSize: 1})
+	upload := func() *models.TranscriptUpload {
+		return &models.TranscriptUpload{MeetingID: "meeting-1", MeetingTitle: "Weekly sync",
+			Content: []byte("WEBVTT\n\n00:00:01.000 --> 00:00:03.000\n<v Alice>Hello</v>\n")}
+	}
+	options := models.GenerateOptions{IdempotencyKey: "upload-1"}
+	first, err := s.ImportMeetingTranscript(tenantContext, upload(), options)
+	require.NoError(t, err)
+
+	// the retried upload has no start time either, which is set to the time of each request
+	replayed, err := s.ImportMeetingTranscript(tenantContext, upload(), options)
+
+	require.NoError(t, err)
+	assert.Equal(t, first, replayed)
+	assert.Equal(t, 1, repo.JobCount())
+}
+
+func TestImportMeetingTranscript_InvalidFile(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{}, jobs.Config{Workers: 1, QueueSize: 1})
+
+	_, err := s.ImportMeetingTranscript(tenantContext, &models.TranscriptUpload{
+		MeetingID:    "meeting-1",
+		MeetingTitle: "Weekly sync",
+		Content:      []byte("not a transcript"),
+	}, models.GenerateOptions{})
+
+	assert.ErrorIs(t, err, errorresponse.ErrUnsupportedTranscriptFormat)
+	assert.Empty(t, repo.Meetings)
+}
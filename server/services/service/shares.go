@@ -0,0 +1,91 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/models/dbmodels"
+	"meeting-analyzer/server/models/errorresponse"
+	"strings"
+	"time"
+)
+
+func (s *svc) ListMeetingShares(ctx context.Context, meetingID string) ([]generated.MeetingShare, error) {
+	if _, err := s.repo.GetMeeting(ctx, meetingID); err != nil {
+		return nil, err
+	}
+	shares, err := s.repo.ListShares(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]generated.MeetingShare, 0, len(shares))
+	for i := range shares {
+		res = append(res, *toMeetingShare(&shares[i]))
+	}
+	return res, nil
+}
+
+func (s *svc) ShareMeeting(ctx context.Context, meetingID string, request *generated.ShareMeetingRequest) (*generated.MeetingShare, error) {
+	tenant, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return nil, errorresponse.ErrUnauthenticated
+	}
+	principalID := strings.TrimSpace(request.PrincipalId)
+	if principalID == "" {
+		return nil, fmt.Errorf("%w: principal_id must not be empty", errorresponse.ErrInvalidRequest)
+	}
+	if request.PrincipalType != generated.User && request.PrincipalType != generated.Group {
+		return nil, fmt.Errorf("%w: unknown principal_type %s", errorresponse.ErrInvalidRequest, request.PrincipalType)
+	}
+	share := &dbmodels.MeetingShare{
+		MeetingID:     meetingID,
+		PrincipalType: request.PrincipalType,
+		PrincipalID:   principalID,
+		CreatedBy:     tenant.InitiatorID,
+		CreatedAt:     time.Now().UTC(),
+	}
+	if err := s.repo.SaveShare(ctx, share); err != nil {
+		return nil, err
+	}
+	return toMeetingShare(share), nil
+}
+
+func (s *svc) UnshareMeeting(ctx context.Context, meetingID string, principalType generated.PrincipalTypeEnum, principalID string) error {
+	return s.repo.DeleteShare(ctx, meetingID, principalType, principalID)
+}
+
+// participantShares shares the meeting with the initiators of its speakers, other than the initiator storing it
+func participantShares(meetingDetails *models.MeetingDetails, tenant tenancy.Tenant, now time.Time) []dbmodels.MeetingShare {
+	shares := make([]dbmodels.MeetingShare, 0)
+	seen := map[string]bool{tenant.InitiatorID: true}
+	for _, t := range meetingDetails.Transcription {
+		memberID := strings.TrimSpace(t.MemberID)
+		if memberID == "" || seen[memberID] {
+			continue
+		}
+		seen[memberID] = true
+		shares = append(shares, dbmodels.MeetingShare{
+			MeetingID:     meetingDetails.MeetingID,
+			PrincipalType: generated.User,
+			PrincipalID:   memberID,
+			CreatedBy:     tenant.InitiatorID,
+			CreatedAt:     now,
+		})
+	}
+	return shares
+}
+
+// toMeetingShare builds the api representation of a stored share
+func toMeetingShare(share *dbmodels.MeetingShare) *generated.MeetingShare {
+	return &generated.MeetingShare{
+		MeetingId:     share.MeetingID,
+		PrincipalType: share.PrincipalType,
+		PrincipalId:   share.PrincipalID,
+		CreatedBy:     share.CreatedBy,
+		CreatedAt:     share.CreatedAt,
+	}
+}
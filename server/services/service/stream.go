@@ -0,0 +1,90 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package service
+
+import (
+	"context"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/services/progress"
+
+	log "eos2git.cec.lab.emc.com/ISG-Edge/hzp-go-commons/logger"
+)
+
+func (s *svc) StreamMeetingSummary(ctx context.Context, meetingID string) (<-chan models.SummaryEvent, error) {
+	// subscribing before reading the summary misses no event, at worst the first ones are already in the summary
+	updates, unsubscribe := s.broker.Subscribe(topic(ctx, meetingID))
+	current, err := s.GetMeetingSummary(ctx, meetingID)
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	events := make(chan models.SummaryEvent)
+	go func() {
+		defer close(events)
+		defer unsubscribe()
+		if !send(ctx, events, models.SummaryEvent{Name: models.SummaryEventSummary, Data: current}) ||
+			finished(current.Status) {
+			return
+		}
+		for {
+			var update progress.Event
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok = <-updates:
+			}
+			if !ok {
+				// the subscription was closed, the client reads the summary again when it reconnects
+				return
+			}
+			switch update.Type {
+			case progress.EventStatus:
+				current, err = s.GetMeetingSummary(ctx, meetingID)
+				if err != nil {
+					log.Error(ctx, nil, "", err, "failed to read the summary of meeting %s", meetingID)
+					return
+				}
+				if !send(ctx, events, models.SummaryEvent{Name: models.SummaryEventSummary, Data: current}) ||
+					finished(current.Status) {
+					return
+				}
+			case progress.EventChunk:
+				if !send(ctx, events, models.SummaryEvent{Name: models.SummaryEventProgress, Data: generated.SummaryProgress{
+					SummarizedChunks: update.SummarizedChunks,
+					TotalChunks:      update.TotalChunks,
+				}}) {
+					return
+				}
+			case progress.EventDelta:
+				if !send(ctx, events, models.SummaryEvent{Name: models.SummaryEventDelta, Data: generated.SummaryDelta{
+					Attempt: update.Attempt,
+					Content: update.Content,
+				}}) {
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// send returns false when ctx is done before the event is received
+func send(ctx context.Context, events chan<- models.SummaryEvent, event models.SummaryEvent) bool {
+	// the select would pick at random when the client reads while it is gone
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func finished(status generated.JobStatusEnum) bool {
+	return status == generated.DONE || status == generated.FAILED
+}
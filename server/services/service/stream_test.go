@@ -0,0 +1,180 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package service
+
+import (
+	"context"
+	"meeting-analyzer/server/api/rest/generated"
+	"meeting-analyzer/server/commons/tenancy"
+	"meeting-analyzer/server/models"
+	"meeting-analyzer/server/models/errorresponse"
+	"meeting-analyzer/server/repositories/repotest"
+	"meeting-analyzer/server/services/jobs"
+	"meeting-analyzer/server/services/llm"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collect reads the events until the channel is closed
+func collect(events <-chan models.SummaryEvent) []models.SummaryEvent {
+	collected := make([]models.SummaryEvent, 0)
+	for event := range events {
+		collected = append(collected, event)
+	}
+	return collected
+}
+
+func TestStreamMeetingSummary(t *testing.T) {
+	provider := &fakeProvider{contents: []string{"The meeting was short.", validSummary}, block: make(chan struct{})}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.INPROGRESS)
+
+	events, err := s.StreamMeetingSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	close(provider.block)
+	collected := collect(events)
+
+	require.Len(t, collected, 4)
+	assert.Equal(t, models.SummaryEventSummary, collected[0].Name)
+	assert.Equal(t, generated.INPROGRESS, collected[0].Data.(*generated.MeetingSummary).Status)
+	assert.Equal(t, models.SummaryEvent{Name: models.SummaryEventDelta,
+		Data: generated.SummaryDelta{Attempt: 1, Content: "The meeting was short."}}, collected[1])
+	assert.Equal(t, models.SummaryEvent{Name: models.SummaryEventDelta,
+		Data: generated.SummaryDelta{Attempt: 2, Content: validSummary}}, collected[2])
+	assert.Equal(t, models.SummaryEventSummary, collected[3].Name)
+	final := collected[3].Data.(*generated.MeetingSummary)
+	assert.Equal(t, generated.DONE, final.Status)
+	assert.Equal(t, "summary", *final.Abstract)
+}
+
+// estateBlockingProvider blocks the completions requested on behalf of the estate until block is closed
+type estateBlockingProvider struct {
+	*fakeProvider
+	estateID string
+	block    chan struct{}
+}
+
+func (p *estateBlockingProvider) Complete(ctx context.Context, request llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	if tenant, _ := tenancy.FromContext(ctx); tenant.EstateID == p.estateID {
+		<-p.block
+	}
+	return p.fakeProvider.Complete(ctx, request)
+}
+
+func TestStreamMeetingSummary_OtherEstate(t *testing.T) {
+	provider := &estateBlockingProvider{fakeProvider: &fakeProvider{contents: []string{validSummary}}, estateID: "e1",
+		block: make(chan struct{})}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 2, QueueSize: 2})
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.INPROGRESS)
+
+	events, err := s.StreamMeetingSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	// the estate e2 summarizes its own meeting-1 while e1 streams
+	otherContext := tenancy.NewContext(context.Background(), tenancy.Tenant{EstateID: "e2", InitiatorID: "u2"})
+	other, err := s.GenerateMeetingSummary(otherContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, other.JobId, generated.DONE)
+	close(provider.block)
+	collected := collect(events)
+
+	// only the delta and status of the job of e1 are streamed
+	require.Len(t, collected, 3)
+	assert.Equal(t, generated.INPROGRESS, collected[0].Data.(*generated.MeetingSummary).Status)
+	assert.Equal(t, models.SummaryEvent{Name: models.SummaryEventDelta,
+		Data: generated.SummaryDelta{Attempt: 1, Content: validSummary}}, collected[1])
+	assert.Equal(t, generated.DONE, collected[2].Data.(*generated.MeetingSummary).Status)
+}
+
+func TestStreamMeetingSummary_StreamingProvider(t *testing.T) {
+	provider := &fakeStreamingProvider{&fakeProvider{contents: []string{validSummary}, block: make(chan struct{})}}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.INPROGRESS)
+
+	events, err := s.StreamMeetingSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	close(provider.block)
+	collected := collect(events)
+
+	var streamed strings.Builder
+	for _, event := range collected[1 : len(collected)-1] {
+		require.Equal(t, models.SummaryEventDelta, event.Name)
+		streamed.WriteString(event.Data.(generated.SummaryDelta).Content)
+	}
+	// the deltas of 16 bytes streamed at once are sent together
+	assert.Less(t, len(collected)-2, (len(validSummary)+15)/16)
+	assert.Equal(t, validSummary, streamed.String())
+	final := collected[len(collected)-1].Data.(*generated.MeetingSummary)
+	assert.Equal(t, generated.DONE, final.Status)
+	assert.Equal(t, "fake-model", *final.Model)
+}
+
+func TestStreamMeetingSummary_Failed(t *testing.T) {
+	provider := &fakeProvider{err: &llm.StatusError{StatusCode: 502}, block: make(chan struct{})}
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.INPROGRESS)
+
+	events, err := s.StreamMeetingSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+	close(provider.block)
+	collected := collect(events)
+
+	require.Len(t, collected, 2)
+	final := collected[1].Data.(*generated.MeetingSummary)
+	assert.Equal(t, generated.FAILED, final.Status)
+	assert.Contains(t, *final.Error, "status 502")
+}
+
+func TestStreamMeetingSummary_Done(t *testing.T) {
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, &fakeProvider{contents: []string{validSummary}}, jobs.Config{Workers: 1, QueueSize: 1})
+	res, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+	waitForJobStatus(t, repo, res.JobId, generated.DONE)
+
+	events, err := s.StreamMeetingSummary(tenantContext, "meeting-1")
+	require.NoError(t, err)
+
+	collected := collect(events)
+	require.Len(t, collected, 1)
+	assert.Equal(t, generated.DONE, collected[0].Data.(*generated.MeetingSummary).Status)
+}
+
+func TestStreamMeetingSummary_Canceled(t *testing.T) {
+	provider := &fakeProvider{contents: []string{validSummary}, block: make(chan struct{})}
+	defer close(provider.block)
+	repo := repotest.NewRepository()
+	s := newTestSvc(t, repo, provider, jobs.Config{Workers: 1, QueueSize: 1})
+	_, err := s.GenerateMeetingSummary(tenantContext, testMeetingDetails(), models.GenerateOptions{})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(tenantContext)
+
+	events, err := s.StreamMeetingSummary(ctx, "meeting-1")
+	require.NoError(t, err)
+	<-events
+	cancel()
+
+	assert.Empty(t, collect(events))
+}
+
+func TestStreamMeetingSummary_UnknownMeeting(t *testing.T) {
+	s := newTestSvc(t, repotest.NewRepository(), &fakeProvider{}, jobs.Config{})
+
+	_, err := s.StreamMeetingSummary(tenantContext, "unknown")
+
+	assert.ErrorIs(t, err, errorresponse.ErrMeetingIDNotFound)
+}
@@ -0,0 +1,186 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package summary defines the structured summary requested from the model and parses its answers
+package summary
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"meeting-analyzer/server/services/llm"
+	"strings"
+	"time"
+)
+
+// DueDateLayout is the format of the action items due dates
+const DueDateLayout = time.DateOnly
+
+// ErrInvalidSummary is returned when the answer of the model cannot be turned into a Summary
+var ErrInvalidSummary = errors.New("llm returned an invalid structured summary")
+
+// Summary is the structured answer requested from the model
+type Summary struct {
+	Summary     string       `json:"summary"`
+	KeyPoints   []string     `json:"key_points"`
+	Decisions   []string     `json:"decisions"`
+	ActionItems []ActionItem `json:"action_items"`
+}
+
+// ActionItem is a task somebody committed to during the meeting. DuePhrase keeps the deadline as it was said,
+// e.g. "by Friday", and Quote the words of the commitment, so that both can be resolved against the transcript.
+type ActionItem struct {
+	Description string  `json:"description"`
+	Owner       *string `json:"owner"`
+	DueDate     *string `json:"due_date"`
+	DuePhrase   *string `json:"due_phrase"`
+	Quote       *string `json:"quote"`
+}
+
+// schema follows the strict structured output rules: every property is required and nullable ones use a null type
+const schema = `{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["summary", "key_points", "decisions", "action_items"],
+  "properties": {
+    "summary": {"type": "string", "description": "A short prose summary of the meeting"},
+    "key_points": {"type": "array", "items": {"type": "string"}},
+    "decisions": {"type": "array", "items": {"type": "string"}},
+    "action_items": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["description", "owner", "due_date", "due_phrase", "quote"],
+        "properties": {
+          "description": {"type": "string"},
+          "owner": {"type": ["string", "null"], "description": "Name of the participant who owns the task"},
+          "due_date": {"type": ["string", "null"], "description": "Due date formatted as YYYY-MM-DD"},
+          "due_phrase": {"type": ["string", "null"], "description": "The deadline as said in the meeting, e.g. by Friday"},
+          "quote": {"type": ["string", "null"], "description": "The words of the transcription where the task was agreed"}
+        }
+      }
+    }
+  }
+}`
+
+// ResponseFormat returns the response format constraining the model to answer with a Summary
+func ResponseFormat() *llm.ResponseFormat {
+	return &llm.ResponseFormat{
+		Type: llm.ResponseFormatJSONSchema,
+		JSONSchema: &llm.JSONSchema{
+			Name:   "meeting_summary",
+			Schema: json.RawMessage(schema),
+			Strict: true,
+		},
+	}
+}
+
+// Instructions describes the expected answer, for the models ignoring the response format
+func Instructions() string {
+	return "Answer only with a JSON object, without markdown, matching this JSON schema:\n" + schema
+}
+
+// Parse repairs the common defects of model answers, then decodes and validates the summary
+func Parse(content string) (*Summary, error) {
+	var summary Summary
+	decoder := json.NewDecoder(strings.NewReader(repair(content)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&summary); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSummary, err)
+	}
+	if err := summary.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSummary, err)
+	}
+	return &summary, nil
+}
+
+// validate checks the mandatory fields and normalizes the empty ones
+func (s *Summary) validate() error {
+	s.Summary = strings.TrimSpace(s.Summary)
+	if s.Summary == "" {
+		return errors.New("summary must not be empty")
+	}
+	s.KeyPoints = nonEmpty(s.KeyPoints)
+	s.Decisions = nonEmpty(s.Decisions)
+	if s.ActionItems == nil {
+		s.ActionItems = []ActionItem{}
+	}
+	for i := range s.ActionItems {
+		item := &s.ActionItems[i]
+		item.Description = strings.TrimSpace(item.Description)
+		if item.Description == "" {
+			return fmt.Errorf("action item %d has no description", i)
+		}
+		item.Owner = trimToNil(item.Owner)
+		item.DueDate = trimToNil(item.DueDate)
+		item.DuePhrase = trimToNil(item.DuePhrase)
+		item.Quote = trimToNil(item.Quote)
+		if item.DueDate != nil {
+			if _, err := time.Parse(DueDateLayout, *item.DueDate); err != nil {
+				return fmt.Errorf("action item %d has an invalid due date %q", i, *item.DueDate)
+			}
+		}
+	}
+	return nil
+}
+
+// repair strips markdown fences and surrounding prose, and removes trailing commas
+func repair(content string) string {
+	content = strings.TrimSpace(content)
+	if start := strings.Index(content, "{"); start >= 0 {
+		if end := strings.LastIndex(content, "}"); end > start {
+			content = content[start : end+1]
+		}
+	}
+	return removeTrailingCommas(content)
+}
+
+// removeTrailingCommas drops the commas directly followed by a closing bracket, ignoring string literals
+func removeTrailingCommas(content string) string {
+	var b strings.Builder
+	inString, escaped := false, false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == ',':
+			next := strings.TrimLeft(content[i+1:], " \t\r\n")
+			if next != "" && (next[0] == '}' || next[0] == ']') {
+				continue
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func nonEmpty(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func trimToNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,89 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package summary
+
+import (
+	"encoding/json"
+	"meeting-analyzer/server/commons/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	expected := &Summary{
+		Summary:     "Release planning",
+		KeyPoints:   []string{"Release slips a week"},
+		Decisions:   []string{},
+		ActionItems: []ActionItem{{Description: "Update the roadmap", Owner: utils.ToPointer("Alice"), DueDate: utils.ToPointer("2024-01-05")}},
+	}
+	valid := `{"summary":"Release planning","key_points":["Release slips a week"],"decisions":[],` +
+		`"action_items":[{"description":"Update the roadmap","owner":"Alice","due_date":"2024-01-05"}]}`
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "Valid", content: valid},
+		{name: "MarkdownFence", content: "```json\n" + valid + "\n```"},
+		{name: "SurroundingProse", content: "Here is the summary:\n" + valid + "\nLet me know if you need more."},
+		{
+			name: "TrailingCommas",
+			content: `{"summary":" Release planning ","key_points":["Release slips a week", ""],"decisions":[],` +
+				`"action_items":[{"description":"Update the roadmap","owner":"Alice","due_date":"2024-01-05",},],}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := Parse(tt.content)
+
+			require.NoError(t, err)
+			assert.Equal(t, expected, parsed)
+		})
+	}
+}
+
+func TestParse_NormalizesEmptyFields(t *testing.T) {
+	parsed, err := Parse(`{"summary":"Sync","action_items":[{"description":"Ship, then rest","owner":" ","due_date":null,` +
+		`"due_phrase":"","quote":" I will ship it "}]}`)
+
+	require.NoError(t, err)
+	assert.Equal(t, &Summary{
+		Summary:     "Sync",
+		KeyPoints:   []string{},
+		Decisions:   []string{},
+		ActionItems: []ActionItem{{Description: "Ship, then rest", Quote: utils.ToPointer("I will ship it")}},
+	}, parsed)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "NotJSON", content: "The meeting was about the release."},
+		{name: "Truncated", content: `{"summary":"Release planning","key_points":["Rel`},
+		{name: "EmptySummary", content: `{"summary":"  ","key_points":[]}`},
+		{name: "UnknownField", content: `{"summary":"Sync","topics":[]}`},
+		{name: "WrongType", content: `{"summary":"Sync","key_points":"none"}`},
+		{name: "ActionItemWithoutDescription", content: `{"summary":"Sync","action_items":[{"owner":"Bob"}]}`},
+		{name: "InvalidDueDate", content: `{"summary":"Sync","action_items":[{"description":"Ship","due_date":"Friday"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.content)
+
+			assert.ErrorIs(t, err, ErrInvalidSummary)
+		})
+	}
+}
+
+func TestResponseFormat_ValidSchema(t *testing.T) {
+	var decoded map[string]any
+
+	require.NoError(t, json.Unmarshal(ResponseFormat().JSONSchema.Schema, &decoded))
+	assert.Equal(t, "object", decoded["type"])
+}
@@ -0,0 +1,218 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+// Package transcript parses the WebVTT and SubRip transcripts exported by the meeting tools
+package transcript
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"meeting-analyzer/server/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Format of a transcript file
+type Format string
+
+// supported formats
+const (
+	FormatVTT Format = "vtt"
+	FormatSRT Format = "srt"
+)
+
+// UnknownSpeaker is the member of the cues without speaker
+const UnknownSpeaker = "Unknown"
+
+// maxSpeakerPrefixLength bounds the "Speaker: " prefixes, so that sentences containing a colon are kept as content
+const maxSpeakerPrefixLength = 64
+
+// MaxSpeakerLength is the maximum length of the speaker names, in characters, the maxLength of member_name in the spec
+const MaxSpeakerLength = 256
+
+// ErrInvalidTranscript is returned when the file is neither a valid WebVTT nor a valid SubRip transcript
+var ErrInvalidTranscript = errors.New("invalid transcript file")
+
+// byteOrderMark starts the UTF-8 files written by some Windows tools
+const byteOrderMark = "\uFEFF"
+
+var (
+	// timingPattern matches the cue timings of both formats: [hh:]mm:ss.ttt --> [hh:]mm:ss.ttt, with a comma in SubRip
+	timingPattern  = regexp.MustCompile(`^((?:\d+:)?\d{2}:\d{2}[.,]\d{3})\s+-->\s+((?:\d+:)?\d{2}:\d{2}[.,]\d{3})`)
+	voicePattern   = regexp.MustCompile(`<v(?:\.[^\s>]+)*\s+([^>]+)>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	speakerPattern = regexp.MustCompile(`^([^:.!?]+):\s+(.+)$`)
+)
+
+// Cue is a timed caption of the transcript
+type Cue struct {
+	Start   time.Duration
+	Speaker string
+	Text    string
+}
+
+// DetectFormat returns the format of the transcript from its content
+func DetectFormat(content []byte) Format {
+	content = bytes.TrimPrefix(content, []byte(byteOrderMark))
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("WEBVTT")) {
+		return FormatVTT
+	}
+	return FormatSRT
+}
+
+// Parse reads the cues of the transcript, converts them to transcriptions timed from startedAt
+// and merges the consecutive cues of the same speaker
+func Parse(content []byte, startedAt time.Time) ([]models.Transcription, error) {
+	cues, err := ParseCues(content)
+	if err != nil {
+		return nil, err
+	}
+	return Merge(cues, startedAt), nil
+}
+
+// ParseCues reads the cues of a WebVTT or SubRip transcript
+func ParseCues(content []byte) ([]Cue, error) {
+	format := DetectFormat(content)
+	cues := make([]Cue, 0)
+	for i, block := range splitBlocks(content) {
+		if format == FormatVTT && i == 0 {
+			continue
+		}
+		cue, err := parseBlock(block, format)
+		if err != nil {
+			return nil, err
+		}
+		if cue != nil {
+			cues = append(cues, *cue)
+		}
+	}
+	if len(cues) == 0 {
+		return nil, fmt.Errorf("%w: no cue found", ErrInvalidTranscript)
+	}
+	return cues, nil
+}
+
+// Merge joins the text of the consecutive cues of the same speaker
+func Merge(cues []Cue, startedAt time.Time) []models.Transcription {
+	transcription := make([]models.Transcription, 0, len(cues))
+	for _, cue := range cues {
+		last := len(transcription) - 1
+		if last >= 0 && transcription[last].MemberName == cue.Speaker {
+			transcription[last].Content += " " + cue.Text
+			continue
+		}
+		timestamp := startedAt.Add(cue.Start).UTC()
+		transcription = append(transcription, models.Transcription{
+			MemberName: cue.Speaker,
+			Timestamp:  &timestamp,
+			Content:    cue.Text,
+		})
+	}
+	return transcription
+}
+
+// splitBlocks splits the content on blank lines
+func splitBlocks(content []byte) [][]string {
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(content, []byte(byteOrderMark))))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	blocks := make([][]string, 0)
+	var block []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// parseBlock parses a cue, the WebVTT NOTE, STYLE and REGION blocks are skipped and return a nil cue
+func parseBlock(block []string, format Format) (*Cue, error) {
+	if format == FormatVTT {
+		for _, prefix := range []string{"NOTE", "STYLE", "REGION"} {
+			if block[0] == prefix || strings.HasPrefix(block[0], prefix+" ") {
+				return nil, nil
+			}
+		}
+	}
+
+	// the timing line is preceded by an optional identifier in WebVTT and by the cue number in SubRip
+	timingLine := 0
+	if !timingPattern.MatchString(block[0]) {
+		timingLine = 1
+	}
+	if timingLine >= len(block) || !timingPattern.MatchString(block[timingLine]) {
+		return nil, fmt.Errorf("%w: missing cue timing in %q", ErrInvalidTranscript, block[0])
+	}
+	start, err := parseTimestamp(timingPattern.FindStringSubmatch(block[timingLine])[1])
+	if err != nil {
+		return nil, err
+	}
+
+	speaker, text := parsePayload(block[timingLine+1:])
+	if text == "" {
+		return nil, nil
+	}
+	if utf8.RuneCountInString(speaker) > MaxSpeakerLength {
+		return nil, fmt.Errorf("%w: speaker name longer than %d characters", ErrInvalidTranscript, MaxSpeakerLength)
+	}
+	return &Cue{Start: start, Speaker: speaker, Text: text}, nil
+}
+
+// parsePayload extracts the speaker from the first voice tag, or from a "Speaker: " prefix, and strips the markup
+func parsePayload(lines []string) (string, string) {
+	payload := strings.Join(lines, " ")
+	speaker := ""
+	if match := voicePattern.FindStringSubmatch(payload); match != nil {
+		speaker = strings.TrimSpace(match[1])
+	}
+	text := strings.Join(strings.Fields(unescape(tagPattern.ReplaceAllString(payload, ""))), " ")
+	if speaker == "" {
+		if match := speakerPattern.FindStringSubmatch(text); match != nil && len(match[1]) <= maxSpeakerPrefixLength {
+			speaker, text = strings.TrimSpace(match[1]), match[2]
+		}
+	}
+	if speaker == "" {
+		speaker = UnknownSpeaker
+	}
+	return speaker, text
+}
+
+func unescape(text string) string {
+	return strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&nbsp;", " ", "&lrm;", "", "&rlm;", "").
+		Replace(text)
+}
+
+// parseTimestamp parses [hh:]mm:ss.ttt, or [hh:]mm:ss,ttt in SubRip
+func parseTimestamp(timestamp string) (time.Duration, error) {
+	parts := strings.Split(strings.Replace(timestamp, ",", ".", 1), ":")
+	if len(parts) == 2 {
+		parts = append([]string{"0"}, parts...)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid timestamp %s", ErrInvalidTranscript, timestamp)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes > 59 {
+		return 0, fmt.Errorf("%w: invalid timestamp %s", ErrInvalidTranscript, timestamp)
+	}
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil || seconds >= 60 {
+		return 0, fmt.Errorf("%w: invalid timestamp %s", ErrInvalidTranscript, timestamp)
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds*float64(time.Second)).Round(time.Millisecond), nil
+}
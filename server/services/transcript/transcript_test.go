@@ -0,0 +1,151 @@
+// Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+package transcript
+
+import (
+	"meeting-analyzer/server/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var startedAt = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+const teamsVTT = byteOrderMark + "WEBVTT\r\nKind: captions\r\n\r\n" +
+	"NOTE exported by Teams\r\n\r\n" +
+	"0f1c2d/12-0\r\n00:00:01.500 --> 00:00:04.000\r\n<v Alice Smith>Good morning everyone,</v>\r\n\r\n" +
+	"0f1c2d/12-1\r\n00:00:04.000 --> 00:00:06.000\r\n<v Alice Smith>let's start.</v>\r\n\r\n" +
+	"00:01:02.250 --> 00:01:05.000 align:start\r\n<v.loud Bob>Sounds <b>good</b> &amp; ready\r\nto go</v>\r\n"
+
+const zoomVTT = `WEBVTT
+
+1
+00:00:00.000 --> 00:00:02.000
+Alice: Hello
+
+2
+00:00:02.000 --> 00:00:03.000
+Bob: Hi Alice
+
+3
+00:00:03.000 --> 00:00:04.000
+Note that this line has no speaker.
+`
+
+const srt = `1
+00:00:01,000 --> 00:00:02,000
+<v Alice>First line
+
+2
+00:00:02,000 --> 00:00:03,500
+<v Alice>second line
+
+3
+01:00:00,000 --> 01:00:01,000
+<v Bob>Bye
+`
+
+// timestamp parses an RFC 3339 timestamp of the fixtures
+func timestamp(value string) *time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		panic(err)
+	}
+	return &parsed
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		format   Format
+		expected []models.Transcription
+	}{
+		{
+			name:    "TeamsVoiceTags",
+			content: teamsVTT,
+			format:  FormatVTT,
+			expected: []models.Transcription{
+				{MemberName: "Alice Smith", Timestamp: timestamp("2024-01-01T10:00:01.5Z"), Content: "Good morning everyone, let's start."},
+				{MemberName: "Bob", Timestamp: timestamp("2024-01-01T10:01:02.25Z"), Content: "Sounds good & ready to go"},
+			},
+		},
+		{
+			name:    "ZoomSpeakerPrefix",
+			content: zoomVTT,
+			format:  FormatVTT,
+			expected: []models.Transcription{
+				{MemberName: "Alice", Timestamp: timestamp("2024-01-01T10:00:00Z"), Content: "Hello"},
+				{MemberName: "Bob", Timestamp: timestamp("2024-01-01T10:00:02Z"), Content: "Hi Alice"},
+				{MemberName: UnknownSpeaker, Timestamp: timestamp("2024-01-01T10:00:03Z"), Content: "Note that this line has no speaker."},
+			},
+		},
+		{
+			name:    "SubRip",
+			content: srt,
+			format:  FormatSRT,
+			expected: []models.Transcription{
+				{MemberName: "Alice", Timestamp: timestamp("2024-01-01T10:00:01Z"), Content: "First line second line"},
+				{MemberName: "Bob", Timestamp: timestamp("2024-01-01T11:00:00Z"), Content: "Bye"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.format, DetectFormat([]byte(tt.content)))
+
+			transcription, err := Parse([]byte(tt.content), startedAt)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, transcription)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "Empty", content: ""},
+		{name: "HeaderOnly", content: "WEBVTT\n\nNOTE nothing to see\n"},
+		{name: "NotATranscript", content: "meeting_id,title\nm1,Weekly\n"},
+		{name: "MissingTiming", content: "1\nHello\n"},
+		{name: "InvalidTimestamp", content: "1\n00:75:00,000 --> 00:76:00,000\nHello\n"},
+		{name: "SpeakerTooLong", content: "WEBVTT\n\n00:00.000 --> 00:01.000\n<v " +
+			strings.Repeat("x", MaxSpeakerLength+1) + ">Hello\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.content), startedAt)
+
+			assert.ErrorIs(t, err, ErrInvalidTranscript)
+		})
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		timestamp string
+		expected  time.Duration
+	}{
+		{timestamp: "00:00:01.500", expected: 1500 * time.Millisecond},
+		{timestamp: "01:02:03,004", expected: time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond},
+		{timestamp: "02:03.100", expected: 2*time.Minute + 3100*time.Millisecond},
+		{timestamp: "100:00:00.000", expected: 100 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timestamp, func(t *testing.T) {
+			duration, err := parseTimestamp(tt.timestamp)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, duration)
+		})
+	}
+}